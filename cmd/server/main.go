@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,16 +15,30 @@ import (
 
 	"github.com/sumelms/microservice-course/internal/matrix"
 	"github.com/sumelms/microservice-course/internal/matrix/clients"
+	matrixdomain "github.com/sumelms/microservice-course/internal/matrix/domain"
 
 	"github.com/sumelms/microservice-course/internal/course"
+	coursedomain "github.com/sumelms/microservice-course/internal/course/domain"
+
+	"github.com/sumelms/microservice-course/internal/webhook"
+	webhookdomain "github.com/sumelms/microservice-course/internal/webhook/domain"
 
 	"github.com/go-kit/log"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 
+	"github.com/sumelms/microservice-course/pkg/auth"
 	"github.com/sumelms/microservice-course/pkg/config"
 	database "github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/health"
+	"github.com/sumelms/microservice-course/pkg/idempotency"
+	"github.com/sumelms/microservice-course/pkg/metrics"
+	"github.com/sumelms/microservice-course/pkg/outbox"
 
 	applogger "github.com/sumelms/microservice-course/pkg/logger"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+	"github.com/sumelms/microservice-course/pkg/tracing"
 
 	_ "github.com/lib/pq"
 )
@@ -29,6 +46,7 @@ import (
 var (
 	logger     log.Logger
 	httpServer *http.Server
+	grpcServer *grpc.Server
 )
 
 //nolint:funlen
@@ -44,12 +62,27 @@ func main() {
 		os.Exit(-1)
 	}
 
+	// Tracing, the spans aren't recorded unless it is configured
+	if cfg.Tracing != nil {
+		provider, err := tracing.NewProvider(context.Background(), cfg.Tracing)
+		if err != nil {
+			logger.Log("msg", "unable to start the tracing", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		defer provider.Shutdown(context.Background()) //nolint: errcheck
+	}
+
 	// Database
 	db, err := database.Connect(cfg.Database)
 	if err != nil {
 		logger.Log("msg", "database error", err) //nolint: errcheck
 		os.Exit(1)
 	}
+	database.SetTimeouts(cfg.Database.Timeouts)
+	if err := metrics.RegisterDB(db.DB, cfg.Database.Database); err != nil {
+		logger.Log("msg", "unable to register the database metrics", "error", err) //nolint: errcheck
+		os.Exit(1)
+	}
 
 	// Initialize the domain services
 	svcLogger := log.With(logger, "component", "service")
@@ -59,12 +92,132 @@ func main() {
 		logger.Log("msg", "unable to start course service", err) //nolint: errcheck
 		os.Exit(1)
 	}
-	matrixSvc, err := matrix.NewService(db, svcLogger, clients.NewCourseClient(courseSvc))
+	var matrixRules *config.MatrixRules
+	if cfg.Rules != nil {
+		matrixRules = cfg.Rules.Matrix
+	}
+	var courseClient matrixdomain.CourseClient = clients.NewCourseClient(courseSvc)
+	if cfg.Clients != nil && cfg.Clients.Course != nil {
+		conn, err := clients.DialCourseService(cfg.Clients.Course)
+		if err != nil {
+			logger.Log("msg", "unable to connect to the course service", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		defer conn.Close() //nolint: errcheck
+		courseClient = clients.NewGRPCCourseClient(conn, cfg.Clients.Course, tenant.NewResolver(cfg.Tenancy))
+	}
+	if cfg.Tracing != nil {
+		courseClient = clients.NewTracingCourseClient(courseClient)
+	}
+	matrixSvc, err := matrix.NewService(db, svcLogger, courseClient, matrixRules)
 	if err != nil {
 		logger.Log("msg", "unable to start matrix service", err) //nolint: errcheck
 		os.Exit(1)
 	}
 
+	// Idempotency keys of the create requests
+	var guard *idempotency.Guard
+	purgers := []purger{courseSvc, matrixSvc}
+	if cfg.Idempotency != nil {
+		store, err := idempotency.NewPostgresStore(db)
+		if err != nil {
+			logger.Log("msg", "unable to start the idempotency store", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		guard = idempotency.NewGuard(store, cfg.Idempotency.Window, errors.EncodeError)
+		purgers = append(purgers, guard)
+	}
+
+	// Webhooks receiving the domain events, they are queued by the outbox relay
+	var webhookSvc *webhookdomain.Service
+	if cfg.Webhooks != nil {
+		if cfg.Outbox == nil {
+			logger.Log("msg", "the webhooks require the outbox configuration") //nolint: errcheck
+			os.Exit(1)
+		}
+		webhookSvc, err = webhook.NewService(db, svcLogger, cfg.Webhooks)
+		if err != nil {
+			logger.Log("msg", "unable to start webhook service", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		purgers = append(purgers, webhookSvc)
+	}
+
+	// Relay of the domain events written to the outbox
+	var relay *outbox.Relay
+	if cfg.Outbox != nil {
+		publisher, err := newPublisher(cfg.Outbox)
+		if err != nil {
+			logger.Log("msg", "unable to start the event publisher", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		if closer, ok := publisher.(io.Closer); ok {
+			defer closer.Close() //nolint: errcheck
+		}
+		if webhookSvc != nil {
+			publisher = outbox.NewMultiPublisher(publisher, webhookSvc)
+		}
+		relay, err = outbox.NewRelay(db, publisher, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts, log.With(logger, "component", "outbox"))
+		if err != nil {
+			logger.Log("msg", "unable to start the outbox relay", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		purgers = append(purgers, relay)
+	}
+
+	// Readiness checks of the dependencies, the statements are prepared by now
+	var checker *health.Checker
+	if cfg.Health != nil {
+		newest, err := database.NewestMigration(cfg.Health.Migrations)
+		if err != nil {
+			logger.Log("msg", "unable to read the migrations", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		checker = health.NewChecker(cfg.Health.Timeout)
+		checker.Add("postgres", db.PingContext)
+		checker.Add("statements", database.CheckStatements)
+		checker.Add("migrations", database.CheckMigrations(db, newest))
+	}
+
+	// Authentication
+	var verifier *auth.Verifier
+	if cfg.Auth != nil {
+		verifier, err = auth.NewVerifier(cfg.Auth)
+		if err != nil {
+			logger.Log("msg", "unable to load the authentication keys", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+	}
+
+	// Put the authorization policies in front of the services when callers are authenticated
+	var courseAPI coursedomain.ServiceInterface = courseSvc
+	var matrixAPI matrixdomain.ServiceInterface = matrixSvc
+	var webhookAPI webhookdomain.ServiceInterface = webhookSvc
+	if verifier != nil {
+		courseAPI = coursedomain.NewPolicyService(courseSvc)
+		matrixAPI = matrixdomain.NewPolicyService(matrixSvc, courseClient)
+		webhookAPI = webhookdomain.NewPolicyService(webhookSvc)
+	}
+
+	// Count the calls to the services, the denied ones included
+	requests, latency := metrics.Service("course")
+	courseAPI = coursedomain.NewInstrumentingService(courseAPI, requests, latency)
+	requests, latency = metrics.Service("matrix")
+	matrixAPI = matrixdomain.NewInstrumentingService(matrixAPI, requests, latency)
+	if webhookSvc != nil {
+		requests, latency = metrics.Service("webhook")
+		webhookAPI = webhookdomain.NewInstrumentingService(webhookAPI, requests, latency)
+	}
+
+	// Start the spans of the services, the parents of the ones of the course client and the statements
+	if cfg.Tracing != nil {
+		courseAPI = coursedomain.NewTracingService(courseAPI)
+		matrixAPI = matrixdomain.NewTracingService(matrixAPI)
+		if webhookSvc != nil {
+			webhookAPI = webhookdomain.NewTracingService(webhookAPI)
+		}
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
 	defer signal.Stop(interrupt)
@@ -82,21 +235,38 @@ func main() {
 		// Initializing the HTTP Services
 		httpLogger := log.With(logger, "component", "http")
 
-		if err := course.NewHTTPService(router, courseSvc, httpLogger); err != nil {
+		if err := course.NewHTTPService(router, courseAPI, httpLogger, guard); err != nil {
 			logger.Log("msg", "unable to start a service: course", "error", err) //nolint: errcheck
 			return err
 		}
-		if err := matrix.NewHTTPService(router, matrixSvc, httpLogger); err != nil {
+		if err := matrix.NewHTTPService(router, matrixAPI, httpLogger); err != nil {
 			logger.Log("msg", "unable to start a service: matrix", "error", err) //nolint: errcheck
 			return err
 		}
+		if webhookSvc != nil {
+			if err := webhook.NewHTTPService(router, webhookAPI, httpLogger); err != nil {
+				logger.Log("msg", "unable to start a service: webhook", "error", err) //nolint: errcheck
+				return err
+			}
+		}
 
 		// Handle the mux & router
 		srv := http.NewServeMux()
 		srv.Handle("/", router)
 
 		// Middlewares
-		http.Handle("/", accessControl(srv))
+		var handler http.Handler = tenant.NewHTTPHandler(tenant.NewResolver(cfg.Tenancy), srv, errors.EncodeError)
+		if verifier != nil {
+			handler = auth.NewHTTPHandler(verifier, handler, errors.EncodeError)
+		}
+		http.Handle("/", accessControl(handler))
+		// The metrics are scraped without a tenant or a token
+		http.Handle("/metrics", metrics.Handler())
+		// So are the probes of the orchestrator
+		if checker != nil {
+			http.Handle("/healthz", checker.LiveHandler())
+			http.Handle("/readyz", checker.ReadyHandler())
+		}
 
 		logger.Log("transport", "http", "address", cfg.Server.HTTP.Host, "msg", "listening") //nolint: errcheck
 
@@ -113,6 +283,64 @@ func main() {
 		return nil
 	})
 
+	if cfg.Server.GRPC != nil {
+		grpcLogger := log.With(logger, "component", "grpc")
+
+		// The errors interceptor comes first to encode the failures of the authentication too
+		interceptors := []grpc.UnaryServerInterceptor{errors.UnaryServerInterceptor}
+		if verifier != nil {
+			interceptors = append(interceptors, auth.NewUnaryServerInterceptor(verifier))
+		}
+		interceptors = append(interceptors, tenant.NewUnaryServerInterceptor(tenant.NewResolver(cfg.Tenancy)))
+		grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+
+		if err := course.NewGRPCService(grpcServer, courseAPI, grpcLogger); err != nil {
+			logger.Log("msg", "unable to start a service: course", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+		if err := matrix.NewGRPCService(grpcServer, matrixAPI, grpcLogger); err != nil {
+			logger.Log("msg", "unable to start a service: matrix", "error", err) //nolint: errcheck
+			os.Exit(1)
+		}
+
+		g.Go(func() error {
+			listener, err := net.Listen("tcp", cfg.Server.GRPC.Host)
+			if err != nil {
+				return err
+			}
+
+			logger.Log("transport", "grpc", "address", cfg.Server.GRPC.Host, "msg", "listening") //nolint: errcheck
+
+			return grpcServer.Serve(listener)
+		})
+	}
+
+	if relay != nil {
+		g.Go(func() error {
+			relay.Run(ctx, cfg.Outbox.Interval)
+			return nil
+		})
+		// The expiration of the subscriptions is only told to the other services by its event
+		g.Go(func() error {
+			expireSubscriptions(ctx, cfg.Outbox.Interval, courseSvc)
+			return nil
+		})
+	}
+
+	if webhookSvc != nil {
+		g.Go(func() error {
+			webhookSvc.Run(ctx, cfg.Webhooks.Interval)
+			return nil
+		})
+	}
+
+	if cfg.Retention != nil {
+		g.Go(func() error {
+			purgeDeleted(ctx, cfg.Retention, purgers...)
+			return nil
+		})
+	}
+
 	select {
 	case <-interrupt:
 		break
@@ -122,6 +350,12 @@ func main() {
 
 	logger.Log("msg", "received shutdown signal") //nolint: errcheck
 
+	// Stop being ready first, the orchestrator drains the traffic before the server shuts down
+	if checker != nil {
+		checker.Drain()
+		time.Sleep(cfg.Health.DrainDelay)
+	}
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
 
@@ -132,6 +366,24 @@ func main() {
 		}
 	}
 
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+			logger.Log("msg", "grpc server wasn't gracefully shutdown") //nolint: errcheck
+			defer os.Exit(2)
+		}
+	}
+
+	cancel()
+
 	if err := g.Wait(); err != nil {
 		logger.Log("msg", "server returning an error", "error", err) //nolint: errcheck
 		defer os.Exit(2)
@@ -155,11 +407,61 @@ func loadConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
+type purger interface {
+	PurgeDeleted(ctx context.Context, retention time.Duration) error
+}
+
+func purgeDeleted(ctx context.Context, cfg *config.Retention, services ...purger) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, svc := range services {
+				if err := svc.PurgeDeleted(ctx, cfg.Period); err != nil {
+					logger.Log("msg", "unable to purge deleted records", "error", err) //nolint: errcheck
+				}
+			}
+		}
+	}
+}
+
+func newPublisher(cfg *config.Outbox) (outbox.Publisher, error) {
+	if cfg.Publisher != "nats" {
+		return outbox.NewMemoryPublisher(), nil
+	}
+	if cfg.NATS == nil {
+		return nil, fmt.Errorf("the nats publisher requires the nats configuration")
+	}
+	return outbox.NewNATSPublisher(cfg.NATS.URL, cfg.NATS.Subject)
+}
+
+func expireSubscriptions(ctx context.Context, interval time.Duration, svc *coursedomain.Service) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.ExpireSubscriptions(ctx); err != nil {
+				logger.Log("msg", "unable to expire subscriptions", "error", err) //nolint: errcheck
+			}
+		}
+	}
+}
+
 func accessControl(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, PUT, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, PUT, PATCH, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, If-Match, If-None-Match, "+
+			idempotency.Header+", "+tenant.DefaultHeader)
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, "+idempotency.ReplayedHeader)
 
 		if r.Method == "OPTIONS" {
 			return
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX courses_status_index;
+
+ALTER TABLE courses
+    DROP COLUMN status,
+    DROP COLUMN status_changed_by,
+    DROP COLUMN status_changed_at;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE courses
+    ADD COLUMN status               varchar         DEFAULT 'draft' NOT NULL,
+    ADD COLUMN status_changed_by    uuid            NULL,
+    ADD COLUMN status_changed_at    timestamp       NULL;
+
+CREATE INDEX courses_status_index
+    ON courses (status);
+
+COMMIT;
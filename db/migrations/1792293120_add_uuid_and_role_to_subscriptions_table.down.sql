@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX subscriptions_course_id_role_index;
+
+ALTER TABLE subscriptions
+    ALTER COLUMN role DROP NOT NULL,
+    ALTER COLUMN role DROP DEFAULT;
+
+DROP INDEX subscriptions_uuid_uindex;
+
+ALTER TABLE subscriptions
+    DROP COLUMN uuid;
+
+COMMIT;
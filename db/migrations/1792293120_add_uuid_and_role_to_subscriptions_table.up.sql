@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+ALTER TABLE subscriptions
+    ADD COLUMN uuid uuid DEFAULT uuid_generate_v4() NOT NULL;
+
+CREATE UNIQUE INDEX subscriptions_uuid_uindex
+    ON subscriptions (uuid);
+
+UPDATE subscriptions SET role = 'student' WHERE role IS NULL;
+
+ALTER TABLE subscriptions
+    ALTER COLUMN role SET DEFAULT 'student',
+    ALTER COLUMN role SET NOT NULL;
+
+CREATE INDEX subscriptions_course_id_role_index
+    ON subscriptions (course_id, role);
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+DROP INDEX matrix_subjects_matrix_id_subject_id_uindex;
+
+ALTER TABLE matrix_subjects
+    ADD COLUMN subject_bigid bigint NULL,
+    ADD COLUMN matrix_bigid  bigint NULL;
+
+UPDATE matrix_subjects ms
+    SET subject_bigid = s.id
+    FROM subjects s
+    WHERE s.uuid = ms.subject_id;
+
+UPDATE matrix_subjects ms
+    SET matrix_bigid = m.id
+    FROM matrices m
+    WHERE m.uuid = ms.matrix_id;
+
+DELETE FROM matrix_subjects WHERE subject_bigid IS NULL OR matrix_bigid IS NULL;
+
+ALTER TABLE matrix_subjects
+    DROP COLUMN subject_id,
+    DROP COLUMN matrix_id;
+
+ALTER TABLE matrix_subjects RENAME COLUMN subject_bigid TO subject_id;
+ALTER TABLE matrix_subjects RENAME COLUMN matrix_bigid TO matrix_id;
+
+ALTER TABLE matrix_subjects
+    ALTER COLUMN subject_id SET NOT NULL,
+    ALTER COLUMN matrix_id SET NOT NULL,
+    ALTER COLUMN is_required DROP NOT NULL;
+
+COMMIT;
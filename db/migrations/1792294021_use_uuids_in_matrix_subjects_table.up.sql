@@ -0,0 +1,35 @@
+BEGIN;
+
+ALTER TABLE matrix_subjects
+    ADD COLUMN subject_uuid uuid NULL,
+    ADD COLUMN matrix_uuid  uuid NULL;
+
+UPDATE matrix_subjects ms
+    SET subject_uuid = s.uuid
+    FROM subjects s
+    WHERE s.id = ms.subject_id;
+
+UPDATE matrix_subjects ms
+    SET matrix_uuid = m.uuid
+    FROM matrices m
+    WHERE m.id = ms.matrix_id;
+
+DELETE FROM matrix_subjects WHERE subject_uuid IS NULL OR matrix_uuid IS NULL;
+
+ALTER TABLE matrix_subjects
+    DROP COLUMN subject_id,
+    DROP COLUMN matrix_id;
+
+ALTER TABLE matrix_subjects RENAME COLUMN subject_uuid TO subject_id;
+ALTER TABLE matrix_subjects RENAME COLUMN matrix_uuid TO matrix_id;
+
+ALTER TABLE matrix_subjects
+    ALTER COLUMN subject_id SET NOT NULL,
+    ALTER COLUMN matrix_id SET NOT NULL,
+    ALTER COLUMN is_required SET NOT NULL;
+
+CREATE UNIQUE INDEX matrix_subjects_matrix_id_subject_id_uindex
+    ON matrix_subjects (matrix_id, subject_id)
+    WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE matrix_requisites;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE matrix_requisites
+(
+    id              bigserial       CONSTRAINT matrix_requisites_pk PRIMARY KEY,
+    matrix_id       uuid            NOT NULL,
+    subject_id      uuid            NOT NULL,
+    requisite_id    uuid            NOT NULL,
+    kind            varchar         NOT NULL DEFAULT 'prerequisite',
+    created_at      timestamp       DEFAULT now() NOT NULL,
+    updated_at      timestamp       DEFAULT now() NOT NULL,
+    CONSTRAINT matrix_requisites_kind_check CHECK (kind IN ('prerequisite', 'corequisite')),
+    CONSTRAINT matrix_requisites_self_check CHECK (subject_id <> requisite_id)
+);
+
+CREATE UNIQUE INDEX matrix_requisites_matrix_id_subject_id_requisite_id_uindex
+    ON matrix_requisites (matrix_id, subject_id, requisite_id);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX matrices_status_index;
+
+ALTER TABLE matrices
+    DROP COLUMN status,
+    DROP COLUMN published_at;
+
+COMMIT;
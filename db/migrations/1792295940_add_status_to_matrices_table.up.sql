@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE matrices
+    ADD COLUMN status           varchar         DEFAULT 'draft' NOT NULL,
+    ADD COLUMN published_at     timestamp       NULL;
+
+CREATE INDEX matrices_status_index
+    ON matrices (status);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE subject_completions
+(
+    id                  bigserial       CONSTRAINT subject_completions_pk PRIMARY KEY,
+    subscription_id     uuid            NOT NULL,
+    subject_id          uuid            NOT NULL,
+    status              varchar         DEFAULT 'in_progress' NOT NULL,
+    grade               numeric(5, 2)   NULL,
+    completed_at        timestamp       NULL,
+    created_at          timestamp       DEFAULT now() NOT NULL,
+    updated_at          timestamp       DEFAULT now() NOT NULL,
+    CONSTRAINT subject_completions_status_check CHECK (status IN ('in_progress', 'passed', 'failed'))
+);
+
+CREATE UNIQUE INDEX subject_completions_subscription_id_subject_id_uindex
+    ON subject_completions (subscription_id, subject_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+DROP INDEX courses_tenant_id_code_uindex;
+DROP INDEX matrices_tenant_id_code_uindex;
+DROP INDEX subjects_tenant_id_code_uindex;
+
+ALTER TABLE courses ADD CONSTRAINT courses_code_key UNIQUE (code);
+ALTER TABLE matrices ADD CONSTRAINT matrices_code_key UNIQUE (code);
+ALTER TABLE subjects ADD CONSTRAINT subjects_code_key UNIQUE (code);
+
+ALTER TABLE courses DROP COLUMN tenant_id;
+ALTER TABLE subscriptions DROP COLUMN tenant_id;
+ALTER TABLE matrices DROP COLUMN tenant_id;
+ALTER TABLE subjects DROP COLUMN tenant_id;
+ALTER TABLE matrix_subjects DROP COLUMN tenant_id;
+ALTER TABLE matrix_requisites DROP COLUMN tenant_id;
+ALTER TABLE subject_completions DROP COLUMN tenant_id;
+
+COMMIT;
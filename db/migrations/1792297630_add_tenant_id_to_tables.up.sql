@@ -0,0 +1,34 @@
+BEGIN;
+
+ALTER TABLE courses ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE subscriptions ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE matrices ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE subjects ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE matrix_subjects ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE matrix_requisites ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+ALTER TABLE subject_completions ADD COLUMN tenant_id varchar DEFAULT 'default' NOT NULL;
+
+ALTER TABLE courses DROP CONSTRAINT courses_code_key;
+ALTER TABLE matrices DROP CONSTRAINT matrices_code_key;
+ALTER TABLE subjects DROP CONSTRAINT subjects_code_key;
+
+CREATE UNIQUE INDEX courses_tenant_id_code_uindex
+    ON courses (tenant_id, code)
+    WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX matrices_tenant_id_code_uindex
+    ON matrices (tenant_id, code)
+    WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX subjects_tenant_id_code_uindex
+    ON subjects (tenant_id, code)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX subscriptions_tenant_id_index
+    ON subscriptions (tenant_id);
+CREATE INDEX matrix_subjects_tenant_id_index
+    ON matrix_subjects (tenant_id);
+CREATE INDEX matrix_requisites_tenant_id_index
+    ON matrix_requisites (tenant_id);
+CREATE INDEX subject_completions_tenant_id_index
+    ON subject_completions (tenant_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE courses DROP COLUMN version;
+ALTER TABLE subscriptions DROP COLUMN version;
+ALTER TABLE matrices DROP COLUMN version;
+ALTER TABLE subjects DROP COLUMN version;
+
+COMMIT;
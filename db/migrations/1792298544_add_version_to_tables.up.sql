@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE courses ADD COLUMN version integer DEFAULT 1 NOT NULL;
+ALTER TABLE subscriptions ADD COLUMN version integer DEFAULT 1 NOT NULL;
+ALTER TABLE matrices ADD COLUMN version integer DEFAULT 1 NOT NULL;
+ALTER TABLE subjects ADD COLUMN version integer DEFAULT 1 NOT NULL;
+
+COMMIT;
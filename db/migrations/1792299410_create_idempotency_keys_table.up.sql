@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE idempotency_keys
+(
+    id              bigserial       CONSTRAINT idempotency_keys_pk PRIMARY KEY,
+    tenant_id       varchar         NOT NULL,
+    owner           varchar         NOT NULL,
+    key             varchar(255)    NOT NULL,
+    fingerprint     varchar(64)     NOT NULL,
+    status          integer         NULL,
+    content_type    varchar         DEFAULT '' NOT NULL,
+    body            bytea           NULL,
+    created_at      timestamp       DEFAULT now() NOT NULL,
+    expires_at      timestamp       NOT NULL
+);
+
+CREATE UNIQUE INDEX idempotency_keys_tenant_id_owner_key_uindex
+    ON idempotency_keys (tenant_id, owner, key);
+
+CREATE INDEX idempotency_keys_expires_at_index
+    ON idempotency_keys (expires_at);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE outbox
+(
+    id              bigserial       CONSTRAINT outbox_pk PRIMARY KEY,
+    uuid            uuid            DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id       varchar         NOT NULL,
+    type            varchar         NOT NULL,
+    aggregate_id    uuid            NOT NULL,
+    payload         jsonb           NOT NULL,
+    attempts        integer         DEFAULT 0 NOT NULL,
+    last_error      text            NULL,
+    created_at      timestamp       DEFAULT now() NOT NULL,
+    published_at    timestamp       NULL
+);
+
+CREATE UNIQUE INDEX outbox_uuid_uindex
+    ON outbox (uuid);
+
+CREATE INDEX outbox_pending_index
+    ON outbox (id) WHERE published_at IS NULL;
+
+COMMIT;
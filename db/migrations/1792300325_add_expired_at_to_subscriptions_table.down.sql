@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS expired_at;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE subscriptions
+    ADD COLUMN expired_at   timestamp   NULL;
+
+COMMIT;
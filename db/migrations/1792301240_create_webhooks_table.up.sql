@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE webhooks
+(
+    id              bigserial       CONSTRAINT webhooks_pk PRIMARY KEY,
+    uuid            uuid            DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id       varchar         NOT NULL,
+    url             varchar         NOT NULL,
+    secret          varchar         NOT NULL,
+    event_types     varchar[]       NOT NULL,
+    active          boolean         DEFAULT true NOT NULL,
+    version         integer         DEFAULT 1 NOT NULL,
+    created_at      timestamp       DEFAULT now() NOT NULL,
+    updated_at      timestamp       DEFAULT now() NOT NULL,
+    deleted_at      timestamp
+);
+
+CREATE UNIQUE INDEX webhooks_uuid_uindex
+    ON webhooks (uuid);
+CREATE INDEX webhooks_tenant_id_index
+    ON webhooks (tenant_id);
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE TABLE webhook_deliveries
+(
+    id                  bigserial       CONSTRAINT webhook_deliveries_pk PRIMARY KEY,
+    uuid                uuid            DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id           varchar         NOT NULL,
+    webhook_id          uuid            NOT NULL,
+    event_id            uuid            NOT NULL,
+    event_type          varchar         NOT NULL,
+    payload             jsonb           NOT NULL,
+    status              varchar         DEFAULT 'pending' NOT NULL,
+    attempts            integer         DEFAULT 0 NOT NULL,
+    next_attempt_at     timestamp       DEFAULT now() NOT NULL,
+    response_status     integer         NULL,
+    last_error          text            NULL,
+    delivered_at        timestamp       NULL,
+    created_at          timestamp       DEFAULT now() NOT NULL,
+    updated_at          timestamp       DEFAULT now() NOT NULL,
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'failed', 'succeeded', 'dead'))
+);
+
+CREATE UNIQUE INDEX webhook_deliveries_uuid_uindex
+    ON webhook_deliveries (uuid);
+CREATE UNIQUE INDEX webhook_deliveries_webhook_id_event_id_uindex
+    ON webhook_deliveries (webhook_id, event_id);
+CREATE INDEX webhook_deliveries_due_index
+    ON webhook_deliveries (next_attempt_at) WHERE status IN ('pending', 'failed');
+
+COMMIT;
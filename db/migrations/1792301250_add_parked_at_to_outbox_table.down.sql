@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS outbox_pending_index;
+
+CREATE INDEX outbox_pending_index
+    ON outbox (id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS parked_at;
+
+COMMIT;
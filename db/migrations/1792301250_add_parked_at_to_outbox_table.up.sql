@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE outbox
+    ADD COLUMN parked_at    timestamp   NULL;
+
+DROP INDEX IF EXISTS outbox_pending_index;
+
+CREATE INDEX outbox_pending_index
+    ON outbox (id) WHERE published_at IS NULL AND parked_at IS NULL;
+
+COMMIT;
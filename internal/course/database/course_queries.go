@@ -1,23 +1,37 @@
 package database
 
 const (
-	createCourse = "create course"
-	deleteCourse = "delete course by uuid"
-	getCourse    = "get course by uuid"
-	listCourse   = "list course"
-	updateCourse = "update course by uuid"
+	createCourse  = "create course"
+	deleteCourse  = "delete course by uuid"
+	getCourse     = "get course by uuid"
+	updateCourse  = "update course by uuid"
+	restoreCourse = "restore course by uuid"
+	purgeCourses  = "purge deleted courses"
+	updateStatus  = "update course status by uuid"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listCourses = "list courses"
+	patchCourse = "patch course by uuid"
+
+	coursesTable = "courses"
 )
 
 func queriesCourse() map[string]string {
 	return map[string]string{
 		createCourse: `INSERT INTO 
-    		courses (code, name, underline, image, image_cover, excerpt, description)
-			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`,
-		deleteCourse: "UPDATE courses SET deleted_at = NOW() WHERE uuid = $1",
-		getCourse:    "SELECT * FROM courses WHERE uuid = $1",
-		listCourse:   "SELECT * FROM courses",
+    		courses (code, name, underline, image, image_cover, excerpt, description, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`,
+		deleteCourse: `UPDATE courses SET deleted_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL`,
+		getCourse: "SELECT * FROM courses WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NULL",
 		updateCourse: `UPDATE courses 
-			SET code = $1, name = $2, underline = $3, image = $4, image_cover = $5, excerpt = $6, description = $7 
-			WHERE uuid = $8 RETURNING *`,
+			SET code = $1, name = $2, underline = $3, image = $4, image_cover = $5, excerpt = $6, description = $7, 
+			version = version + 1 
+			WHERE uuid = $8 AND tenant_id = $9 AND ($10 = 0 OR version = $10) AND deleted_at IS NULL RETURNING *`,
+		restoreCourse: `UPDATE courses SET deleted_at = NULL, version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL RETURNING *`,
+		purgeCourses: "DELETE FROM courses WHERE deleted_at < $1",
+		updateStatus: `UPDATE courses SET status = $1, status_changed_by = $2, status_changed_at = NOW(), version = version + 1 
+			WHERE uuid = $3 AND status = $4 AND tenant_id = $5 AND deleted_at IS NULL RETURNING *`,
 	}
 }
@@ -1,97 +1,304 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 )
 
 func NewCourseRepository(db *sqlx.DB) (courseRepository, error) { //nolint: revive
 	sqlStatements := make(map[string]*sqlx.Stmt)
 
 	for queryName, query := range queriesCourse() {
-		stmt, err := db.Preparex(query)
+		stmt, err := postgres.Prepare(db, queryName, query)
 		if err != nil {
-			return courseRepository{}, errors.WrapErrorf(err, errors.ErrCodeUnknown,
-				"error preparing statement %s", queryName)
+			return courseRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
 		}
 		sqlStatements[queryName] = stmt
 	}
 
 	return courseRepository{
+		db:         db,
 		statements: sqlStatements,
 	}, nil
 }
 
 type courseRepository struct {
+	db         *sqlx.DB
 	statements map[string]*sqlx.Stmt
 }
 
 // Course get the Course by given id
-func (r courseRepository) Course(id uuid.UUID) (domain.Course, error) {
+func (r courseRepository) Course(ctx context.Context, id uuid.UUID) (domain.Course, error) {
 	stmt, ok := r.statements[getCourse]
 	if !ok {
 		return domain.Course{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getCourse)
 	}
+	ctx, end := postgres.StartStatement(ctx, getCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Course{}, err
+	}
 
 	var c domain.Course
-	if err := stmt.Get(&c, id); err != nil {
-		return domain.Course{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting course")
+	if err := stmt.GetContext(ctx, &c, id, tenantID); err != nil {
+		return domain.Course{}, errors.WrapDatabaseErrorf(err, "error getting course")
 	}
 	return c, nil
 }
 
-// Courses list all courses
-func (r courseRepository) Courses() ([]domain.Course, error) {
-	stmt, ok := r.statements[listCourse]
-	if !ok {
-		return []domain.Course{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listCourse)
+// Courses list a page of courses matching the given query
+func (r courseRepository) Courses(ctx context.Context, q pagination.Query) ([]domain.Course, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listCourses)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Course{}, pagination.Page{}, err
 	}
 
+	query, args := pagination.Select(coursesTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenantID}})
+
 	var cc []domain.Course
-	if err := stmt.Select(&cc); err != nil {
-		return []domain.Course{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting course")
+	if err := r.db.SelectContext(ctx, &cc, query, args...); err != nil {
+		return []domain.Course{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting courses")
 	}
-	return cc, nil
+	return pagination.Paginate(q, cc)
 }
 
 // CreateCourse creates a new course
-func (r courseRepository) CreateCourse(c *domain.Course) error {
+func (r courseRepository) CreateCourse(ctx context.Context, c *domain.Course) error {
 	stmt, ok := r.statements[createCourse]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createCourse)
 	}
+	ctx, end := postgres.StartStatement(ctx, createCourse)
+	defer end()
 
-	if err := stmt.Get(c, c.Code, c.Name, c.Underline, c.Image, c.ImageCover, c.Excerpt, c.Description); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error creating course")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, c,
+			c.Code, c.Name, c.Underline, c.Image, c.ImageCover, c.Excerpt, c.Description, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error creating course")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventCourseCreated, tenantID, c.UUID, c)}, nil
+	})
 }
 
 // UpdateCourse update the given course
-func (r courseRepository) UpdateCourse(c *domain.Course) error {
+func (r courseRepository) UpdateCourse(ctx context.Context, c *domain.Course) error {
 	stmt, ok := r.statements[updateCourse]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateCourse)
 	}
+	ctx, end := postgres.StartStatement(ctx, updateCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, c,
+			c.Code, c.Name, c.Underline, c.Image, c.ImageCover, c.Excerpt, c.Description, c.UUID, tenantID, expected); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, c.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error updating course")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventCourseUpdated, tenantID, c.UUID, c)}, nil
+	})
+}
+
+// PatchCourse writes only the given fields of the course
+func (r courseRepository) PatchCourse(ctx context.Context, c *domain.Course, fields []string) error {
+	ctx, end := postgres.StartStatement(ctx, patchCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 
-	if err := stmt.Get(c, c.Code, c.Name, c.Underline, c.Image, c.ImageCover, c.Excerpt, c.Description, c.UUID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error updating course")
+	columns := map[string]interface{}{
+		"name":        c.Name,
+		"underline":   c.Underline,
+		"image":       c.Image,
+		"image_cover": c.ImageCover,
+		"excerpt":     c.Excerpt,
+		"description": c.Description,
 	}
-	return nil
+	expected := etag.Expected(ctx)
+	query, args, err := patch.Update(coursesTable, columns, fields, c.UUID, tenantID, expected)
+	if err != nil {
+		return err
+	}
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.GetContext(ctx, c, query, args...); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, c.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error patching course")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventCourseUpdated, tenantID, c.UUID, c)}, nil
+	})
 }
 
 // DeleteCourse soft delete the course by given id
-func (r courseRepository) DeleteCourse(id uuid.UUID) error {
+func (r courseRepository) DeleteCourse(ctx context.Context, id uuid.UUID) error {
 	stmt, ok := r.statements[deleteCourse]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteCourse)
 	}
+	ctx, end := postgres.StartStatement(ctx, deleteCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, id, tenantID, expected)
+		if err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error deleting course")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, id)
+			}
+			return nil, nil
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventCourseDeleted, tenantID, id, deleted{UUID: id})}, nil
+	})
+}
+
+// RestoreCourse restores the soft deleted course by given id
+func (r courseRepository) RestoreCourse(ctx context.Context, id uuid.UUID) (domain.Course, error) {
+	stmt, ok := r.statements[restoreCourse]
+	if !ok {
+		return domain.Course{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", restoreCourse)
+	}
+	ctx, end := postgres.StartStatement(ctx, restoreCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Course{}, err
+	}
+
+	var c domain.Course
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &c, id, tenantID); err != nil {
+			if errors.DatabaseCode(err) == errors.ErrCodeConflict {
+				return nil, errors.WrapErrorf(err, errors.ErrCodeConflict, "course %s code is taken by another course", id)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error restoring course")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventCourseRestored, tenantID, c.UUID, &c)}, nil
+	})
+	if err != nil {
+		return domain.Course{}, err
+	}
+	return c, nil
+}
+
+// PurgeCourses permanently deletes the courses soft deleted before the given time
+func (r courseRepository) PurgeCourses(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := r.statements[purgeCourses]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeCourses)
+	}
+	ctx, end := postgres.StartStatement(ctx, purgeCourses)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging courses")
+	}
+	return res.RowsAffected()
+}
+
+// UpdateCourseStatus moves the course to a new status, provided it is still in the expected one
+func (r courseRepository) UpdateCourseStatus(ctx context.Context, id uuid.UUID, from, to domain.CourseStatus, changedBy *uuid.UUID) (domain.Course, error) {
+	stmt, ok := r.statements[updateStatus]
+	if !ok {
+		return domain.Course{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateStatus)
+	}
+	ctx, end := postgres.StartStatement(ctx, updateStatus)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Course{}, err
+	}
 
-	if _, err := stmt.Exec(id); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error deleting course")
+	var c domain.Course
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &c, to, changedBy, id, from, tenantID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.NewErrorf(errors.ErrCodeConflict, "course %s is no longer %s", id, from)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error updating course status")
+		}
+
+		events := []outbox.Event{outbox.NewEvent(domain.EventCourseStatusChanged, tenantID, c.UUID, &c)}
+		if to == domain.CoursePublished {
+			events = append(events, outbox.NewEvent(domain.EventCoursePublished, tenantID, c.UUID, &c))
+		}
+		return events, nil
+	})
+	if err != nil {
+		return domain.Course{}, err
+	}
+	return c, nil
+}
+
+// versionMismatch tells a course changed since the expected version apart from a missing one,
+// the course is read within the transaction of the failed change
+func (r courseRepository) versionMismatch(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
+	stmt, ok := r.statements[getCourse]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getCourse)
+	}
+	ctx, end := postgres.StartStatement(ctx, getCourse)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	var current domain.Course
+	if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &current, id, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error getting course")
+	}
+	return errors.NewErrorf(errors.ErrCodePreconditionFailed, "course %s was changed, it is at version %d", id, current.Version)
+}
+
+// deleted is the payload of the events of the deleted records
+type deleted struct {
+	UUID uuid.UUID `json:"uuid"`
 }
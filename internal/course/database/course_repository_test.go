@@ -1,19 +1,27 @@
 package database
 
 import (
+	"context"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 	utils "github.com/sumelms/microservice-course/tests"
 )
 
 var (
-	course = domain.Course{
+	tenantCtx = tenant.NewContext(context.Background(), tenant.DefaultTenant)
+	course    = domain.Course{
 		ID:          1,
 		UUID:        utils.CourseUUID,
 		Code:        "SUME123",
@@ -23,6 +31,7 @@ var (
 		ImageCover:  "image_cover.png",
 		Excerpt:     "Course Excerpt",
 		Description: "Course Description",
+		Status:      domain.CoursePublished,
 		CreatedAt:   utils.Now,
 		UpdatedAt:   utils.Now,
 		DeletedAt:   nil,
@@ -35,9 +44,9 @@ func newCourseTestDB() (*sqlx.DB, sqlmock.Sqlmock, map[string]*sqlmock.ExpectedP
 
 func TestRepository_Course(t *testing.T) {
 	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "underline", "image", "image_cover", "excerpt",
-		"description", "created_at", "updated_at", "deleted_at"}).
+		"description", "status", "created_at", "updated_at", "deleted_at"}).
 		AddRow(course.ID, course.UUID, course.Code, course.Name, course.Underline, course.Image, course.ImageCover,
-			course.Excerpt, course.Description, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
+			course.Excerpt, course.Description, course.Status, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
 
 	type args struct {
 		id uuid.UUID
@@ -81,9 +90,9 @@ func TestRepository_Course(t *testing.T) {
 				t.Fatalf("prepared statement %s not found", getCourse)
 			}
 
-			prep.ExpectQuery().WithArgs(utils.CourseUUID).WillReturnRows(validRows)
+			prep.ExpectQuery().WithArgs(utils.CourseUUID, tenant.DefaultTenant).WillReturnRows(validRows)
 
-			got, err := r.Course(tt.args.id)
+			got, err := r.Course(tenantCtx, tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Course() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -97,12 +106,12 @@ func TestRepository_Course(t *testing.T) {
 
 func TestRepository_Courses(t *testing.T) {
 	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "underline", "image", "image_cover", "excerpt",
-		"description", "created_at", "updated_at", "deleted_at"}).
+		"description", "status", "created_at", "updated_at", "deleted_at"}).
 		AddRow(course.ID, course.UUID, course.Code, course.Name, course.Underline, course.Image, course.ImageCover,
-			course.Excerpt, course.Description, course.CreatedAt, course.UpdatedAt, course.DeletedAt).
+			course.Excerpt, course.Description, course.Status, course.CreatedAt, course.UpdatedAt, course.DeletedAt).
 		AddRow(2, uuid.MustParse("7aec21ad-2fa8-4ddd-b5af-073144031ecc"), course.Code, course.Name,
-			course.Underline, course.Image, course.ImageCover, course.Excerpt, course.Description, course.CreatedAt,
-			course.UpdatedAt, course.DeletedAt)
+			course.Underline, course.Image, course.ImageCover, course.Excerpt, course.Description, course.Status,
+			course.CreatedAt, course.UpdatedAt, course.DeletedAt)
 
 	tests := []struct {
 		name    string
@@ -129,19 +138,17 @@ func TestRepository_Courses(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newCourseTestDB()
+			db, mock, _ := newCourseTestDB()
 			r, err := NewCourseRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the courseRepository", err)
 			}
-			prep, ok := stmts[listCourse]
-			if !ok {
-				t.Fatalf("prepared statement %s not found", getCourse)
-			}
 
-			prep.ExpectQuery().WillReturnRows(tt.rows)
+			q := pagination.NewQuery()
+			query, _ := pagination.Select(coursesTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenant.DefaultTenant}})
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(tt.rows)
 
-			got, err := r.Courses()
+			got, _, err := r.Courses(tenantCtx, q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Courses() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -155,9 +162,9 @@ func TestRepository_Courses(t *testing.T) {
 
 func TestRepository_CreateCourse(t *testing.T) {
 	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "underline", "image", "image_cover", "excerpt",
-		"description", "created_at", "updated_at", "deleted_at"}).
+		"description", "status", "created_at", "updated_at", "deleted_at"}).
 		AddRow(course.ID, course.UUID, course.Code, course.Name, course.Underline, course.Image, course.ImageCover,
-			course.Excerpt, course.Description, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
+			course.Excerpt, course.Description, course.Status, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
 
 	type args struct {
 		c *domain.Course
@@ -188,7 +195,8 @@ func TestRepository_CreateCourse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newCourseTestDB()
+			db, mock, stmts := newCourseTestDB()
+			utils.ExpectOutboxTx(mock)
 			r, err := NewCourseRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the courseRepository", err)
@@ -200,7 +208,7 @@ func TestRepository_CreateCourse(t *testing.T) {
 
 			prep.ExpectQuery().WillReturnRows(tt.rows)
 
-			if err := r.CreateCourse(tt.args.c); (err != nil) != tt.wantErr {
+			if err := r.CreateCourse(tenantCtx, tt.args.c); (err != nil) != tt.wantErr {
 				t.Errorf("CreateCourse() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -209,9 +217,9 @@ func TestRepository_CreateCourse(t *testing.T) {
 
 func TestRepository_UpdateCourse(t *testing.T) {
 	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "underline", "image", "image_cover", "excerpt",
-		"description", "created_at", "updated_at", "deleted_at"}).
+		"description", "status", "created_at", "updated_at", "deleted_at"}).
 		AddRow(course.ID, course.UUID, course.Code, course.Name, course.Underline, course.Image, course.ImageCover,
-			course.Excerpt, course.Description, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
+			course.Excerpt, course.Description, course.Status, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
 
 	type args struct {
 		c *domain.Course
@@ -238,10 +246,12 @@ func TestRepository_UpdateCourse(t *testing.T) {
 	}
 
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newCourseTestDB()
+			db, mock, stmts := newCourseTestDB()
+			utils.ExpectOutboxTx(mock)
 			r, err := NewCourseRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the courseRepository", err)
@@ -253,9 +263,112 @@ func TestRepository_UpdateCourse(t *testing.T) {
 
 			prep.ExpectQuery().WillReturnRows(tt.rows)
 
-			if err := r.UpdateCourse(tt.args.c); (err != nil) != tt.wantErr {
+			if err := r.UpdateCourse(tenantCtx, tt.args.c); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateCourse() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestRepository_UpdateCourseVersionMismatch(t *testing.T) {
+	currentRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "version"}).
+		AddRow(course.ID, course.UUID, course.Code, course.Name, 4)
+
+	db, mock, stmts := newCourseTestDB()
+	utils.ExpectOutboxTx(mock)
+	r, err := NewCourseRepository(db)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the courseRepository", err)
+	}
+
+	stmts[updateCourse].ExpectQuery().WillReturnRows(utils.EmptyRows)
+	stmts[getCourse].ExpectQuery().WithArgs(course.UUID, tenant.DefaultTenant).WillReturnRows(currentRows)
+
+	c := course
+	err = r.UpdateCourse(etag.NewContext(tenantCtx, 3), &c)
+	if ierr, ok := err.(*errors.Error); !ok || ierr.Code() != errors.ErrCodePreconditionFailed {
+		t.Errorf("UpdateCourse() error = %v, want a failed precondition", err)
+	}
+}
+
+func TestRepository_RestoreCourse(t *testing.T) {
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "underline", "image", "image_cover", "excerpt",
+		"description", "status", "created_at", "updated_at", "deleted_at"}).
+		AddRow(course.ID, course.UUID, course.Code, course.Name, course.Underline, course.Image, course.ImageCover,
+			course.Excerpt, course.Description, course.Status, course.CreatedAt, course.UpdatedAt, course.DeletedAt)
+
+	type args struct {
+		id uuid.UUID
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		rows     *sqlmock.Rows
+		queryErr error
+		want     domain.Course
+		wantCode errors.ErrorCode
+		wantErr  bool
+	}{
+		{
+			name:    "restore course",
+			args:    args{id: course.UUID},
+			rows:    validRows,
+			want:    course,
+			wantErr: false,
+		},
+		{
+			name:     "course not deleted error",
+			args:     args{id: course.UUID},
+			rows:     utils.EmptyRows,
+			want:     domain.Course{},
+			wantCode: errors.ErrCodeNotFound,
+			wantErr:  true,
+		},
+		{
+			name:     "course code taken",
+			args:     args{id: course.UUID},
+			queryErr: &pq.Error{Code: "23505"},
+			want:     domain.Course{},
+			wantCode: errors.ErrCodeConflict,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, stmts := newCourseTestDB()
+			utils.ExpectOutboxTx(mock)
+			r, err := NewCourseRepository(db)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when creating the courseRepository", err)
+			}
+			prep, ok := stmts[restoreCourse]
+			if !ok {
+				t.Fatalf("prepared statement %s not found", restoreCourse)
+			}
+
+			query := prep.ExpectQuery().WithArgs(tt.args.id, tenant.DefaultTenant)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			got, err := r.RestoreCourse(tenantCtx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RestoreCourse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if code := errors.CodeOf(err); tt.wantErr && code != tt.wantCode {
+				t.Errorf("RestoreCourse() error code = %v, want %v", code, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RestoreCourse() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,36 @@
 package database
 
 const (
-	createSubscription = "create subscription"
-	deleteSubscription = "delete subscription by uuid"
-	getSubscription    = "get subscription by uuid"
-	listSubscription   = "list subscriptions"
-	updateSubscription = "update subscription by uuid"
+	createSubscription  = "create subscription"
+	deleteSubscription  = "delete subscription by uuid"
+	getSubscription     = "get subscription by uuid"
+	updateSubscription  = "update subscription by uuid"
+	restoreSubscription = "restore subscription by uuid"
+	purgeSubscriptions  = "purge deleted subscriptions"
+	expireSubscriptions = "expire subscriptions"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listSubscriptions = "list subscriptions"
+	patchSubscription = "patch subscription by uuid"
+
+	subscriptionsTable = "subscriptions"
 )
 
 func queriesSubscription() map[string]string {
 	return map[string]string{
-		createSubscription: "INSERT INTO subscriptions (course_id, matrix_id, user_id, valid_until) VALUES ($1, $2, $3, $4) RETURNING *",
-		deleteSubscription: "UPDATE subscriptions SET deleted_at = NOW() WHERE id = $1",
-		getSubscription:    "SELECT * FROM subscriptions WHERE id = $1",
-		listSubscription:   "SELECT * FROM subscriptions",
-		updateSubscription: "UPDATE subscriptions SET user_id = $1, course_id = $2, matrix_id = $3, valid_until = $4 WHERE id = $5 RETURNING *",
+		createSubscription: `INSERT INTO subscriptions (course_id, matrix_id, user_id, role, expires_at, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`,
+		deleteSubscription: `UPDATE subscriptions SET deleted_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL`,
+		getSubscription: "SELECT * FROM subscriptions WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		updateSubscription: `UPDATE subscriptions SET user_id = $1, course_id = $2, matrix_id = $3, role = $4, expires_at = $5, 
+			version = version + 1 
+			WHERE uuid = $6 AND tenant_id = $7 AND ($8 = 0 OR version = $8) AND deleted_at IS NULL RETURNING *`,
+		restoreSubscription: `UPDATE subscriptions SET deleted_at = NULL, version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL RETURNING *`,
+		purgeSubscriptions: "DELETE FROM subscriptions WHERE deleted_at < $1",
+		// A subscription extended after it expired expires again at its new expiration
+		expireSubscriptions: `UPDATE subscriptions SET expired_at = $1, version = version + 1 
+			WHERE expires_at <= $1 AND (expired_at IS NULL OR expired_at < expires_at) AND deleted_at IS NULL RETURNING *`,
 	}
 }
@@ -1,11 +1,21 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 )
 
 // NewSubscriptionRepository creates the subscription subscriptionRepository
@@ -13,80 +23,259 @@ func NewSubscriptionRepository(db *sqlx.DB) (subscriptionRepository, error) { //
 	sqlStatements := make(map[string]*sqlx.Stmt)
 
 	for queryName, query := range queriesSubscription() {
-		stmt, err := db.Preparex(query)
+		stmt, err := postgres.Prepare(db, queryName, query)
 		if err != nil {
-			return subscriptionRepository{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error preparing statement %s", queryName)
+			return subscriptionRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
 		}
 		sqlStatements[queryName] = stmt
 	}
 
 	return subscriptionRepository{
+		db:         db,
 		statements: sqlStatements,
 	}, nil
 }
 
 type subscriptionRepository struct {
+	db         *sqlx.DB
 	statements map[string]*sqlx.Stmt
 }
 
-func (r subscriptionRepository) Subscription(id uuid.UUID) (domain.Subscription, error) {
+func (r subscriptionRepository) Subscription(ctx context.Context, id uuid.UUID) (domain.Subscription, error) {
 	stmt, ok := r.statements[getSubscription]
 	if !ok {
 		return domain.Subscription{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getSubscription)
 	}
+	ctx, end := postgres.StartStatement(ctx, getSubscription)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
 
 	var sub domain.Subscription
-	if err := stmt.Get(&sub, id); err != nil {
-		return domain.Subscription{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting subscription")
+	if err := stmt.GetContext(ctx, &sub, id, tenantID); err != nil {
+		return domain.Subscription{}, errors.WrapDatabaseErrorf(err, "error getting subscription")
 	}
 	return sub, nil
 }
 
-func (r subscriptionRepository) Subscriptions() ([]domain.Subscription, error) {
-	stmt, ok := r.statements[listSubscription]
-	if !ok {
-		return []domain.Subscription{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listSubscription)
+func (r subscriptionRepository) Subscriptions(ctx context.Context, q pagination.Query) ([]domain.Subscription, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listSubscriptions)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Subscription{}, pagination.Page{}, err
 	}
 
+	query, args := pagination.Select(subscriptionsTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenantID}})
+
 	var subs []domain.Subscription
-	if err := stmt.Select(&subs); err != nil {
-		return []domain.Subscription{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting subscriptions")
+	if err := r.db.SelectContext(ctx, &subs, query, args...); err != nil {
+		return []domain.Subscription{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting subscriptions")
 	}
-	return subs, nil
+	return pagination.Paginate(q, subs)
 }
 
-func (r subscriptionRepository) CreateSubscription(s *domain.Subscription) error {
+func (r subscriptionRepository) CreateSubscription(ctx context.Context, s *domain.Subscription) error {
 	stmt, ok := r.statements[createSubscription]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createSubscription)
 	}
+	ctx, end := postgres.StartStatement(ctx, createSubscription)
+	defer end()
 
-	if err := stmt.Get(s, s.CourseID, s.MatrixID, s.UserID, s.ExpiresAt); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error creating subscription")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, s,
+			s.CourseID, s.MatrixID, s.UserID, s.Role, s.ExpiresAt, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error creating subscription")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubscriptionCreated, tenantID, s.UUID, s)}, nil
+	})
 }
 
-func (r subscriptionRepository) UpdateSubscription(sub *domain.Subscription) error {
+func (r subscriptionRepository) UpdateSubscription(ctx context.Context, sub *domain.Subscription) error {
 	stmt, ok := r.statements[updateSubscription]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateSubscription)
 	}
+	ctx, end := postgres.StartStatement(ctx, updateSubscription)
+	defer end()
 
-	if err := stmt.Get(sub, sub.UserID, sub.CourseID, sub.MatrixID, sub.ExpiresAt, sub.UUID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error updating subscription")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, sub,
+			sub.UserID, sub.CourseID, sub.MatrixID, sub.Role, sub.ExpiresAt, sub.UUID, tenantID, expected); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, sub.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error updating subscription")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubscriptionUpdated, tenantID, sub.UUID, sub)}, nil
+	})
 }
 
-func (r subscriptionRepository) DeleteSubscription(id uuid.UUID) error {
+// PatchSubscription writes only the given fields of the subscription
+func (r subscriptionRepository) PatchSubscription(ctx context.Context, s *domain.Subscription, fields []string) error {
+	ctx, end := postgres.StartStatement(ctx, patchSubscription)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]interface{}{
+		"user_id":    s.UserID,
+		"course_id":  s.CourseID,
+		"matrix_id":  s.MatrixID,
+		"role":       s.Role,
+		"expires_at": s.ExpiresAt,
+	}
+	expected := etag.Expected(ctx)
+	query, args, err := patch.Update(subscriptionsTable, columns, fields, s.UUID, tenantID, expected)
+	if err != nil {
+		return err
+	}
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.GetContext(ctx, s, query, args...); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, s.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error patching subscription")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubscriptionUpdated, tenantID, s.UUID, s)}, nil
+	})
+}
+
+func (r subscriptionRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
 	stmt, ok := r.statements[deleteSubscription]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteSubscription)
 	}
+	ctx, end := postgres.StartStatement(ctx, deleteSubscription)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, id, tenantID, expected)
+		if err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error deleting subscription")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, id)
+			}
+			return nil, nil
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubscriptionDeleted, tenantID, id, deleted{UUID: id})}, nil
+	})
+}
+
+func (r subscriptionRepository) RestoreSubscription(ctx context.Context, id uuid.UUID) (domain.Subscription, error) {
+	stmt, ok := r.statements[restoreSubscription]
+	if !ok {
+		return domain.Subscription{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", restoreSubscription)
+	}
+	ctx, end := postgres.StartStatement(ctx, restoreSubscription)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	var sub domain.Subscription
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &sub, id, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error restoring subscription")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubscriptionRestored, tenantID, sub.UUID, &sub)}, nil
+	})
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	return sub, nil
+}
+
+func (r subscriptionRepository) PurgeSubscriptions(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := r.statements[purgeSubscriptions]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeSubscriptions)
+	}
+	ctx, end := postgres.StartStatement(ctx, purgeSubscriptions)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging subscriptions")
+	}
+	return res.RowsAffected()
+}
+
+// ExpireSubscriptions marks the subscriptions of every tenant whose expiration passed as expired
+func (r subscriptionRepository) ExpireSubscriptions(ctx context.Context, now time.Time) ([]domain.Subscription, error) {
+	stmt, ok := r.statements[expireSubscriptions]
+	if !ok {
+		return nil, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", expireSubscriptions)
+	}
+	ctx, end := postgres.StartStatement(ctx, expireSubscriptions)
+	defer end()
+
+	var subs []domain.Subscription
+	err := outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).SelectContext(ctx, &subs, now); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error expiring subscriptions")
+		}
+
+		events := make([]outbox.Event, 0, len(subs))
+		for i := range subs {
+			events = append(events, outbox.NewEvent(domain.EventSubscriptionExpired, subs[i].TenantID, subs[i].UUID, &subs[i]))
+		}
+		return events, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// versionMismatch tells a subscription changed since the expected version apart from a missing one,
+// the subscription is read within the transaction of the failed change
+func (r subscriptionRepository) versionMismatch(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
+	stmt, ok := r.statements[getSubscription]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getSubscription)
+	}
+	ctx, end := postgres.StartStatement(ctx, getSubscription)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 
-	if _, err := stmt.Exec(id); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error deleting subscription")
+	var current domain.Subscription
+	if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &current, id, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error getting subscription")
 	}
-	return nil
+	return errors.NewErrorf(errors.ErrCodePreconditionFailed, "subscription %s was changed, it is at version %d", id, current.Version)
 }
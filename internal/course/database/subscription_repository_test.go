@@ -2,6 +2,7 @@ package database
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -9,6 +10,8 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 	utils "github.com/sumelms/microservice-course/tests"
 )
 
@@ -19,6 +22,7 @@ var (
 		UserID:    utils.UserUUID,
 		CourseID:  utils.CourseUUID,
 		MatrixID:  &utils.MatrixUUID,
+		Role:      domain.RoleStudent,
 		ExpiresAt: &utils.Now,
 		CreatedAt: utils.Now,
 		UpdatedAt: utils.Now,
@@ -31,10 +35,10 @@ func newSubscriptionTestDB() (*sqlx.DB, sqlmock.Sqlmock, map[string]*sqlmock.Exp
 }
 
 func TestRepository_Subscription(t *testing.T) {
-	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id",
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id", "role",
 		"expires_at", "created_at", "updated_at", "deleted_at"}).
 		AddRow(subscription.ID, subscription.UUID, subscription.UserID, subscription.CourseID, subscription.MatrixID,
-			subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
+			subscription.Role, subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
 
 	type args struct {
 		id uuid.UUID
@@ -78,9 +82,9 @@ func TestRepository_Subscription(t *testing.T) {
 				t.Fatalf("prepared statement %s not found", getSubscription)
 			}
 
-			prep.ExpectQuery().WithArgs(utils.SubscriptionUUID).WillReturnRows(tt.rows)
+			prep.ExpectQuery().WithArgs(utils.SubscriptionUUID, tenant.DefaultTenant).WillReturnRows(tt.rows)
 
-			got, err := r.Subscription(tt.args.id)
+			got, err := r.Subscription(tenantCtx, tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Course() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -93,12 +97,12 @@ func TestRepository_Subscription(t *testing.T) {
 }
 
 func TestRepository_Subscriptions(t *testing.T) {
-	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id", "expires_at",
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id", "role", "expires_at",
 		"created_at", "updated_at", "deleted_at"}).
 		AddRow(subscription.ID, subscription.UUID, subscription.UserID, subscription.CourseID, subscription.MatrixID,
-			subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt).
+			subscription.Role, subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt).
 		AddRow(2, uuid.MustParse("7aec21ad-2fa8-4ddd-b5af-073144031ecc"), subscription.UserID,
-			subscription.CourseID, subscription.MatrixID, subscription.ExpiresAt, subscription.CreatedAt,
+			subscription.CourseID, subscription.MatrixID, subscription.Role, subscription.ExpiresAt, subscription.CreatedAt,
 			subscription.UpdatedAt, subscription.DeletedAt)
 
 	tests := []struct {
@@ -126,19 +130,17 @@ func TestRepository_Subscriptions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newSubscriptionTestDB()
+			db, mock, _ := newSubscriptionTestDB()
 			r, err := NewSubscriptionRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected creating the repository", err)
 			}
-			prep, ok := stmts[listSubscription]
-			if !ok {
-				t.Fatalf("prepared statement %s not found", listSubscription)
-			}
 
-			prep.ExpectQuery().WillReturnRows(tt.rows)
+			q := pagination.NewQuery()
+			query, _ := pagination.Select(subscriptionsTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenant.DefaultTenant}})
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(tt.rows)
 
-			got, err := r.Subscriptions()
+			got, _, err := r.Subscriptions(tenantCtx, q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Subscriptions() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -151,10 +153,10 @@ func TestRepository_Subscriptions(t *testing.T) {
 }
 
 func TestRepository_CreateSubscription(t *testing.T) {
-	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id",
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id", "role",
 		"expires_at", "created_at", "updated_at", "deleted_at"}).
 		AddRow(subscription.ID, subscription.UUID, subscription.UserID, subscription.CourseID, subscription.MatrixID,
-			subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
+			subscription.Role, subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
 
 	type args struct {
 		s *domain.Subscription
@@ -185,7 +187,8 @@ func TestRepository_CreateSubscription(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newSubscriptionTestDB()
+			db, mock, stmts := newSubscriptionTestDB()
+			utils.ExpectOutboxTx(mock)
 			r, err := NewSubscriptionRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the repository", err)
@@ -197,7 +200,7 @@ func TestRepository_CreateSubscription(t *testing.T) {
 
 			prep.ExpectQuery().WillReturnRows(tt.rows)
 
-			if err := r.CreateSubscription(tt.args.s); (err != nil) != tt.wantErr {
+			if err := r.CreateSubscription(tenantCtx, tt.args.s); (err != nil) != tt.wantErr {
 				t.Errorf("CreateSubscription() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -205,10 +208,10 @@ func TestRepository_CreateSubscription(t *testing.T) {
 }
 
 func TestRepository_UpdateSubscription(t *testing.T) {
-	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id",
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "user_id", "course_id", "matrix_id", "role",
 		"expires_at", "created_at", "updated_at", "deleted_at"}).
 		AddRow(subscription.ID, subscription.UUID, subscription.UserID, subscription.CourseID, subscription.MatrixID,
-			subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
+			subscription.Role, subscription.ExpiresAt, subscription.CreatedAt, subscription.UpdatedAt, subscription.DeletedAt)
 
 	type fields struct {
 		DB *sqlx.DB
@@ -237,10 +240,12 @@ func TestRepository_UpdateSubscription(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, _, stmts := newSubscriptionTestDB()
+			db, mock, stmts := newSubscriptionTestDB()
+			utils.ExpectOutboxTx(mock)
 			r, err := NewSubscriptionRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the repository", err)
@@ -252,9 +257,47 @@ func TestRepository_UpdateSubscription(t *testing.T) {
 
 			prep.ExpectQuery().WillReturnRows(tt.rows)
 
-			if err := r.UpdateSubscription(tt.args.s); (err != nil) != tt.wantErr {
+			if err := r.UpdateSubscription(tenantCtx, tt.args.s); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateSubscription() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestRepository_ExpireSubscriptions(t *testing.T) {
+	otherUUID := uuid.MustParse("3b7e1c0e-5d8a-4f0b-9a43-2f8f1c6d7e21")
+	expiredRows := sqlmock.NewRows([]string{"id", "uuid", "tenant_id", "user_id", "course_id", "role", "expires_at",
+		"expired_at"}).
+		AddRow(1, subscription.UUID, tenant.DefaultTenant, subscription.UserID, subscription.CourseID, subscription.Role,
+			utils.Now, utils.Now).
+		AddRow(2, otherUUID, "other", subscription.UserID, subscription.CourseID, subscription.Role, utils.Now, utils.Now)
+
+	db, mock, stmts := newSubscriptionTestDB()
+	r, err := NewSubscriptionRepository(db)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the repository", err)
+	}
+
+	mock.ExpectBegin()
+	stmts[expireSubscriptions].ExpectQuery().WithArgs(utils.Now).WillReturnRows(expiredRows)
+	for _, e := range []struct {
+		tenantID string
+		id       uuid.UUID
+	}{{tenant.DefaultTenant, subscription.UUID}, {"other", otherUUID}} {
+		mock.ExpectExec(`^INSERT INTO\s+outbox`).
+			WithArgs(e.tenantID, domain.EventSubscriptionExpired, e.id, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	subs, err := r.ExpireSubscriptions(tenantCtx, utils.Now)
+	if err != nil {
+		t.Fatalf("ExpireSubscriptions() error = %v", err)
+	}
+	if len(subs) != 2 {
+		t.Errorf("ExpireSubscriptions() got %d subscriptions, want 2", len(subs))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("ExpireSubscriptions() didn't write the events: %v", err)
+	}
+}
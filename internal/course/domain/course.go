@@ -4,20 +4,33 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 // Course struct
 type Course struct {
-	ID          uint       `json:"id"`
-	UUID        uuid.UUID  `json:"uuid"`
-	Code        string     `json:"code"`
-	Name        string     `json:"name"`
-	Underline   string     `json:"underline"`
-	Image       string     `json:"image"`
-	ImageCover  string     `db:"image_cover" json:"image_cover"`
-	Excerpt     string     `json:"excerpt"`
-	Description string     `json:"description"`
-	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID              uint         `json:"id"`
+	TenantID        string       `db:"tenant_id" json:"-"`
+	UUID            uuid.UUID    `json:"uuid"`
+	Code            string       `json:"code"`
+	Name            string       `json:"name"`
+	Underline       string       `json:"underline"`
+	Image           string       `json:"image"`
+	ImageCover      string       `db:"image_cover" json:"image_cover"`
+	Excerpt         string       `json:"excerpt"`
+	Description     string       `json:"description"`
+	Status          CourseStatus `json:"status"`
+	StatusChangedBy *uuid.UUID   `db:"status_changed_by" json:"status_changed_by"`
+	StatusChangedAt *time.Time   `db:"status_changed_at" json:"status_changed_at"`
+	Version         int          `json:"version"`
+	CreatedAt       time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at" json:"updated_at"`
+	DeletedAt       *time.Time   `db:"deleted_at" json:"deleted_at"`
+}
+
+// CourseListFields are the course fields allowed to filter and sort the listing
+var CourseListFields = pagination.Fields{
+	Filters: []string{"code", "name", "status"},
+	Sorts:   []string{"code", "name", "created_at", "updated_at"},
 }
@@ -1,11 +1,22 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
 
 type CourseRepository interface {
-	Course(id uuid.UUID) (Course, error)
-	Courses() ([]Course, error)
-	CreateCourse(lesson *Course) error
-	UpdateCourse(lesson *Course) error
-	DeleteCourse(id uuid.UUID) error
+	Course(ctx context.Context, id uuid.UUID) (Course, error)
+	Courses(ctx context.Context, q pagination.Query) ([]Course, pagination.Page, error)
+	CreateCourse(ctx context.Context, lesson *Course) error
+	UpdateCourse(ctx context.Context, lesson *Course) error
+	PatchCourse(ctx context.Context, course *Course, fields []string) error
+	DeleteCourse(ctx context.Context, id uuid.UUID) error
+	RestoreCourse(ctx context.Context, id uuid.UUID) (Course, error)
+	UpdateCourseStatus(ctx context.Context, id uuid.UUID, from, to CourseStatus, changedBy *uuid.UUID) (Course, error)
+	PurgeCourses(ctx context.Context, before time.Time) (int64, error)
 }
@@ -5,41 +5,101 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
-func (s *Service) Course(_ context.Context, id uuid.UUID) (Course, error) {
-	c, err := s.courses.Course(id)
+func (s *Service) Course(ctx context.Context, id uuid.UUID) (Course, error) {
+	c, err := s.courses.Course(ctx, id)
 	if err != nil {
 		return Course{}, fmt.Errorf("service can't find course: %w", err)
 	}
 	return c, nil
 }
 
-func (s *Service) Courses(_ context.Context) ([]Course, error) {
-	cc, err := s.courses.Courses()
+func (s *Service) Courses(ctx context.Context, q pagination.Query) ([]Course, pagination.Page, error) {
+	cc, page, err := s.courses.Courses(ctx, q)
 	if err != nil {
-		return []Course{}, fmt.Errorf("service didn't found any course: %w", err)
+		return []Course{}, pagination.Page{}, fmt.Errorf("service didn't found any course: %w", err)
 	}
-	return cc, nil
+	return cc, page, nil
 }
 
-func (s *Service) CreateCourse(_ context.Context, c *Course) error {
-	if err := s.courses.CreateCourse(c); err != nil {
+func (s *Service) CreateCourse(ctx context.Context, c *Course) error {
+	if err := s.courses.CreateCourse(ctx, c); err != nil {
 		return fmt.Errorf("service can't create course: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) UpdateCourse(_ context.Context, c *Course) error {
-	if err := s.courses.UpdateCourse(c); err != nil {
+func (s *Service) UpdateCourse(ctx context.Context, c *Course) error {
+	if err := s.courseNoneMatch(ctx, c.UUID); err != nil {
+		return err
+	}
+	if err := s.courses.UpdateCourse(ctx, c); err != nil {
 		return fmt.Errorf("service can't update course: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) DeleteCourse(_ context.Context, id uuid.UUID) error {
-	if err := s.courses.DeleteCourse(id); err != nil {
+// PatchCourse writes only the given fields of the course
+func (s *Service) PatchCourse(ctx context.Context, c *Course, fields []string) error {
+	if err := s.courseNoneMatch(ctx, c.UUID); err != nil {
+		return err
+	}
+	if err := s.courses.PatchCourse(ctx, c, fields); err != nil {
+		return fmt.Errorf("service can't patch course: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteCourse(ctx context.Context, id uuid.UUID) error {
+	if err := s.courseNoneMatch(ctx, id); err != nil {
+		return err
+	}
+	if err := s.courses.DeleteCourse(ctx, id); err != nil {
 		return fmt.Errorf("service can't delete course: %w", err)
 	}
 	return nil
 }
+
+func (s *Service) RestoreCourse(ctx context.Context, id uuid.UUID) (Course, error) {
+	c, err := s.courses.RestoreCourse(ctx, id)
+	if err != nil {
+		return Course{}, fmt.Errorf("service can't restore course: %w", err)
+	}
+	return c, nil
+}
+
+// TransitionCourse moves the course through its publication lifecycle, the author of the change
+// is unknown without authentication
+func (s *Service) TransitionCourse(ctx context.Context, id uuid.UUID, t CourseTransition, changedBy *uuid.UUID) (Course, error) {
+	c, err := s.courses.Course(ctx, id)
+	if err != nil {
+		return Course{}, fmt.Errorf("service can't find course: %w", err)
+	}
+
+	to, ok := t.Target(c.Status)
+	if !ok {
+		return Course{}, errors.NewErrorf(errors.ErrCodeInvalidArgument, "course can't %s from status %s", t, c.Status)
+	}
+
+	updated, err := s.courses.UpdateCourseStatus(ctx, id, c.Status, to, changedBy)
+	if err != nil {
+		return Course{}, fmt.Errorf("service can't %s course: %w", t, err)
+	}
+	return updated, nil
+}
+
+// courseNoneMatch fails when the If-None-Match of the change matches the current course
+func (s *Service) courseNoneMatch(ctx context.Context, id uuid.UUID) error {
+	return etag.CheckNoneMatch(ctx, func() (int, error) {
+		current, err := s.courses.Course(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("service can't find course: %w", err)
+		}
+		return current.Version, nil
+	})
+}
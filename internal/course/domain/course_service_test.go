@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+)
+
+type stubCourseWrites struct {
+	CourseRepository
+	current Course
+	written bool
+}
+
+func (r *stubCourseWrites) Course(_ context.Context, _ uuid.UUID) (Course, error) {
+	return r.current, nil
+}
+
+func (r *stubCourseWrites) UpdateCourse(_ context.Context, _ *Course) error {
+	r.written = true
+	return nil
+}
+
+func (r *stubCourseWrites) PatchCourse(_ context.Context, _ *Course, _ []string) error {
+	r.written = true
+	return nil
+}
+
+func (r *stubCourseWrites) DeleteCourse(_ context.Context, _ uuid.UUID) error {
+	r.written = true
+	return nil
+}
+
+func TestService_CourseWritesNoneMatch(t *testing.T) {
+	writes := []struct {
+		method string
+		write  func(ctx context.Context, s *Service, c *Course) error
+	}{
+		{method: http.MethodPut, write: func(ctx context.Context, s *Service, c *Course) error {
+			return s.UpdateCourse(ctx, c)
+		}},
+		{method: http.MethodPatch, write: func(ctx context.Context, s *Service, c *Course) error {
+			return s.PatchCourse(ctx, c, []string{"name"})
+		}},
+		{method: http.MethodDelete, write: func(ctx context.Context, s *Service, c *Course) error {
+			return s.DeleteCourse(ctx, c.UUID)
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantCode    errors.ErrorCode
+	}{
+		{name: "no header"},
+		{name: "stale tag", ifNoneMatch: `"1"`},
+		{name: "current tag", ifNoneMatch: `"2"`, wantCode: errors.ErrCodePreconditionFailed},
+		{name: "wildcard", ifNoneMatch: "*", wantCode: errors.ErrCodePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, w := range writes {
+				courses := &stubCourseWrites{current: Course{UUID: uuid.New(), Version: 2}}
+				s, _ := NewService(WithCourseRepository(courses))
+
+				r := httptest.NewRequest(w.method, "/courses/"+courses.current.UUID.String(), nil)
+				if tt.ifNoneMatch != "" {
+					r.Header.Set("If-None-Match", tt.ifNoneMatch)
+				}
+				ctx := etag.PopulatePreconditions(context.Background(), r)
+
+				err := w.write(ctx, s, &Course{UUID: courses.current.UUID})
+				if tt.wantCode == errors.ErrCodeUnknown {
+					if err != nil || !courses.written {
+						t.Errorf("%s error = %v, written = %v", w.method, err, courses.written)
+					}
+					continue
+				}
+				if errors.CodeOf(err) != tt.wantCode || courses.written {
+					t.Errorf("%s error = %v, want code %v without writing", w.method, err, tt.wantCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+// CourseStatus is the publication state of a course
+type CourseStatus string
+
+const (
+	CourseDraft     CourseStatus = "draft"
+	CourseReview    CourseStatus = "review"
+	CoursePublished CourseStatus = "published"
+	CourseArchived  CourseStatus = "archived"
+)
+
+// CourseTransition is an action moving a course from one status to another
+type CourseTransition string
+
+const (
+	SubmitCourse  CourseTransition = "submit"
+	PublishCourse CourseTransition = "publish"
+	RejectCourse  CourseTransition = "reject"
+	ArchiveCourse CourseTransition = "archive"
+	ReopenCourse  CourseTransition = "reopen"
+)
+
+type courseTransitionRule struct {
+	from []CourseStatus
+	to   CourseStatus
+}
+
+var courseTransitions = map[CourseTransition]courseTransitionRule{
+	SubmitCourse:  {from: []CourseStatus{CourseDraft}, to: CourseReview},
+	PublishCourse: {from: []CourseStatus{CourseReview}, to: CoursePublished},
+	RejectCourse:  {from: []CourseStatus{CourseReview}, to: CourseDraft},
+	ArchiveCourse: {from: []CourseStatus{CoursePublished}, to: CourseArchived},
+	ReopenCourse:  {from: []CourseStatus{CourseArchived}, to: CourseDraft},
+}
+
+// Target returns the status reached by applying the transition to a course in the given status
+func (t CourseTransition) Target(from CourseStatus) (CourseStatus, bool) {
+	rule, ok := courseTransitions[t]
+	if !ok {
+		return "", false
+	}
+	for _, s := range rule.from {
+		if s == from {
+			return rule.to, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestCourseTransition_Target(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition CourseTransition
+		from       CourseStatus
+		want       CourseStatus
+		wantOk     bool
+	}{
+		{name: "submit draft", transition: SubmitCourse, from: CourseDraft, want: CourseReview, wantOk: true},
+		{name: "publish reviewed", transition: PublishCourse, from: CourseReview, want: CoursePublished, wantOk: true},
+		{name: "reject reviewed", transition: RejectCourse, from: CourseReview, want: CourseDraft, wantOk: true},
+		{name: "archive published", transition: ArchiveCourse, from: CoursePublished, want: CourseArchived, wantOk: true},
+		{name: "reopen archived", transition: ReopenCourse, from: CourseArchived, want: CourseDraft, wantOk: true},
+		{name: "publish draft", transition: PublishCourse, from: CourseDraft, wantOk: false},
+		{name: "archive draft", transition: ArchiveCourse, from: CourseDraft, wantOk: false},
+		{name: "unknown transition", transition: "delete", from: CourseDraft, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.transition.Target(tt.from)
+			if ok != tt.wantOk {
+				t.Errorf("Target() ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Target() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Types of the events written to the outbox along with the changes of the courses and
+// subscriptions, their payload is the changed record
+const (
+	EventCourseCreated       = "course.created"
+	EventCourseUpdated       = "course.updated"
+	EventCourseDeleted       = "course.deleted"
+	EventCourseRestored      = "course.restored"
+	EventCourseStatusChanged = "course.status_changed"
+	EventCoursePublished     = "course.published"
+
+	EventSubscriptionCreated  = "subscription.created"
+	EventSubscriptionUpdated  = "subscription.updated"
+	EventSubscriptionDeleted  = "subscription.deleted"
+	EventSubscriptionRestored = "subscription.restored"
+	EventSubscriptionExpired  = "subscription.expired"
+)
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type instrumentingService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	next           ServiceInterface
+}
+
+// NewInstrumentingService counts the calls to every method of the service and records their latency
+func NewInstrumentingService(next ServiceInterface, counter metrics.Counter, latency metrics.Histogram) ServiceInterface {
+	return &instrumentingService{requestCount: counter, requestLatency: latency, next: next}
+}
+
+func (s *instrumentingService) observe(method string, begin time.Time, err error) {
+	labels := []string{"method", method, "error", strconv.FormatBool(err != nil)}
+	s.requestCount.With(labels...).Add(1)
+	s.requestLatency.With(labels...).Observe(time.Since(begin).Seconds())
+}
+
+func (s *instrumentingService) Course(ctx context.Context, id uuid.UUID) (res Course, err error) {
+	defer func(begin time.Time) { s.observe("Course", begin, err) }(time.Now())
+	return s.next.Course(ctx, id)
+}
+
+func (s *instrumentingService) Courses(ctx context.Context, q pagination.Query) (list []Course, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Courses", begin, err) }(time.Now())
+	return s.next.Courses(ctx, q)
+}
+
+func (s *instrumentingService) CreateCourse(ctx context.Context, c *Course) (err error) {
+	defer func(begin time.Time) { s.observe("CreateCourse", begin, err) }(time.Now())
+	return s.next.CreateCourse(ctx, c)
+}
+
+func (s *instrumentingService) UpdateCourse(ctx context.Context, c *Course) (err error) {
+	defer func(begin time.Time) { s.observe("UpdateCourse", begin, err) }(time.Now())
+	return s.next.UpdateCourse(ctx, c)
+}
+
+func (s *instrumentingService) PatchCourse(ctx context.Context, c *Course, fields []string) (err error) {
+	defer func(begin time.Time) { s.observe("PatchCourse", begin, err) }(time.Now())
+	return s.next.PatchCourse(ctx, c, fields)
+}
+
+func (s *instrumentingService) DeleteCourse(ctx context.Context, courseID uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteCourse", begin, err) }(time.Now())
+	return s.next.DeleteCourse(ctx, courseID)
+}
+
+func (s *instrumentingService) RestoreCourse(ctx context.Context, courseID uuid.UUID) (res Course, err error) {
+	defer func(begin time.Time) { s.observe("RestoreCourse", begin, err) }(time.Now())
+	return s.next.RestoreCourse(ctx, courseID)
+}
+
+func (s *instrumentingService) TransitionCourse(ctx context.Context, courseID uuid.UUID, t CourseTransition, changedBy *uuid.UUID) (res Course, err error) {
+	defer func(begin time.Time) { s.observe("TransitionCourse", begin, err) }(time.Now())
+	return s.next.TransitionCourse(ctx, courseID, t, changedBy)
+}
+
+func (s *instrumentingService) Subscription(ctx context.Context, id uuid.UUID) (res Subscription, err error) {
+	defer func(begin time.Time) { s.observe("Subscription", begin, err) }(time.Now())
+	return s.next.Subscription(ctx, id)
+}
+
+func (s *instrumentingService) Subscriptions(ctx context.Context, q pagination.Query) (list []Subscription, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Subscriptions", begin, err) }(time.Now())
+	return s.next.Subscriptions(ctx, q)
+}
+
+func (s *instrumentingService) CreateSubscription(ctx context.Context, cs *Subscription) (err error) {
+	defer func(begin time.Time) { s.observe("CreateSubscription", begin, err) }(time.Now())
+	return s.next.CreateSubscription(ctx, cs)
+}
+
+func (s *instrumentingService) UpdateSubscription(ctx context.Context, cs *Subscription) (err error) {
+	defer func(begin time.Time) { s.observe("UpdateSubscription", begin, err) }(time.Now())
+	return s.next.UpdateSubscription(ctx, cs)
+}
+
+func (s *instrumentingService) PatchSubscription(ctx context.Context, cs *Subscription, fields []string) (err error) {
+	defer func(begin time.Time) { s.observe("PatchSubscription", begin, err) }(time.Now())
+	return s.next.PatchSubscription(ctx, cs, fields)
+}
+
+func (s *instrumentingService) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteSubscription", begin, err) }(time.Now())
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+func (s *instrumentingService) RestoreSubscription(ctx context.Context, id uuid.UUID) (res Subscription, err error) {
+	defer func(begin time.Time) { s.observe("RestoreSubscription", begin, err) }(time.Now())
+	return s.next.RestoreSubscription(ctx, id)
+}
+
+func (s *instrumentingService) CourseMembers(ctx context.Context, courseID uuid.UUID, q pagination.Query) (list []Subscription, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("CourseMembers", begin, err) }(time.Now())
+	return s.next.CourseMembers(ctx, courseID, q)
+}
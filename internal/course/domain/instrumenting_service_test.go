@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/google/uuid"
+)
+
+// recordingCounter keeps the count of every set of labels it was given
+type recordingCounter struct {
+	counts map[string]float64
+	labels []string
+}
+
+func (c recordingCounter) With(labelValues ...string) metrics.Counter {
+	return recordingCounter{counts: c.counts, labels: append(append([]string{}, c.labels...), labelValues...)}
+}
+
+func (c recordingCounter) Add(delta float64) {
+	c.counts[strings.Join(c.labels, " ")] += delta
+}
+
+type failingService struct {
+	ServiceInterface
+}
+
+func (failingService) DeleteCourse(context.Context, uuid.UUID) error {
+	return fmt.Errorf("course is locked")
+}
+
+func TestInstrumentingService(t *testing.T) {
+	requests := recordingCounter{counts: map[string]float64{}}
+	latency := generic.NewHistogram("latency", 10)
+
+	s := NewInstrumentingService(&stubService{}, requests, latency)
+	if _, err := s.Subscription(context.Background(), uuid.New()); err != nil {
+		t.Fatalf("Subscription() error = %v", err)
+	}
+	s = NewInstrumentingService(failingService{}, requests, latency)
+	if err := s.DeleteCourse(context.Background(), uuid.New()); err == nil {
+		t.Fatalf("DeleteCourse() should fail")
+	}
+
+	for _, labels := range []string{"method Subscription error false", "method DeleteCourse error true"} {
+		if requests.counts[labels] != 1 {
+			t.Errorf("requests{%s} = %v, want 1", labels, requests.counts[labels])
+		}
+	}
+}
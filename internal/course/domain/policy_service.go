@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+const (
+	ScopeCourseWrite       = "course:write"
+	ScopeSubscriptionRead  = "subscription:read"
+	ScopeSubscriptionWrite = "subscription:write"
+)
+
+var (
+	readPolicy              = auth.Policy{}
+	courseWritePolicy       = auth.Policy{Scopes: []string{ScopeCourseWrite}}
+	subscriptionReadPolicy  = auth.Policy{Scopes: []string{ScopeSubscriptionRead, ScopeSubscriptionWrite}}
+	subscriptionWritePolicy = auth.Policy{Scopes: []string{ScopeSubscriptionWrite}}
+	membersPolicy           = auth.Policy{Scopes: []string{ScopeCourseWrite, ScopeSubscriptionRead}}
+)
+
+type policyService struct {
+	next ServiceInterface
+}
+
+// NewPolicyService puts the authorization policies in front of the service. Learners without
+// the subscription scopes only read, create and delete their own subscriptions.
+func NewPolicyService(next ServiceInterface) ServiceInterface {
+	return &policyService{next: next}
+}
+
+func (s *policyService) Course(ctx context.Context, id uuid.UUID) (Course, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return Course{}, err
+	}
+	return s.next.Course(ctx, id)
+}
+
+func (s *policyService) Courses(ctx context.Context, q pagination.Query) ([]Course, pagination.Page, error) {
+	if err := readPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Course{}, pagination.Page{}, err
+	}
+	return s.next.Courses(ctx, q)
+}
+
+func (s *policyService) CreateCourse(ctx context.Context, c *Course) error {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.CreateCourse(ctx, c)
+}
+
+func (s *policyService) UpdateCourse(ctx context.Context, c *Course) error {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.UpdateCourse(ctx, c)
+}
+
+func (s *policyService) PatchCourse(ctx context.Context, c *Course, fields []string) error {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.PatchCourse(ctx, c, fields)
+}
+
+func (s *policyService) DeleteCourse(ctx context.Context, courseID uuid.UUID) error {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.DeleteCourse(ctx, courseID)
+}
+
+func (s *policyService) RestoreCourse(ctx context.Context, courseID uuid.UUID) (Course, error) {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return Course{}, err
+	}
+	return s.next.RestoreCourse(ctx, courseID)
+}
+
+func (s *policyService) TransitionCourse(ctx context.Context, courseID uuid.UUID, t CourseTransition,
+	changedBy *uuid.UUID) (Course, error) {
+	if err := courseWritePolicy.Authorize(ctx); err != nil {
+		return Course{}, err
+	}
+	return s.next.TransitionCourse(ctx, courseID, t, changedBy)
+}
+
+func (s *policyService) Subscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return Subscription{}, err
+	}
+
+	sub, err := s.next.Subscription(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if err := authorizeOwner(ctx, subscriptionReadPolicy, sub.UserID); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+func (s *policyService) Subscriptions(ctx context.Context, q pagination.Query) ([]Subscription, pagination.Page, error) {
+	if err := readPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Subscription{}, pagination.Page{}, err
+	}
+
+	if !subscriptionReadPolicy.Allows(ctx) {
+		userID, err := auth.SubjectID(ctx)
+		if err != nil {
+			return []Subscription{}, pagination.Page{}, err
+		}
+		if filter, ok := q.Filters["user_id"]; ok && filter != userID.String() {
+			return []Subscription{}, pagination.Page{}, errors.NewErrorf(errors.ErrCodeForbidden,
+				"learners only list their own subscriptions")
+		}
+		q.Filters = copyFilters(q.Filters)
+		q.Filters["user_id"] = userID.String()
+	}
+	return s.next.Subscriptions(ctx, q)
+}
+
+func (s *policyService) CreateSubscription(ctx context.Context, sub *Subscription) error {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+
+	if !subscriptionWritePolicy.Allows(ctx) {
+		if err := authorizeOwner(ctx, subscriptionWritePolicy, sub.UserID); err != nil {
+			return err
+		}
+		if sub.Role != "" && sub.Role != RoleStudent {
+			return errors.NewErrorf(errors.ErrCodeForbidden, "learners only subscribe as %s", RoleStudent)
+		}
+	}
+	return s.next.CreateSubscription(ctx, sub)
+}
+
+func (s *policyService) UpdateSubscription(ctx context.Context, sub *Subscription) error {
+	if err := subscriptionWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.UpdateSubscription(ctx, sub)
+}
+
+func (s *policyService) PatchSubscription(ctx context.Context, sub *Subscription, fields []string) error {
+	if err := subscriptionWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.PatchSubscription(ctx, sub, fields)
+}
+
+func (s *policyService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+
+	if !subscriptionWritePolicy.Allows(ctx) {
+		sub, err := s.next.Subscription(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := authorizeOwner(ctx, subscriptionWritePolicy, sub.UserID); err != nil {
+			return err
+		}
+	}
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+func (s *policyService) RestoreSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	if err := subscriptionWritePolicy.Authorize(ctx); err != nil {
+		return Subscription{}, err
+	}
+	return s.next.RestoreSubscription(ctx, id)
+}
+
+func (s *policyService) CourseMembers(ctx context.Context, courseID uuid.UUID,
+	q pagination.Query) ([]Subscription, pagination.Page, error) {
+	if err := membersPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Subscription{}, pagination.Page{}, err
+	}
+	return s.next.CourseMembers(ctx, courseID, q)
+}
+
+// authorizeOwner lets the owner through, anyone else must be granted the policy
+func authorizeOwner(ctx context.Context, p auth.Policy, owner uuid.UUID) error {
+	if p.Allows(ctx) {
+		return nil
+	}
+	userID, err := auth.SubjectID(ctx)
+	if err != nil {
+		return err
+	}
+	if userID != owner {
+		return errors.NewErrorf(errors.ErrCodeForbidden, "subscription belongs to another user")
+	}
+	return nil
+}
+
+func copyFilters(filters map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(filters)+1)
+	for k, v := range filters {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type stubService struct {
+	ServiceInterface
+	subscription Subscription
+	query        pagination.Query
+}
+
+func (s *stubService) Subscription(_ context.Context, _ uuid.UUID) (Subscription, error) {
+	return s.subscription, nil
+}
+
+func (s *stubService) Subscriptions(_ context.Context, q pagination.Query) ([]Subscription, pagination.Page, error) {
+	s.query = q
+	return []Subscription{s.subscription}, pagination.Page{}, nil
+}
+
+func (s *stubService) CreateSubscription(_ context.Context, _ *Subscription) error {
+	return nil
+}
+
+func TestPolicyService_Subscriptions(t *testing.T) {
+	var (
+		learner = uuid.MustParse("d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d")
+		other   = uuid.MustParse("0c2e7a51-8d4b-4d55-9b5c-8a1f3e2d4c6b")
+	)
+	ctxFor := func(scope string) context.Context {
+		return auth.NewContext(context.Background(), &auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: learner.String()},
+			Scope:            scope,
+		})
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		owner   uuid.UUID
+		role    SubscriptionRole
+		wantErr bool
+	}{
+		{name: "learner owns the subscription", ctx: ctxFor(""), owner: learner},
+		{name: "learner reads another subscription", ctx: ctxFor(""), owner: other, wantErr: true},
+		{name: "learner subscribes as instructor", ctx: ctxFor(""), owner: learner, role: RoleInstructor, wantErr: true},
+		{name: "staff manages subscriptions", ctx: ctxFor(ScopeSubscriptionWrite), owner: other, role: RoleInstructor},
+		{name: "anonymous caller", ctx: context.Background(), owner: learner, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := &stubService{subscription: Subscription{UserID: tt.owner, Role: tt.role}}
+			s := NewPolicyService(next)
+
+			_, errRead := s.Subscription(tt.ctx, uuid.New())
+			errCreate := s.CreateSubscription(tt.ctx, &Subscription{UserID: tt.owner, Role: tt.role})
+			if gotErr := errRead != nil || errCreate != nil; gotErr != tt.wantErr {
+				t.Errorf("PolicyService errors = %v, %v, wantErr %v", errRead, errCreate, tt.wantErr)
+			}
+		})
+	}
+
+	next := &stubService{}
+	if _, _, err := NewPolicyService(next).Subscriptions(ctxFor(""), pagination.NewQuery()); err != nil {
+		t.Fatalf("Subscriptions() unexpected error = %v", err)
+	}
+	if got := next.query.Filters["user_id"]; got != learner.String() {
+		t.Errorf("Subscriptions() user_id filter = %v, want %v", got, learner)
+	}
+}
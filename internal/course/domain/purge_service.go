@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PurgeDeleted permanently deletes the subscriptions and courses soft deleted longer than the retention period
+func (s *Service) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+
+	subs, err := s.subscriptions.PurgeSubscriptions(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge subscriptions: %w", err)
+	}
+	courses, err := s.courses.PurgeCourses(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge courses: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Log("msg", "purged deleted records", "courses", courses, "subscriptions", subs) //nolint: errcheck
+	}
+	return nil
+}
@@ -5,21 +5,29 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 // ServiceInterface defines the domains Service interface
 type ServiceInterface interface {
 	Course(ctx context.Context, id uuid.UUID) (Course, error)
-	Courses(ctx context.Context) ([]Course, error)
+	Courses(ctx context.Context, q pagination.Query) ([]Course, pagination.Page, error)
 	CreateCourse(ctx context.Context, c *Course) error
 	UpdateCourse(ctx context.Context, c *Course) error
+	PatchCourse(ctx context.Context, c *Course, fields []string) error
 	DeleteCourse(ctx context.Context, courseID uuid.UUID) error
+	RestoreCourse(ctx context.Context, courseID uuid.UUID) (Course, error)
+	TransitionCourse(ctx context.Context, courseID uuid.UUID, t CourseTransition, changedBy *uuid.UUID) (Course, error)
 
 	Subscription(ctx context.Context, id uuid.UUID) (Subscription, error)
-	Subscriptions(ctx context.Context) ([]Subscription, error)
+	Subscriptions(ctx context.Context, q pagination.Query) ([]Subscription, pagination.Page, error)
 	CreateSubscription(ctx context.Context, cs *Subscription) error
 	UpdateSubscription(ctx context.Context, cs *Subscription) error
+	PatchSubscription(ctx context.Context, cs *Subscription, fields []string) error
 	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	RestoreSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	CourseMembers(ctx context.Context, courseID uuid.UUID, q pagination.Query) ([]Subscription, pagination.Page, error)
 }
 
 type serviceConfiguration func(svc *Service) error
@@ -4,16 +4,35 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 type Subscription struct {
-	ID        uint       `json:"id"`
-	UUID      uuid.UUID  `json:"uuid"`
-	UserID    uuid.UUID  `db:"user_id" json:"user_id"`
-	CourseID  uuid.UUID  `db:"course_id" json:"course_id"`
-	MatrixID  *uuid.UUID `db:"matrix_id" json:"matrix_id"`
-	ExpiresAt *time.Time `db:"expires_at" json:"expires_at"`
-	CreatedAt time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID        uint             `json:"id"`
+	TenantID  string           `db:"tenant_id" json:"-"`
+	UUID      uuid.UUID        `json:"uuid"`
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	CourseID  uuid.UUID        `db:"course_id" json:"course_id"`
+	MatrixID  *uuid.UUID       `db:"matrix_id" json:"matrix_id"`
+	Role      SubscriptionRole `json:"role"`
+	ExpiresAt *time.Time       `db:"expires_at" json:"expires_at"`
+	ExpiredAt *time.Time       `db:"expired_at" json:"expired_at"`
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time        `db:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time       `db:"deleted_at" json:"deleted_at"`
+}
+
+// SubscriptionListFields are the subscription fields allowed to filter and sort the listing
+var SubscriptionListFields = pagination.Fields{
+	Filters:  []string{"user_id", "course_id", "matrix_id", "role"},
+	Sorts:    []string{"created_at", "updated_at", "expires_at"},
+	Nullable: []string{"expires_at"},
+}
+
+// MemberListFields are the fields allowed to filter and sort the members of a course
+var MemberListFields = pagination.Fields{
+	Filters: []string{"role"},
+	Sorts:   []string{"created_at", "updated_at"},
 }
@@ -1,11 +1,22 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
 
 type SubscriptionRepository interface {
-	Subscription(id uuid.UUID) (Subscription, error)
-	Subscriptions() ([]Subscription, error)
-	CreateSubscription(subscription *Subscription) error
-	UpdateSubscription(subscription *Subscription) error
-	DeleteSubscription(id uuid.UUID) error
+	Subscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	Subscriptions(ctx context.Context, q pagination.Query) ([]Subscription, pagination.Page, error)
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	PatchSubscription(ctx context.Context, subscription *Subscription, fields []string) error
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	RestoreSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	PurgeSubscriptions(ctx context.Context, before time.Time) (int64, error)
+	ExpireSubscriptions(ctx context.Context, now time.Time) ([]Subscription, error)
 }
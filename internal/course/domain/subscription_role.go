@@ -0,0 +1,25 @@
+package domain
+
+// SubscriptionRole is the part a user plays in a subscribed course
+type SubscriptionRole string
+
+const (
+	RoleStudent           SubscriptionRole = "student"
+	RoleInstructor        SubscriptionRole = "instructor"
+	RoleTeachingAssistant SubscriptionRole = "teaching_assistant"
+	RoleObserver          SubscriptionRole = "observer"
+)
+
+// IsStaff tells whether the role belongs to the course staff rather than to its learners
+func (r SubscriptionRole) IsStaff() bool {
+	return r == RoleInstructor || r == RoleTeachingAssistant
+}
+
+// Valid tells whether the role is one of the known subscription roles
+func (r SubscriptionRole) Valid() bool {
+	switch r {
+	case RoleStudent, RoleInstructor, RoleTeachingAssistant, RoleObserver:
+		return true
+	}
+	return false
+}
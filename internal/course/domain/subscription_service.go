@@ -3,47 +3,149 @@ package domain
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
-func (s *Service) Subscription(_ context.Context, id uuid.UUID) (Subscription, error) {
-	sub, err := s.subscriptions.Subscription(id)
+func (s *Service) Subscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	sub, err := s.subscriptions.Subscription(ctx, id)
 	if err != nil {
 		return Subscription{}, fmt.Errorf("service can't find subscription: %w", err)
 	}
 	return sub, nil
 }
 
-func (s *Service) Subscriptions(_ context.Context) ([]Subscription, error) {
-	list, err := s.subscriptions.Subscriptions()
+func (s *Service) Subscriptions(ctx context.Context, q pagination.Query) ([]Subscription, pagination.Page, error) {
+	list, page, err := s.subscriptions.Subscriptions(ctx, q)
 	if err != nil {
-		return []Subscription{}, fmt.Errorf("service didn't found any subscription: %w", err)
+		return []Subscription{}, pagination.Page{}, fmt.Errorf("service didn't found any subscription: %w", err)
 	}
-	return list, nil
+	return list, page, nil
 }
 
-func (s *Service) CreateSubscription(_ context.Context, sub *Subscription) error {
-	_, err := s.courses.Course(sub.CourseID)
-	if err != nil {
-		return fmt.Errorf("error checking if course %s exists: %w", sub.CourseID, err)
+func (s *Service) CreateSubscription(ctx context.Context, sub *Subscription) error {
+	if err := s.checkPublished(ctx, sub.CourseID); err != nil {
+		return err
+	}
+	if sub.Role == "" {
+		sub.Role = RoleStudent
 	}
-	if err := s.subscriptions.CreateSubscription(sub); err != nil {
+	if err := s.subscriptions.CreateSubscription(ctx, sub); err != nil {
 		return fmt.Errorf("service can't create subscription: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) UpdateSubscription(_ context.Context, sub *Subscription) error {
-	if err := s.subscriptions.UpdateSubscription(sub); err != nil {
+func (s *Service) UpdateSubscription(ctx context.Context, sub *Subscription) error {
+	if err := s.subscriptionNoneMatch(ctx, sub.UUID); err != nil {
+		return err
+	}
+	if err := s.checkCourseChange(ctx, sub); err != nil {
+		return err
+	}
+	if err := s.subscriptions.UpdateSubscription(ctx, sub); err != nil {
 		return fmt.Errorf("service can't update subscription: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) DeleteSubscription(_ context.Context, id uuid.UUID) error {
-	if err := s.subscriptions.DeleteSubscription(id); err != nil {
+// PatchSubscription writes only the given fields of the subscription
+func (s *Service) PatchSubscription(ctx context.Context, sub *Subscription, fields []string) error {
+	if err := s.subscriptionNoneMatch(ctx, sub.UUID); err != nil {
+		return err
+	}
+	if err := s.checkCourseChange(ctx, sub); err != nil {
+		return err
+	}
+	if err := s.subscriptions.PatchSubscription(ctx, sub, fields); err != nil {
+		return fmt.Errorf("service can't patch subscription: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := s.subscriptionNoneMatch(ctx, id); err != nil {
+		return err
+	}
+	if err := s.subscriptions.DeleteSubscription(ctx, id); err != nil {
 		return fmt.Errorf("service can't delete subscription: %w", err)
 	}
 	return nil
 }
+
+func (s *Service) RestoreSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	sub, err := s.subscriptions.RestoreSubscription(ctx, id)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("service can't restore subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// CourseMembers lists the subscriptions of the given course, optionally filtered by role
+func (s *Service) CourseMembers(ctx context.Context, courseID uuid.UUID, q pagination.Query) ([]Subscription, pagination.Page, error) {
+	if _, err := s.courses.Course(ctx, courseID); err != nil {
+		return []Subscription{}, pagination.Page{}, fmt.Errorf("error checking if course %s exists: %w", courseID, err)
+	}
+
+	q.Filters["course_id"] = courseID
+	list, page, err := s.subscriptions.Subscriptions(ctx, q)
+	if err != nil {
+		return []Subscription{}, pagination.Page{}, fmt.Errorf("service didn't found any member: %w", err)
+	}
+	return list, page, nil
+}
+
+// ExpireSubscriptions marks the subscriptions whose expiration passed, their expiration is
+// announced to the other services through the outbox
+func (s *Service) ExpireSubscriptions(ctx context.Context) error {
+	subs, err := s.subscriptions.ExpireSubscriptions(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("service can't expire subscriptions: %w", err)
+	}
+
+	if s.logger != nil && len(subs) > 0 {
+		s.logger.Log("msg", "expired subscriptions", "subscriptions", len(subs)) //nolint: errcheck
+	}
+	return nil
+}
+
+// checkPublished fails unless the course takes subscriptions
+func (s *Service) checkPublished(ctx context.Context, courseID uuid.UUID) error {
+	c, err := s.courses.Course(ctx, courseID)
+	if err != nil {
+		return fmt.Errorf("error checking if course %s exists: %w", courseID, err)
+	}
+	if c.Status != CoursePublished {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "course %s is not published", courseID)
+	}
+	return nil
+}
+
+// checkCourseChange applies the checks of a new subscription when the subscription moves to
+// another course
+func (s *Service) checkCourseChange(ctx context.Context, sub *Subscription) error {
+	current, err := s.subscriptions.Subscription(ctx, sub.UUID)
+	if err != nil {
+		return fmt.Errorf("service can't find subscription: %w", err)
+	}
+	if current.CourseID == sub.CourseID {
+		return nil
+	}
+	return s.checkPublished(ctx, sub.CourseID)
+}
+
+// subscriptionNoneMatch fails when the If-None-Match of the change matches the current subscription
+func (s *Service) subscriptionNoneMatch(ctx context.Context, id uuid.UUID) error {
+	return etag.CheckNoneMatch(ctx, func() (int, error) {
+		current, err := s.subscriptions.Subscription(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("service can't find subscription: %w", err)
+		}
+		return current.Version, nil
+	})
+}
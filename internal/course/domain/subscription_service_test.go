@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+type stubCourses struct {
+	CourseRepository
+	courses map[uuid.UUID]Course
+}
+
+func (r *stubCourses) Course(_ context.Context, id uuid.UUID) (Course, error) {
+	c, ok := r.courses[id]
+	if !ok {
+		return Course{}, errors.NewErrorf(errors.ErrCodeNotFound, "error getting course")
+	}
+	return c, nil
+}
+
+type stubSubscriptions struct {
+	SubscriptionRepository
+	current Subscription
+	written bool
+}
+
+func (r *stubSubscriptions) Subscription(_ context.Context, _ uuid.UUID) (Subscription, error) {
+	return r.current, nil
+}
+
+func (r *stubSubscriptions) UpdateSubscription(_ context.Context, _ *Subscription) error {
+	r.written = true
+	return nil
+}
+
+func (r *stubSubscriptions) PatchSubscription(_ context.Context, _ *Subscription, _ []string) error {
+	r.written = true
+	return nil
+}
+
+func TestService_SubscriptionCourseChange(t *testing.T) {
+	var (
+		published = uuid.New()
+		draft     = uuid.New()
+		archived  = uuid.New()
+	)
+	courses := &stubCourses{courses: map[uuid.UUID]Course{
+		published: {UUID: published, Status: CoursePublished},
+		draft:     {UUID: draft, Status: CourseDraft},
+		archived:  {UUID: archived, Status: CourseArchived},
+	}}
+
+	tests := []struct {
+		name     string
+		current  uuid.UUID
+		target   uuid.UUID
+		wantCode errors.ErrorCode
+	}{
+		{name: "same course", current: draft, target: draft},
+		{name: "to a published course", current: draft, target: published},
+		{name: "to a draft course", current: published, target: draft, wantCode: errors.ErrCodeInvalidArgument},
+		{name: "to an archived course", current: published, target: archived, wantCode: errors.ErrCodeInvalidArgument},
+		{name: "to a missing course", current: published, target: uuid.New(), wantCode: errors.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writes := map[string]func(s *Service, sub *Subscription) error{
+				"UpdateSubscription": func(s *Service, sub *Subscription) error {
+					return s.UpdateSubscription(context.Background(), sub)
+				},
+				"PatchSubscription": func(s *Service, sub *Subscription) error {
+					return s.PatchSubscription(context.Background(), sub, []string{"course_id"})
+				},
+			}
+			for name, write := range writes {
+				subscriptions := &stubSubscriptions{current: Subscription{UUID: uuid.New(), CourseID: tt.current}}
+				s, _ := NewService(WithCourseRepository(courses), WithSubscriptionRepository(subscriptions))
+
+				err := write(s, &Subscription{UUID: subscriptions.current.UUID, CourseID: tt.target})
+				if tt.wantCode == errors.ErrCodeUnknown {
+					if err != nil || !subscriptions.written {
+						t.Errorf("%s() error = %v, written = %v", name, err, subscriptions.written)
+					}
+					continue
+				}
+				if errors.CodeOf(err) != tt.wantCode || subscriptions.written {
+					t.Errorf("%s() error = %v, want code %v without writing", name, err, tt.wantCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+)
+
+type tracingService struct {
+	next ServiceInterface
+}
+
+// NewTracingService starts a span for every call to the service, the parent of the spans of the
+// course client and of the statements it runs
+func NewTracingService(next ServiceInterface) ServiceInterface {
+	return &tracingService{next: next}
+}
+
+func (s *tracingService) Course(ctx context.Context, id uuid.UUID) (res Course, err error) {
+	ctx, span := tracing.Start(ctx, "course.Course")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Course(ctx, id)
+}
+
+func (s *tracingService) Courses(ctx context.Context, q pagination.Query) (list []Course, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "course.Courses")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Courses(ctx, q)
+}
+
+func (s *tracingService) CreateCourse(ctx context.Context, c *Course) (err error) {
+	ctx, span := tracing.Start(ctx, "course.CreateCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateCourse(ctx, c)
+}
+
+func (s *tracingService) UpdateCourse(ctx context.Context, c *Course) (err error) {
+	ctx, span := tracing.Start(ctx, "course.UpdateCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateCourse(ctx, c)
+}
+
+func (s *tracingService) PatchCourse(ctx context.Context, c *Course, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "course.PatchCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.PatchCourse(ctx, c, fields)
+}
+
+func (s *tracingService) DeleteCourse(ctx context.Context, courseID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "course.DeleteCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteCourse(ctx, courseID)
+}
+
+func (s *tracingService) RestoreCourse(ctx context.Context, courseID uuid.UUID) (res Course, err error) {
+	ctx, span := tracing.Start(ctx, "course.RestoreCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RestoreCourse(ctx, courseID)
+}
+
+func (s *tracingService) TransitionCourse(ctx context.Context, courseID uuid.UUID, t CourseTransition, changedBy *uuid.UUID) (res Course, err error) {
+	ctx, span := tracing.Start(ctx, "course.TransitionCourse")
+	defer func() { tracing.End(span, err) }()
+	return s.next.TransitionCourse(ctx, courseID, t, changedBy)
+}
+
+func (s *tracingService) Subscription(ctx context.Context, id uuid.UUID) (res Subscription, err error) {
+	ctx, span := tracing.Start(ctx, "course.Subscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Subscription(ctx, id)
+}
+
+func (s *tracingService) Subscriptions(ctx context.Context, q pagination.Query) (list []Subscription, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "course.Subscriptions")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Subscriptions(ctx, q)
+}
+
+func (s *tracingService) CreateSubscription(ctx context.Context, cs *Subscription) (err error) {
+	ctx, span := tracing.Start(ctx, "course.CreateSubscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateSubscription(ctx, cs)
+}
+
+func (s *tracingService) UpdateSubscription(ctx context.Context, cs *Subscription) (err error) {
+	ctx, span := tracing.Start(ctx, "course.UpdateSubscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateSubscription(ctx, cs)
+}
+
+func (s *tracingService) PatchSubscription(ctx context.Context, cs *Subscription, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "course.PatchSubscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.PatchSubscription(ctx, cs, fields)
+}
+
+func (s *tracingService) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "course.DeleteSubscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+func (s *tracingService) RestoreSubscription(ctx context.Context, id uuid.UUID) (res Subscription, err error) {
+	ctx, span := tracing.Start(ctx, "course.RestoreSubscription")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RestoreSubscription(ctx, id)
+}
+
+func (s *tracingService) CourseMembers(ctx context.Context, courseID uuid.UUID, q pagination.Query) (list []Subscription, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "course.CourseMembers")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CourseMembers(ctx, courseID, q)
+}
@@ -34,6 +34,7 @@ type createCourseResponse struct {
 	ImageCover  string    `json:"image_cover,omitempty"`
 	Excerpt     string    `json:"excerpt"`
 	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -90,6 +91,7 @@ func makeCreateCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			ImageCover:  c.ImageCover,
 			Excerpt:     c.Excerpt,
 			Description: c.Description,
+			Status:      string(c.Status),
 			CreatedAt:   c.CreatedAt,
 			UpdatedAt:   c.UpdatedAt,
 		}, nil
@@ -21,6 +21,7 @@ type createSubscriptionRequest struct {
 	UserID    uuid.UUID  `json:"user_id" validate:"required"`
 	CourseID  uuid.UUID  `json:"course_id" validate:"required"`
 	MatrixID  *uuid.UUID `json:"matrix_id"`
+	Role      string     `json:"role" validate:"omitempty,oneof=student instructor teaching_assistant observer"`
 	ExpiresAt *time.Time `json:"expires_at"`
 }
 
@@ -29,6 +30,7 @@ type createSubscriptionResponse struct {
 	UserID    uuid.UUID  `json:"user_id"`
 	CourseID  uuid.UUID  `json:"course_id"`
 	MatrixID  *uuid.UUID `json:"matrix_id,omitempty"`
+	Role      string     `json:"role"`
 	ExpiresAt *time.Time `json:"expires_at"`
 }
 
@@ -68,6 +70,7 @@ func makeCreateSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint
 			UserID:    sub.UserID,
 			CourseID:  sub.CourseID,
 			MatrixID:  sub.MatrixID,
+			Role:      string(sub.Role),
 			ExpiresAt: sub.ExpiresAt,
 		}, nil
 	}
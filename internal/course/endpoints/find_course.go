@@ -9,9 +9,9 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type findCourseRequest struct {
@@ -26,6 +26,8 @@ type findCourseResponse struct {
 	ImageCover  string    `json:"image_cover,omitempty"`
 	Excerpt     string    `json:"excerpt"`
 	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	Version     int       `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -52,6 +54,7 @@ func makeFindCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 		}
 
 		return &findCourseResponse{
+			Version:     c.Version,
 			UUID:        c.UUID,
 			Name:        c.Name,
 			Underline:   c.Underline,
@@ -59,22 +62,24 @@ func makeFindCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			ImageCover:  c.ImageCover,
 			Excerpt:     c.Excerpt,
 			Description: c.Description,
+			Status:      string(c.Status),
 		}, nil
 	}
 }
 
 func decodeFindCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return findCourseRequest{UUID: uid}, nil
+	return findCourseRequest{UUID: id}, nil
 }
 
 func encodeFindCourseResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(*findCourseResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
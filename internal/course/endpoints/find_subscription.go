@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/go-kit/kit/endpoint"
 
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type findSubscriptionRequest struct {
@@ -25,7 +25,9 @@ type findSubscriptionResponse struct {
 	UserID    uuid.UUID  `json:"user_id"`
 	CourseID  uuid.UUID  `json:"course_id"`
 	MatrixID  *uuid.UUID `json:"matrix_id,omitempty"`
+	Role      string     `json:"role"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Version   int        `json:"-"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
@@ -52,10 +54,12 @@ func makeFindSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 		}
 
 		return &findSubscriptionResponse{
+			Version:   sub.Version,
 			UUID:      sub.UUID,
 			UserID:    sub.UserID,
 			CourseID:  sub.CourseID,
 			MatrixID:  sub.MatrixID,
+			Role:      string(sub.Role),
 			ExpiresAt: sub.ExpiresAt,
 			CreatedAt: sub.CreatedAt,
 			UpdatedAt: sub.UpdatedAt,
@@ -64,17 +68,18 @@ func makeFindSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 }
 
 func decodeFindSubscriptionRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return findSubscriptionRequest{UUID: uid}, nil
+	return findSubscriptionRequest{UUID: id}, nil
 }
 
 func encodeFindSubscriptionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(*findSubscriptionResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
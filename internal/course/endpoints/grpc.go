@@ -0,0 +1,272 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	pb "github.com/sumelms/microservice-course/proto/course"
+)
+
+// The gRPC handlers reuse the endpoints of the HTTP handlers, only the decoding of the
+// protobuf requests and the encoding of the responses differ.
+
+func NewGRPCCreateCourseHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeCreateCourseEndpoint(s), decodeGRPCCreateCourseRequest, encodeGRPCCourseResponse, opts...)
+}
+
+func NewGRPCFindCourseHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeFindCourseEndpoint(s), decodeGRPCFindCourseRequest, encodeGRPCCourseResponse, opts...)
+}
+
+func NewGRPCUpdateCourseHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeUpdateCourseEndpoint(s), decodeGRPCUpdateCourseRequest, encodeGRPCCourseResponse, opts...)
+}
+
+func NewGRPCDeleteCourseHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeDeleteCourseEndpoint(s), decodeGRPCDeleteCourseRequest,
+		func(context.Context, interface{}) (interface{}, error) {
+			return &pb.DeleteCourseResponse{}, nil
+		}, opts...)
+}
+
+func NewGRPCCreateSubscriptionHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeCreateSubscriptionEndpoint(s), decodeGRPCCreateSubscriptionRequest,
+		encodeGRPCSubscriptionResponse, opts...)
+}
+
+func NewGRPCFindSubscriptionHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeFindSubscriptionEndpoint(s), decodeGRPCFindSubscriptionRequest,
+		encodeGRPCSubscriptionResponse, opts...)
+}
+
+func NewGRPCDeleteSubscriptionHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeDeleteSubscriptionEndpoint(s), decodeGRPCDeleteSubscriptionRequest,
+		func(context.Context, interface{}) (interface{}, error) {
+			return &pb.DeleteSubscriptionResponse{}, nil
+		}, opts...)
+}
+
+func decodeGRPCCreateCourseRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.CreateCourseRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	return createCourseRequest{
+		Code:        req.Code,
+		Name:        req.Name,
+		Underline:   req.Underline,
+		Image:       req.Image,
+		ImageCover:  req.ImageCover,
+		Excerpt:     req.Excerpt,
+		Description: req.Description,
+	}, nil
+}
+
+func decodeGRPCFindCourseRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.FindCourseRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return findCourseRequest{UUID: id}, nil
+}
+
+func decodeGRPCUpdateCourseRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.UpdateCourseRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return updateCourseRequest{
+		UUID:        id,
+		Name:        req.Name,
+		Underline:   req.Underline,
+		Image:       req.Image,
+		ImageCover:  req.ImageCover,
+		Excerpt:     req.Excerpt,
+		Description: req.Description,
+	}, nil
+}
+
+func decodeGRPCDeleteCourseRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.DeleteCourseRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return deleteCourseRequest{UUID: id}, nil
+}
+
+func decodeGRPCCreateSubscriptionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.CreateSubscriptionRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	userID, err := parseUUID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	courseID, err := parseUUID("course_id", req.CourseId)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := createSubscriptionRequest{UserID: userID, CourseID: courseID, Role: req.Role}
+	if req.MatrixId != "" {
+		matrixID, err := parseUUID("matrix_id", req.MatrixId)
+		if err != nil {
+			return nil, err
+		}
+		sub.MatrixID = &matrixID
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		sub.ExpiresAt = &expiresAt
+	}
+	return sub, nil
+}
+
+func decodeGRPCFindSubscriptionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.FindSubscriptionRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return findSubscriptionRequest{UUID: id}, nil
+}
+
+func decodeGRPCDeleteSubscriptionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.DeleteSubscriptionRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return deleteSubscriptionRequest{ID: id}, nil
+}
+
+func encodeGRPCCourseResponse(_ context.Context, response interface{}) (interface{}, error) {
+	switch r := response.(type) {
+	case createCourseResponse:
+		return &pb.Course{
+			Uuid:        r.UUID.String(),
+			Code:        r.Code,
+			Name:        r.Name,
+			Underline:   r.Underline,
+			Image:       r.Image,
+			ImageCover:  r.ImageCover,
+			Excerpt:     r.Excerpt,
+			Description: r.Description,
+			Status:      r.Status,
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	case *findCourseResponse:
+		return &pb.Course{
+			Uuid:        r.UUID.String(),
+			Name:        r.Name,
+			Underline:   r.Underline,
+			Image:       r.Image,
+			ImageCover:  r.ImageCover,
+			Excerpt:     r.Excerpt,
+			Description: r.Description,
+			Status:      r.Status,
+			Version:     int32(r.Version),
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	case updateCourseResponse:
+		return &pb.Course{
+			Uuid:        r.UUID.String(),
+			Name:        r.Name,
+			Underline:   r.Underline,
+			Image:       r.Image,
+			ImageCover:  r.ImageCover,
+			Excerpt:     r.Excerpt,
+			Description: r.Description,
+			Status:      r.Status,
+			Version:     int32(r.Version),
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid response")
+}
+
+func encodeGRPCSubscriptionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	switch r := response.(type) {
+	case createSubscriptionResponse:
+		return &pb.Subscription{
+			Uuid:      r.UUID.String(),
+			UserId:    r.UserID.String(),
+			CourseId:  r.CourseID.String(),
+			MatrixId:  optionalUUID(r.MatrixID),
+			Role:      r.Role,
+			ExpiresAt: optionalTimestamp(r.ExpiresAt),
+		}, nil
+	case *findSubscriptionResponse:
+		return &pb.Subscription{
+			Uuid:      r.UUID.String(),
+			UserId:    r.UserID.String(),
+			CourseId:  r.CourseID.String(),
+			MatrixId:  optionalUUID(r.MatrixID),
+			Role:      r.Role,
+			ExpiresAt: optionalTimestamp(r.ExpiresAt),
+			Version:   int32(r.Version),
+			CreatedAt: timestamp(r.CreatedAt),
+			UpdatedAt: timestamp(r.UpdatedAt),
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid response")
+}
+
+func parseUUID(field, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "%s must be a valid UUID", field)
+	}
+	return id, nil
+}
+
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// timestamp leaves the zero time unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
@@ -2,16 +2,23 @@ package endpoints
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
+type listCourseRequest struct {
+	Query pagination.Query
+}
+
 type listCourseResponse struct {
 	Courses []findCourseResponse `json:"courses"`
+	pagination.Page
 }
 
 func NewListCourseHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
@@ -25,7 +32,12 @@ func NewListCourseHandler(s domain.ServiceInterface, opts ...kithttp.ServerOptio
 
 func makeListCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		cc, err := s.Courses(ctx)
+		req, ok := request.(listCourseRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		cc, page, err := s.Courses(ctx, req.Query)
 		if err != nil {
 			return nil, err
 		}
@@ -41,17 +53,22 @@ func makeListCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 				ImageCover:  c.ImageCover,
 				Excerpt:     c.Excerpt,
 				Description: c.Description,
+				Status:      string(c.Status),
 				CreatedAt:   c.CreatedAt,
 				UpdatedAt:   c.UpdatedAt,
 			})
 		}
 
-		return &listCourseResponse{Courses: list}, nil
+		return &listCourseResponse{Courses: list, Page: page}, nil
 	}
 }
 
-func decodeListCourseRequest(_ context.Context, _ *http.Request) (interface{}, error) {
-	return nil, nil
+func decodeListCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := pagination.ParseQuery(r, domain.CourseListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listCourseRequest{Query: q}, nil
 }
 
 func encodeListCourseResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
@@ -0,0 +1,108 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type listMemberRequest struct {
+	CourseID uuid.UUID
+	Query    pagination.Query
+}
+
+type memberResponse struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Role           string     `json:"role"`
+	Staff          bool       `json:"staff"`
+	MatrixID       *uuid.UUID `json:"matrix_id,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type listMemberResponse struct {
+	Members []memberResponse `json:"members"`
+	pagination.Page
+}
+
+// NewListMemberHandler creates the course members handler
+// @Summary      List course members
+// @Description  List the users subscribed to the course, optionally filtered by role
+// @Tags         course
+// @Produce      json
+// @Param        uuid  path      string  true   "Course UUID"
+// @Param        role  query     string  false  "Role"  Enums(student, instructor, teaching_assistant, observer)
+// @Success      200      {object}  listMemberResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /courses/{uuid}/members [get]
+func NewListMemberHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListMemberEndpoint(s),
+		decodeListMemberRequest,
+		encodeListMemberResponse,
+		opts...,
+	)
+}
+
+func makeListMemberEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listMemberRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		members, page, err := s.CourseMembers(ctx, req.CourseID, req.Query)
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]memberResponse, 0, len(members))
+		for i := range members {
+			m := members[i]
+			list = append(list, memberResponse{
+				SubscriptionID: m.UUID,
+				UserID:         m.UserID,
+				Role:           string(m.Role),
+				Staff:          m.Role.IsStaff(),
+				MatrixID:       m.MatrixID,
+				ExpiresAt:      m.ExpiresAt,
+				CreatedAt:      m.CreatedAt,
+			})
+		}
+
+		return &listMemberResponse{Members: list, Page: page}, nil
+	}
+}
+
+func decodeListMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := pagination.ParseQuery(r, domain.MemberListFields)
+	if err != nil {
+		return nil, err
+	}
+	if role, ok := q.Filters["role"].(string); ok && !domain.SubscriptionRole(role).Valid() {
+		return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid role %s", role)
+	}
+
+	return listMemberRequest{CourseID: id, Query: q}, nil
+}
+
+func encodeListMemberResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -7,18 +7,18 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
-	"github.com/google/uuid"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 type listSubscriptionRequest struct {
-	CourseID uuid.UUID `json:"course_id"`
-	UserID   uuid.UUID `json:"user_id"`
+	Query pagination.Query
 }
 
 type listSubscriptionResponse struct {
 	Subscriptions []findSubscriptionResponse `json:"subscriptions"`
+	pagination.Page
 }
 
 func NewListSubscriptionHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
@@ -37,16 +37,7 @@ func makeListSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, fmt.Errorf("invalid argument")
 		}
 
-		filters := make(map[string]interface{})
-		if req.CourseID != uuid.Nil {
-			filters["course_id"] = req.CourseID
-		}
-		if req.UserID != uuid.Nil {
-			filters["user_id"] = req.UserID
-		}
-
-		// @TODO Implement filters to service
-		subscriptions, err := s.Subscriptions(ctx)
+		subscriptions, page, err := s.Subscriptions(ctx, req.Query)
 		if err != nil {
 			return nil, err
 		}
@@ -59,23 +50,23 @@ func makeListSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 				UserID:    sub.UserID,
 				CourseID:  sub.CourseID,
 				MatrixID:  sub.MatrixID,
+				Role:      string(sub.Role),
 				ExpiresAt: sub.ExpiresAt,
 				CreatedAt: sub.CreatedAt,
 				UpdatedAt: sub.UpdatedAt,
 			})
 		}
 
-		return &listSubscriptionResponse{Subscriptions: list}, nil
+		return &listSubscriptionResponse{Subscriptions: list, Page: page}, nil
 	}
 }
 
 func decodeListSubscriptionRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	courseID := r.FormValue("course_id")
-	userID := r.FormValue("user_id")
-	return listSubscriptionRequest{
-		CourseID: uuid.MustParse(courseID),
-		UserID:   uuid.MustParse(userID),
-	}, nil
+	q, err := pagination.ParseQuery(r, domain.SubscriptionListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listSubscriptionRequest{Query: q}, nil
 }
 
 func encodeListSubscriptionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
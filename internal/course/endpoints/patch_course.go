@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type patchCourseRequest struct {
+	UUID  uuid.UUID       `json:"uuid" validate:"required"`
+	Patch json.RawMessage `json:"patch" validate:"required"`
+}
+
+func NewPatchCourseHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makePatchCourseEndpoint(s),
+		decodePatchCourseRequest,
+		encodeUpdateCourseResponse,
+		opts...,
+	)
+}
+
+//nolint:dupl
+func makePatchCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(patchCourseRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		c, err := s.Course(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if err := etag.Check(ctx, c.Version); err != nil {
+			return nil, err
+		}
+
+		// The patch is merged into the fields the update accepts, so it is validated the same way
+		var update updateCourseRequest
+		data, _ := json.Marshal(c)
+		if err := json.Unmarshal(data, &update); err != nil {
+			return nil, err
+		}
+		fields, err := patch.Apply(&update, req.Patch)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			return newUpdateCourseResponse(c), nil
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(update); err != nil {
+			return nil, err
+		}
+
+		data, _ = json.Marshal(update)
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, err
+		}
+		c.UUID = req.UUID
+
+		if err := s.PatchCourse(ctx, &c, fields); err != nil {
+			return nil, err
+		}
+
+		return newUpdateCourseResponse(c), nil
+	}
+}
+
+func decodePatchCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return patchCourseRequest{UUID: id, Patch: data}, nil
+}
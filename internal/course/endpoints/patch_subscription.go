@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type patchSubscriptionRequest struct {
+	UUID  uuid.UUID       `json:"uuid" validate:"required"`
+	Patch json.RawMessage `json:"patch" validate:"required"`
+}
+
+func NewPatchSubscriptionHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makePatchSubscriptionEndpoint(s),
+		decodePatchSubscriptionRequest,
+		encodeUpdateSubscriptionResponse,
+		opts...,
+	)
+}
+
+//nolint:dupl
+func makePatchSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(patchSubscriptionRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		sub, err := s.Subscription(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if err := etag.Check(ctx, sub.Version); err != nil {
+			return nil, err
+		}
+
+		// The patch is merged into the fields the update accepts, so it is validated the same way
+		var update updateSubscriptionRequest
+		data, _ := json.Marshal(sub)
+		if err := json.Unmarshal(data, &update); err != nil {
+			return nil, err
+		}
+		fields, err := patch.Apply(&update, req.Patch)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			return newUpdateSubscriptionResponse(sub), nil
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(update); err != nil {
+			return nil, err
+		}
+
+		data, _ = json.Marshal(update)
+		if err := json.Unmarshal(data, &sub); err != nil {
+			return nil, err
+		}
+		sub.UUID = req.UUID
+
+		if err := s.PatchSubscription(ctx, &sub, fields); err != nil {
+			return nil, err
+		}
+
+		return newUpdateSubscriptionResponse(sub), nil
+	}
+}
+
+func decodePatchSubscriptionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return patchSubscriptionRequest{UUID: id, Patch: data}, nil
+}
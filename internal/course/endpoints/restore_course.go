@@ -0,0 +1,66 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+)
+
+type restoreCourseRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+func NewRestoreCourseHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRestoreCourseEndpoint(s),
+		decodeRestoreCourseRequest,
+		encodeRestoreCourseResponse,
+		opts...,
+	)
+}
+
+func makeRestoreCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(restoreCourseRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		c, err := s.RestoreCourse(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &findCourseResponse{
+			UUID:        c.UUID,
+			Name:        c.Name,
+			Underline:   c.Underline,
+			Image:       c.Image,
+			ImageCover:  c.ImageCover,
+			Excerpt:     c.Excerpt,
+			Description: c.Description,
+			Status:      string(c.Status),
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+		}, nil
+	}
+}
+
+func decodeRestoreCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreCourseRequest{UUID: id}, nil
+}
+
+func encodeRestoreCourseResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,65 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+)
+
+type restoreSubscriptionRequest struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+func NewRestoreSubscriptionHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRestoreSubscriptionEndpoint(s),
+		decodeRestoreSubscriptionRequest,
+		encodeRestoreSubscriptionResponse,
+		opts...,
+	)
+}
+
+func makeRestoreSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(restoreSubscriptionRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		sub, err := s.RestoreSubscription(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &findSubscriptionResponse{
+			UUID:      sub.UUID,
+			UserID:    sub.UserID,
+			CourseID:  sub.CourseID,
+			MatrixID:  sub.MatrixID,
+			Role:      string(sub.Role),
+			ExpiresAt: sub.ExpiresAt,
+			CreatedAt: sub.CreatedAt,
+			UpdatedAt: sub.UpdatedAt,
+		}, nil
+	}
+}
+
+func decodeRestoreSubscriptionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreSubscriptionRequest{ID: id}, nil
+}
+
+func encodeRestoreSubscriptionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
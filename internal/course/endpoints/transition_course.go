@@ -0,0 +1,109 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type transitionCourseRequest struct {
+	UUID       uuid.UUID               `json:"uuid" validate:"required"`
+	Transition domain.CourseTransition `json:"transition" validate:"required,oneof=submit publish reject archive reopen"`
+}
+
+type transitionCourseResponse struct {
+	UUID            uuid.UUID  `json:"uuid"`
+	Status          string     `json:"status"`
+	StatusChangedBy *uuid.UUID `json:"status_changed_by,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// NewTransitionCourseHandler creates the course publication lifecycle handler
+// @Summary      Transition course
+// @Description  Move the course through draft, review, published and archived, the status is changed by the caller
+// @Tags         course
+// @Produce      json
+// @Param        uuid        path      string  true  "Course UUID"
+// @Param        transition  path      string  true  "Transition"  Enums(submit, publish, reject, archive, reopen)
+// @Success      200      {object}  transitionCourseResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /courses/{uuid}/{transition} [post]
+func NewTransitionCourseHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeTransitionCourseEndpoint(s),
+		decodeTransitionCourseRequest,
+		encodeTransitionCourseResponse,
+		opts...,
+	)
+}
+
+func makeTransitionCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(transitionCourseRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		// Without authentication there is no caller to record as the author of the change
+		var changedBy *uuid.UUID
+		if _, ok := auth.FromContext(ctx); ok {
+			userID, err := auth.SubjectID(ctx)
+			if err != nil {
+				return nil, err
+			}
+			changedBy = &userID
+		}
+
+		c, err := s.TransitionCourse(ctx, req.UUID, req.Transition, changedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		return &transitionCourseResponse{
+			UUID:            c.UUID,
+			Status:          string(c.Status),
+			StatusChangedBy: c.StatusChangedBy,
+			StatusChangedAt: c.StatusChangedAt,
+			UpdatedAt:       c.UpdatedAt,
+		}, nil
+	}
+}
+
+func decodeTransitionCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	transition, ok := vars["transition"]
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+
+	return transitionCourseRequest{
+		UUID:       id,
+		Transition: domain.CourseTransition(transition),
+	}, nil
+}
+
+func encodeTransitionCourseResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,67 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/auth"
+)
+
+type stubTransitions struct {
+	domain.ServiceInterface
+	changedBy *uuid.UUID
+}
+
+func (s *stubTransitions) TransitionCourse(_ context.Context, id uuid.UUID, _ domain.CourseTransition,
+	changedBy *uuid.UUID) (domain.Course, error) {
+	s.changedBy = changedBy
+	return domain.Course{UUID: id, Status: domain.CoursePublished, StatusChangedBy: changedBy}, nil
+}
+
+func TestTransitionCourseHandler(t *testing.T) {
+	staff := uuid.MustParse("d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d")
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		wantChangedBy *uuid.UUID
+	}{
+		{name: "without authentication", ctx: context.Background()},
+		{
+			name: "authenticated caller",
+			ctx: auth.NewContext(context.Background(), &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: staff.String()},
+			}),
+			wantChangedBy: &staff,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &stubTransitions{}
+			id := uuid.New()
+			r := httptest.NewRequest(http.MethodPost, "/courses/"+id.String()+"/publish", nil).WithContext(tt.ctx)
+			r = mux.SetURLVars(r, map[string]string{"uuid": id.String(), "transition": "publish"})
+			w := httptest.NewRecorder()
+			NewTransitionCourseHandler(s).ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("TransitionCourse status = %v, want %v: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			if (s.changedBy == nil) != (tt.wantChangedBy == nil) ||
+				(s.changedBy != nil && *s.changedBy != *tt.wantChangedBy) {
+				t.Errorf("TransitionCourse changedBy = %v, want %v", s.changedBy, tt.wantChangedBy)
+			}
+		})
+	}
+}
@@ -10,14 +10,15 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 	"github.com/sumelms/microservice-course/pkg/validator"
 )
 
 type updateCourseRequest struct {
 	UUID        uuid.UUID `json:"uuid" validate:"required"`
+	Code        string    `json:"code" validate:"required,max=15"`
 	Name        string    `json:"name" validate:"required,max=100"`
 	Underline   string    `json:"underline" validate:"required,max=100"`
 	Image       string    `json:"image"`
@@ -28,12 +29,15 @@ type updateCourseRequest struct {
 
 type updateCourseResponse struct {
 	UUID        uuid.UUID `json:"uuid"`
+	Code        string    `json:"code"`
 	Name        string    `json:"name"`
 	Underline   string    `json:"underline"`
 	Image       string    `json:"image"`
 	ImageCover  string    `json:"image_cover"`
 	Excerpt     string    `json:"excerpt"`
 	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Version     int       `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -69,36 +73,45 @@ func makeUpdateCourseEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return updateCourseResponse{
-			UUID:        c.UUID,
-			Name:        c.Name,
-			Underline:   c.Underline,
-			Image:       c.Image,
-			ImageCover:  c.ImageCover,
-			Excerpt:     c.Excerpt,
-			Description: c.Description,
-		}, nil
+		return newUpdateCourseResponse(c), nil
+	}
+}
+
+func newUpdateCourseResponse(c domain.Course) updateCourseResponse {
+	return updateCourseResponse{
+		Version:     c.Version,
+		UUID:        c.UUID,
+		Code:        c.Code,
+		Name:        c.Name,
+		Underline:   c.Underline,
+		Image:       c.Image,
+		ImageCover:  c.ImageCover,
+		Excerpt:     c.Excerpt,
+		Description: c.Description,
+		Status:      string(c.Status),
 	}
 }
 
 func decodeUpdateCourseRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
 	var req updateCourseRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, err
 	}
 
-	req.UUID = uuid.MustParse(id)
+	req.UUID = id
 
 	return req, nil
 }
 
 func encodeUpdateCourseResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(updateCourseResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
@@ -0,0 +1,75 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+type stubUpdates struct {
+	domain.ServiceInterface
+	updated *domain.Course
+}
+
+func (s *stubUpdates) UpdateCourse(_ context.Context, c *domain.Course) error {
+	s.updated = c
+	return nil
+}
+
+func TestUpdateCourseHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name: "course code kept",
+			body: `{"code":"SUME","name":"Course","underline":"Underline","excerpt":"Excerpt",
+				"description":"Description"}`,
+			wantStatus: http.StatusOK,
+			wantCode:   "SUME",
+		},
+		{
+			name:       "missing course code",
+			body:       `{"name":"Course","underline":"Underline","excerpt":"Excerpt","description":"Description"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &stubUpdates{}
+			id := uuid.New()
+			r := httptest.NewRequest(http.MethodPut, "/courses/"+id.String(), strings.NewReader(tt.body))
+			r = mux.SetURLVars(r, map[string]string{"uuid": id.String()})
+			w := httptest.NewRecorder()
+			NewUpdateCourseHandler(s, kithttp.ServerErrorEncoder(errors.EncodeError)).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("UpdateCourse status = %v, want %v: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				if s.updated != nil {
+					t.Errorf("UpdateCourse updated %+v, want the request turned down", s.updated)
+				}
+				return
+			}
+			if s.updated == nil || s.updated.Code != tt.wantCode {
+				t.Errorf("UpdateCourse updated %+v, want code %v", s.updated, tt.wantCode)
+			}
+		})
+	}
+}
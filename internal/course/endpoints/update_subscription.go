@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/pkg/validator"
 
@@ -16,14 +15,16 @@ import (
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type updateSubscriptionRequest struct {
-	UUID       uuid.UUID  `json:"uuid" validate:"required"`
-	UserID     uuid.UUID  `json:"user_id" validate:"required"`
-	CourseID   uuid.UUID  `json:"course_id" validate:"required"`
-	MatrixID   *uuid.UUID `json:"matrix_id"`
-	ValidUntil *time.Time `json:"valid_until"`
+	UUID      uuid.UUID  `json:"uuid" validate:"required"`
+	UserID    uuid.UUID  `json:"user_id" validate:"required"`
+	CourseID  uuid.UUID  `json:"course_id" validate:"required"`
+	MatrixID  *uuid.UUID `json:"matrix_id"`
+	Role      string     `json:"role" validate:"required,oneof=student instructor teaching_assistant observer"`
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 type updateSubscriptionResponse struct {
@@ -31,7 +32,9 @@ type updateSubscriptionResponse struct {
 	UserID    uuid.UUID  `json:"user_id"`
 	CourseID  uuid.UUID  `json:"course_id"`
 	MatrixID  *uuid.UUID `json:"matrix_id,omitempty"`
+	Role      string     `json:"role"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Version   int        `json:"-"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
@@ -67,23 +70,28 @@ func makeUpdateSubscriptionEndpoint(s domain.ServiceInterface) endpoint.Endpoint
 			return nil, err
 		}
 
-		return updateSubscriptionResponse{
-			UUID:      sub.UUID,
-			UserID:    sub.UserID,
-			CourseID:  sub.CourseID,
-			MatrixID:  sub.MatrixID,
-			ExpiresAt: sub.ExpiresAt,
-			CreatedAt: sub.CreatedAt,
-			UpdatedAt: sub.UpdatedAt,
-		}, nil
+		return newUpdateSubscriptionResponse(sub), nil
+	}
+}
+
+func newUpdateSubscriptionResponse(sub domain.Subscription) updateSubscriptionResponse {
+	return updateSubscriptionResponse{
+		Version:   sub.Version,
+		UUID:      sub.UUID,
+		UserID:    sub.UserID,
+		CourseID:  sub.CourseID,
+		MatrixID:  sub.MatrixID,
+		Role:      string(sub.Role),
+		ExpiresAt: sub.ExpiresAt,
+		CreatedAt: sub.CreatedAt,
+		UpdatedAt: sub.UpdatedAt,
 	}
 }
 
 func decodeUpdateSubscriptionRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
 	var req updateSubscriptionRequest
@@ -91,11 +99,15 @@ func decodeUpdateSubscriptionRequest(_ context.Context, r *http.Request) (interf
 		return nil, err
 	}
 
-	req.UUID = uuid.MustParse(id)
+	req.UUID = id
 
 	return req, nil
 }
 
 func encodeUpdateSubscriptionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(updateSubscriptionResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
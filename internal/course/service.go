@@ -3,12 +3,15 @@ package course
 import (
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
 
 	"github.com/go-kit/log"
 
 	"github.com/sumelms/microservice-course/internal/course/database"
 	"github.com/sumelms/microservice-course/internal/course/domain"
 	"github.com/sumelms/microservice-course/internal/course/transport"
+	"github.com/sumelms/microservice-course/pkg/idempotency"
+	pb "github.com/sumelms/microservice-course/proto/course"
 )
 
 func NewService(db *sqlx.DB, logger log.Logger) (*domain.Service, error) {
@@ -31,7 +34,13 @@ func NewService(db *sqlx.DB, logger log.Logger) (*domain.Service, error) {
 	return service, nil
 }
 
-func NewHTTPService(router *mux.Router, service domain.ServiceInterface, logger log.Logger) error {
-	transport.NewHTTPHandler(router, service, logger)
+func NewHTTPService(router *mux.Router, service domain.ServiceInterface, logger log.Logger,
+	guard *idempotency.Guard) error {
+	transport.NewHTTPHandler(router, service, logger, guard)
+	return nil
+}
+
+func NewGRPCService(server *grpc.Server, service domain.ServiceInterface, logger log.Logger) error {
+	pb.RegisterCourseServiceServer(server, transport.NewGRPCServer(service, logger))
 	return nil
 }
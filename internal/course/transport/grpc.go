@@ -0,0 +1,102 @@
+package transport
+
+import (
+	"context"
+
+	kittransport "github.com/go-kit/kit/transport"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-kit/log"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/internal/course/endpoints"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	pb "github.com/sumelms/microservice-course/proto/course"
+)
+
+type grpcServer struct {
+	pb.UnimplementedCourseServiceServer
+
+	createCourse       kitgrpc.Handler
+	findCourse         kitgrpc.Handler
+	updateCourse       kitgrpc.Handler
+	deleteCourse       kitgrpc.Handler
+	createSubscription kitgrpc.Handler
+	findSubscription   kitgrpc.Handler
+	deleteSubscription kitgrpc.Handler
+}
+
+func NewGRPCServer(s domain.ServiceInterface, logger log.Logger) pb.CourseServiceServer {
+	opts := []kitgrpc.ServerOption{
+		kitgrpc.ServerBefore(tracing.GRPCToContext),
+		kitgrpc.ServerFinalizer(tracing.GRPCFinalizer),
+		kitgrpc.ServerErrorHandler(kittransport.NewLogErrorHandler(logger)),
+	}
+
+	return &grpcServer{
+		createCourse:       endpoints.NewGRPCCreateCourseHandler(s, opts...),
+		findCourse:         endpoints.NewGRPCFindCourseHandler(s, opts...),
+		updateCourse:       endpoints.NewGRPCUpdateCourseHandler(s, opts...),
+		deleteCourse:       endpoints.NewGRPCDeleteCourseHandler(s, opts...),
+		createSubscription: endpoints.NewGRPCCreateSubscriptionHandler(s, opts...),
+		findSubscription:   endpoints.NewGRPCFindSubscriptionHandler(s, opts...),
+		deleteSubscription: endpoints.NewGRPCDeleteSubscriptionHandler(s, opts...),
+	}
+}
+
+func (s *grpcServer) CreateCourse(ctx context.Context, req *pb.CreateCourseRequest) (*pb.Course, error) {
+	_, resp, err := s.createCourse.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Course), nil
+}
+
+func (s *grpcServer) FindCourse(ctx context.Context, req *pb.FindCourseRequest) (*pb.Course, error) {
+	_, resp, err := s.findCourse.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Course), nil
+}
+
+func (s *grpcServer) UpdateCourse(ctx context.Context, req *pb.UpdateCourseRequest) (*pb.Course, error) {
+	_, resp, err := s.updateCourse.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Course), nil
+}
+
+func (s *grpcServer) DeleteCourse(ctx context.Context, req *pb.DeleteCourseRequest) (*pb.DeleteCourseResponse, error) {
+	_, resp, err := s.deleteCourse.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeleteCourseResponse), nil
+}
+
+func (s *grpcServer) CreateSubscription(ctx context.Context,
+	req *pb.CreateSubscriptionRequest) (*pb.Subscription, error) {
+	_, resp, err := s.createSubscription.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Subscription), nil
+}
+
+func (s *grpcServer) FindSubscription(ctx context.Context, req *pb.FindSubscriptionRequest) (*pb.Subscription, error) {
+	_, resp, err := s.findSubscription.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Subscription), nil
+}
+
+func (s *grpcServer) DeleteSubscription(ctx context.Context,
+	req *pb.DeleteSubscriptionRequest) (*pb.DeleteSubscriptionResponse, error) {
+	_, resp, err := s.deleteSubscription.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeleteSubscriptionResponse), nil
+}
@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+	pb "github.com/sumelms/microservice-course/proto/course"
+)
+
+type stubService struct {
+	domain.ServiceInterface
+	course domain.Course
+}
+
+func (s *stubService) Course(ctx context.Context, id uuid.UUID) (domain.Course, error) {
+	if _, err := tenant.Require(ctx); err != nil {
+		return domain.Course{}, err
+	}
+	if id != s.course.UUID {
+		return domain.Course{}, errors.NewErrorf(errors.ErrCodeNotFound, "course %s not found", id)
+	}
+	return s.course, nil
+}
+
+func (s *stubService) CreateCourse(_ context.Context, c *domain.Course) error {
+	c.UUID = s.course.UUID
+	c.Status = domain.CourseDraft
+	return nil
+}
+
+func newClient(t *testing.T, s domain.ServiceInterface) pb.CourseServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(errors.UnaryServerInterceptor,
+		tenant.NewUnaryServerInterceptor(tenant.NewResolver(nil))))
+	pb.RegisterCourseServiceServer(server, NewGRPCServer(s, log.NewNopLogger()))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unable to dial the server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewCourseServiceClient(conn)
+}
+
+func TestGRPCServer_FindCourse(t *testing.T) {
+	course := domain.Course{
+		UUID:    uuid.MustParse("e8276e31-9a87-4cf1-a16c-080f9c5790d1"),
+		Name:    "Course",
+		Version: 3,
+		Status:  domain.CoursePublished,
+	}
+	client := newClient(t, &stubService{course: course})
+
+	tests := []struct {
+		name     string
+		uuid     string
+		wantCode codes.Code
+	}{
+		{name: "found", uuid: course.UUID.String(), wantCode: codes.OK},
+		{name: "not found", uuid: "0c2e7a51-8d4b-4d55-9b5c-8a1f3e2d4c6b", wantCode: codes.NotFound},
+		{name: "invalid uuid", uuid: "course", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := client.FindCourse(context.Background(), &pb.FindCourseRequest{Uuid: tt.uuid})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FindCourse() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if got.Uuid != course.UUID.String() || got.Name != course.Name || got.Version != 3 {
+				t.Errorf("FindCourse() got = %v", got)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_CreateCourse(t *testing.T) {
+	client := newClient(t, &stubService{course: domain.Course{UUID: uuid.New()}})
+
+	tests := []struct {
+		name     string
+		req      *pb.CreateCourseRequest
+		wantCode codes.Code
+	}{
+		{
+			name: "created",
+			req: &pb.CreateCourseRequest{Code: "C1", Name: "Course", Underline: "Underline", Excerpt: "Excerpt",
+				Description: "Description"},
+			wantCode: codes.OK,
+		},
+		{name: "invalid fields", req: &pb.CreateCourseRequest{Name: "Course"}, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := client.CreateCourse(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateCourse() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if err == nil && (got.Name != tt.req.Name || got.Status != string(domain.CourseDraft)) {
+				t.Errorf("CreateCourse() got = %v", got)
+			}
+		})
+	}
+}
@@ -5,6 +5,11 @@ import (
 
 	"github.com/sumelms/microservice-course/internal/course/endpoints"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/idempotency"
+	"github.com/sumelms/microservice-course/pkg/metrics"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	"github.com/sumelms/microservice-course/pkg/validator"
 
 	kittransport "github.com/go-kit/kit/transport"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -14,37 +19,53 @@ import (
 	"github.com/sumelms/microservice-course/internal/course/domain"
 )
 
-func NewHTTPHandler(r *mux.Router, s domain.ServiceInterface, logger log.Logger) {
+func NewHTTPHandler(r *mux.Router, s domain.ServiceInterface, logger log.Logger, guard *idempotency.Guard) {
 	opts := []kithttp.ServerOption{
+		kithttp.ServerBefore(tracing.HTTPToContext, kithttp.PopulateRequestContext, validator.PopulateLanguage,
+			etag.PopulatePreconditions),
+		kithttp.ServerFinalizer(tracing.HTTPFinalizer),
 		kithttp.ServerErrorHandler(kittransport.NewLogErrorHandler(logger)),
 		kithttp.ServerErrorEncoder(errors.EncodeError),
 	}
 
 	// Course handlers
 
-	listCourseHandler := endpoints.NewListCourseHandler(s, opts...)
+	listCourseHandler := metrics.NewHTTPHandler(endpoints.NewListCourseHandler(s, opts...))
 	createCourseHandler := endpoints.NewCreateCourseHandler(s, opts...)
-	findCourseHandler := endpoints.NewFindCourseHandler(s, opts...)
-	updateCourseHandler := endpoints.NewUpdateCourseHandler(s, opts...)
-	deleteCourseHandler := endpoints.NewDeleteCourseHandler(s, opts...)
+	findCourseHandler := metrics.NewHTTPHandler(endpoints.NewFindCourseHandler(s, opts...))
+	updateCourseHandler := metrics.NewHTTPHandler(endpoints.NewUpdateCourseHandler(s, opts...))
+	patchCourseHandler := metrics.NewHTTPHandler(endpoints.NewPatchCourseHandler(s, opts...))
+	deleteCourseHandler := metrics.NewHTTPHandler(endpoints.NewDeleteCourseHandler(s, opts...))
+	restoreCourseHandler := metrics.NewHTTPHandler(endpoints.NewRestoreCourseHandler(s, opts...))
+	transitionCourseHandler := metrics.NewHTTPHandler(endpoints.NewTransitionCourseHandler(s, opts...))
 
-	r.Handle("/courses", createCourseHandler).Methods(http.MethodPost)
+	r.Handle("/courses", metrics.NewHTTPHandler(guard.Handler(createCourseHandler))).Methods(http.MethodPost)
 	r.Handle("/courses", listCourseHandler).Methods(http.MethodGet)
 	r.Handle("/courses/{uuid}", findCourseHandler).Methods(http.MethodGet)
 	r.Handle("/courses/{uuid}", updateCourseHandler).Methods(http.MethodPut)
+	r.Handle("/courses/{uuid}", patchCourseHandler).Methods(http.MethodPatch)
 	r.Handle("/courses/{uuid}", deleteCourseHandler).Methods(http.MethodDelete)
+	r.Handle("/courses/{uuid}/restore", restoreCourseHandler).Methods(http.MethodPost)
+	r.Handle("/courses/{uuid}/{transition:submit|publish|reject|archive|reopen}", transitionCourseHandler).
+		Methods(http.MethodPost)
 
 	// Subscription handlers
 
-	listSubscriptionHandler := endpoints.NewListSubscriptionHandler(s, opts...)
+	listSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewListSubscriptionHandler(s, opts...))
 	createSubscriptionHandler := endpoints.NewCreateSubscriptionHandler(s, opts...)
-	findSubscriptionHandler := endpoints.NewFindSubscriptionHandler(s, opts...)
-	deleteSubscriptionHandler := endpoints.NewDeleteSubscriptionHandler(s, opts...)
-	updateSubscriptionHandler := endpoints.NewUpdateSubscriptionHandler(s, opts...)
+	findSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewFindSubscriptionHandler(s, opts...))
+	deleteSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewDeleteSubscriptionHandler(s, opts...))
+	updateSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewUpdateSubscriptionHandler(s, opts...))
+	patchSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewPatchSubscriptionHandler(s, opts...))
+	restoreSubscriptionHandler := metrics.NewHTTPHandler(endpoints.NewRestoreSubscriptionHandler(s, opts...))
+	listMemberHandler := metrics.NewHTTPHandler(endpoints.NewListMemberHandler(s, opts...))
 
 	r.Handle("/subscriptions", listSubscriptionHandler).Methods(http.MethodGet)
-	r.Handle("/subscriptions", createSubscriptionHandler).Methods(http.MethodPost)
+	r.Handle("/subscriptions", metrics.NewHTTPHandler(guard.Handler(createSubscriptionHandler))).Methods(http.MethodPost)
 	r.Handle("/subscriptions/{uuid}", findSubscriptionHandler).Methods(http.MethodGet)
 	r.Handle("/subscriptions/{uuid}", deleteSubscriptionHandler).Methods(http.MethodDelete)
 	r.Handle("/subscriptions/{uuid}", updateSubscriptionHandler).Methods(http.MethodPut)
+	r.Handle("/subscriptions/{uuid}", patchSubscriptionHandler).Methods(http.MethodPatch)
+	r.Handle("/subscriptions/{uuid}/restore", restoreSubscriptionHandler).Methods(http.MethodPost)
+	r.Handle("/courses/{uuid}/members", listMemberHandler).Methods(http.MethodGet)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/sumelms/microservice-course/internal/course/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
 )
 
 type courseClient struct {
@@ -23,3 +24,22 @@ func (c courseClient) CourseExists(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (c courseClient) SubscriptionMatrix(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	sub, err := c.service.Subscription(ctx, subscriptionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if sub.MatrixID == nil {
+		return uuid.Nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "subscription %s has no matrix", subscriptionID)
+	}
+	return *sub.MatrixID, nil
+}
+
+func (c courseClient) SubscriptionUser(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	sub, err := c.service.Subscription(ctx, subscriptionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return sub.UserID, nil
+}
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sumelms/microservice-course/proto/course"
+)
+
+// FakeCourseServer is an in-memory course service to run the gRPC course client against in
+// tests and local runs. It ignores the tenants.
+type FakeCourseServer struct {
+	pb.UnimplementedCourseServiceServer
+
+	mu            sync.Mutex
+	courses       map[string]*pb.Course
+	subscriptions map[string]*pb.Subscription
+	failures      int
+	calls         int
+}
+
+func NewFakeCourseServer() *FakeCourseServer {
+	return &FakeCourseServer{
+		courses:       make(map[string]*pb.Course),
+		subscriptions: make(map[string]*pb.Subscription),
+	}
+}
+
+// Start serves the fake on the address, such as 127.0.0.1:0, and returns the address it
+// listens on along with the function stopping it
+func (f *FakeCourseServer) Start(address string) (string, func(), error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", nil, err
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterCourseServiceServer(server, f)
+	go func() { _ = server.Serve(listener) }()
+
+	return listener.Addr().String(), server.Stop, nil
+}
+
+func (f *FakeCourseServer) AddCourse(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.courses[id.String()] = &pb.Course{Uuid: id.String()}
+}
+
+func (f *FakeCourseServer) AddSubscription(id, userID uuid.UUID, matrixID *uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub := &pb.Subscription{Uuid: id.String(), UserId: userID.String()}
+	if matrixID != nil {
+		sub.MatrixId = matrixID.String()
+	}
+	f.subscriptions[id.String()] = sub
+}
+
+// FailNext makes the next calls fail as if the service were unavailable
+func (f *FakeCourseServer) FailNext(calls int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = calls
+}
+
+// Calls returns the number of calls received
+func (f *FakeCourseServer) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func (f *FakeCourseServer) FindCourse(_ context.Context, req *pb.FindCourseRequest) (*pb.Course, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	c, ok := f.courses[req.Uuid]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "course %s not found", req.Uuid)
+	}
+	return c, nil
+}
+
+func (f *FakeCourseServer) FindSubscription(_ context.Context, req *pb.FindSubscriptionRequest) (*pb.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	sub, ok := f.subscriptions[req.Uuid]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "subscription %s not found", req.Uuid)
+	}
+	return sub, nil
+}
+
+func (f *FakeCourseServer) call() error {
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return status.Error(codes.Unavailable, "course service is unavailable")
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	pb "github.com/sumelms/microservice-course/proto/course"
+)
+
+const (
+	courseService = "sumelms.course.v1.CourseService"
+
+	// maxCachedCourses is the size above which the expired courses are swept from the cache
+	maxCachedCourses = 1024
+)
+
+// grpcCourseClient calls the course service over gRPC, forwarding the token and the tenant
+// of the request
+type grpcCourseClient struct {
+	findCourse       endpoint.Endpoint
+	findSubscription endpoint.Endpoint
+	cache            *existenceCache
+}
+
+// DialCourseService opens the connection to the course service, it is established lazily
+func DialCourseService(cfg *config.CourseClient) (*grpc.ClientConn, error) {
+	return grpc.Dial(cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+func NewGRPCCourseClient(conn *grpc.ClientConn, cfg *config.CourseClient, res *tenant.Resolver) *grpcCourseClient {
+	opts := []kitgrpc.ClientOption{
+		kitgrpc.ClientBefore(kitjwt.ContextToGRPC(), res.ContextToGRPC(), tracing.ContextToGRPC),
+	}
+	identity := func(_ context.Context, request interface{}) (interface{}, error) {
+		return request, nil
+	}
+
+	// The breaker counts the calls whose retries were exhausted, each attempt has its own timeout
+	b := newBreaker(cfg.MaxFailures, cfg.OpenTimeout)
+	resilient := func(e endpoint.Endpoint) endpoint.Endpoint {
+		return endpoint.Chain(b.middleware, retry(cfg.Retries, cfg.Backoff), timeout(cfg.Timeout))(e)
+	}
+
+	return &grpcCourseClient{
+		findCourse: resilient(kitgrpc.NewClient(conn, courseService, "FindCourse",
+			identity, identity, &pb.Course{}, opts...).Endpoint()),
+		findSubscription: resilient(kitgrpc.NewClient(conn, courseService, "FindSubscription",
+			identity, identity, &pb.Subscription{}, opts...).Endpoint()),
+		cache: newExistenceCache(cfg.CacheTTL),
+	}
+}
+
+func (c grpcCourseClient) CourseExists(ctx context.Context, id uuid.UUID) error {
+	tenantID, _ := tenant.FromContext(ctx)
+	if c.cache.exists(tenantID, id) {
+		return nil
+	}
+
+	if _, err := c.findCourse(ctx, &pb.FindCourseRequest{Uuid: id.String()}); err != nil {
+		return errors.DecodeGRPCError(err)
+	}
+	c.cache.add(tenantID, id)
+	return nil
+}
+
+func (c grpcCourseClient) SubscriptionMatrix(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	sub, err := c.subscription(ctx, subscriptionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if sub.MatrixId == "" {
+		return uuid.Nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "subscription %s has no matrix", subscriptionID)
+	}
+	return parseUUID(sub.MatrixId)
+}
+
+func (c grpcCourseClient) SubscriptionUser(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	sub, err := c.subscription(ctx, subscriptionID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return parseUUID(sub.UserId)
+}
+
+func (c grpcCourseClient) subscription(ctx context.Context, id uuid.UUID) (*pb.Subscription, error) {
+	response, err := c.findSubscription(ctx, &pb.FindSubscriptionRequest{Uuid: id.String()})
+	if err != nil {
+		return nil, errors.DecodeGRPCError(err)
+	}
+	sub, ok := response.(*pb.Subscription)
+	if !ok {
+		return nil, fmt.Errorf("invalid response")
+	}
+	return sub, nil
+}
+
+func parseUUID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.WrapErrorf(err, errors.ErrCodeUnknown, "course service returned an invalid UUID")
+	}
+	return id, nil
+}
+
+// existenceCache remembers the courses found for a short while. Missing courses aren't
+// cached, they may be created at any moment.
+type existenceCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	expires map[string]time.Time
+}
+
+func newExistenceCache(ttl time.Duration) *existenceCache {
+	return &existenceCache{ttl: ttl, expires: make(map[string]time.Time)}
+}
+
+func (c *existenceCache) exists(tenantID string, id uuid.UUID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := tenantID + "/" + id.String()
+	expires, ok := c.expires[key]
+	if ok && time.Now().After(expires) {
+		delete(c.expires, key)
+		return false
+	}
+	return ok
+}
+
+func (c *existenceCache) add(tenantID string, id uuid.UUID) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.expires) >= maxCachedCourses {
+		for key, expires := range c.expires {
+			if now.After(expires) {
+				delete(c.expires, key)
+			}
+		}
+	}
+	c.expires[tenantID+"/"+id.String()] = now.Add(c.ttl)
+}
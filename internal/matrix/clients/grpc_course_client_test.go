@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+func newTestClient(t *testing.T, cfg config.CourseClient) (*grpcCourseClient, *FakeCourseServer) {
+	t.Helper()
+
+	fake := NewFakeCourseServer()
+	address, stop, err := fake.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start the fake course server: %v", err)
+	}
+	t.Cleanup(stop)
+
+	cfg.Address = address
+	conn, err := DialCourseService(&cfg)
+	if err != nil {
+		t.Fatalf("unable to dial the fake course server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewGRPCCourseClient(conn, &cfg, tenant.NewResolver(nil)), fake
+}
+
+func errorCode(err error) errors.ErrorCode {
+	if ierr, ok := err.(*errors.Error); ok {
+		return ierr.Code()
+	}
+	return errors.ErrCodeUnknown
+}
+
+func TestGRPCCourseClient_CourseExists(t *testing.T) {
+	var (
+		ctx     = tenant.NewContext(context.Background(), tenant.DefaultTenant)
+		course  = uuid.MustParse("e8276e31-9a87-4cf1-a16c-080f9c5790d1")
+		missing = uuid.MustParse("0c2e7a51-8d4b-4d55-9b5c-8a1f3e2d4c6b")
+		base    = config.CourseClient{Timeout: time.Second, MaxFailures: 3, OpenTimeout: time.Minute}
+	)
+
+	tests := []struct {
+		name      string
+		cfg       func(c config.CourseClient) config.CourseClient
+		failures  int
+		id        uuid.UUID
+		calls     int
+		wantCode  errors.ErrorCode
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "found", id: course, calls: 1, wantCalls: 1},
+		{name: "not found", id: missing, calls: 1, wantCode: errors.ErrCodeNotFound, wantErr: true, wantCalls: 1},
+		{
+			name:      "cached",
+			cfg:       func(c config.CourseClient) config.CourseClient { c.CacheTTL = time.Minute; return c },
+			id:        course,
+			calls:     3,
+			wantCalls: 1,
+		},
+		{
+			name:      "missing courses not cached",
+			cfg:       func(c config.CourseClient) config.CourseClient { c.CacheTTL = time.Minute; return c },
+			id:        missing,
+			calls:     2,
+			wantCode:  errors.ErrCodeNotFound,
+			wantErr:   true,
+			wantCalls: 2,
+		},
+		{
+			name:      "retried",
+			cfg:       func(c config.CourseClient) config.CourseClient { c.Retries = 2; c.Backoff = time.Millisecond; return c },
+			failures:  2,
+			id:        course,
+			calls:     1,
+			wantCalls: 3,
+		},
+		{
+			name:      "retries exhausted",
+			cfg:       func(c config.CourseClient) config.CourseClient { c.Retries = 1; c.Backoff = time.Millisecond; return c },
+			failures:  2,
+			id:        course,
+			calls:     1,
+			wantCode:  errors.ErrCodeUnavailable,
+			wantErr:   true,
+			wantCalls: 2,
+		},
+		{
+			name:      "breaker open",
+			failures:  10,
+			id:        course,
+			calls:     5,
+			wantCode:  errors.ErrCodeUnavailable,
+			wantErr:   true,
+			wantCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := base
+			if tt.cfg != nil {
+				cfg = tt.cfg(cfg)
+			}
+			client, fake := newTestClient(t, cfg)
+			fake.AddCourse(course)
+			fake.FailNext(tt.failures)
+
+			for i := 0; i < tt.calls; i++ {
+				err := client.CourseExists(ctx, tt.id)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("CourseExists() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr && errorCode(err) != tt.wantCode {
+					t.Errorf("CourseExists() error = %v, want code %v", err, tt.wantCode)
+				}
+			}
+			if got := fake.Calls(); got != tt.wantCalls {
+				t.Errorf("CourseExists() calls = %v, want %v", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	client, fake := newTestClient(t, config.CourseClient{Timeout: time.Second, MaxFailures: 1,
+		OpenTimeout: 20 * time.Millisecond})
+	ctx := tenant.NewContext(context.Background(), tenant.DefaultTenant)
+	course := uuid.New()
+	fake.AddCourse(course)
+	fake.FailNext(1)
+
+	if err := client.CourseExists(ctx, course); errorCode(err) != errors.ErrCodeUnavailable {
+		t.Fatalf("CourseExists() error = %v, want the service unavailable", err)
+	}
+	if err := client.CourseExists(ctx, course); errorCode(err) != errors.ErrCodeUnavailable || fake.Calls() != 1 {
+		t.Fatalf("CourseExists() should fail fast while the breaker is open, error = %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := client.CourseExists(ctx, course); err != nil {
+		t.Fatalf("CourseExists() unexpected error after the open timeout = %v", err)
+	}
+	if err := client.CourseExists(ctx, course); err != nil || fake.Calls() != 3 {
+		t.Errorf("CourseExists() should close the breaker, error = %v, calls = %v", err, fake.Calls())
+	}
+}
+
+func TestGRPCCourseClient_Subscription(t *testing.T) {
+	var (
+		ctx      = tenant.NewContext(context.Background(), tenant.DefaultTenant)
+		sub      = uuid.New()
+		noMatrix = uuid.New()
+		user     = uuid.New()
+		matrix   = uuid.New()
+	)
+	client, fake := newTestClient(t, config.CourseClient{Timeout: time.Second, MaxFailures: 3, OpenTimeout: time.Minute})
+	fake.AddSubscription(sub, user, &matrix)
+	fake.AddSubscription(noMatrix, user, nil)
+
+	if got, err := client.SubscriptionMatrix(ctx, sub); err != nil || got != matrix {
+		t.Errorf("SubscriptionMatrix() got = %v, error = %v, want %v", got, err, matrix)
+	}
+	if got, err := client.SubscriptionUser(ctx, sub); err != nil || got != user {
+		t.Errorf("SubscriptionUser() got = %v, error = %v, want %v", got, err, user)
+	}
+	if _, err := client.SubscriptionMatrix(ctx, noMatrix); errorCode(err) != errors.ErrCodeInvalidArgument {
+		t.Errorf("SubscriptionMatrix() error = %v, want an invalid argument", err)
+	}
+	if _, err := client.SubscriptionUser(ctx, uuid.New()); errorCode(err) != errors.ErrCodeNotFound {
+		t.Errorf("SubscriptionUser() error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// transient tells whether the call failed because of the network or the remote service,
+// only these failures are retried and open the circuit breaker
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// timeout bounds every attempt of the call
+func timeout(d time.Duration) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, request)
+		}
+	}
+}
+
+// retry repeats the calls failing with transient errors, doubling the wait after each attempt
+func retry(retries int, backoff time.Duration) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			wait := backoff
+			for attempt := 0; ; attempt++ {
+				response, err := next(ctx, request)
+				if err == nil || !transient(err) || attempt >= retries {
+					return response, err
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil, err
+				case <-time.After(wait):
+				}
+				wait *= 2
+			}
+		}
+	}
+}
+
+// breaker fails fast once maxFailures calls in a row failed with transient errors. After the
+// open timeout a single call goes through, its success closes the breaker again.
+type breaker struct {
+	mu          sync.Mutex
+	maxFailures int
+	openTimeout time.Duration
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newBreaker(maxFailures int, openTimeout time.Duration) *breaker {
+	return &breaker{maxFailures: maxFailures, openTimeout: openTimeout}
+}
+
+func (b *breaker) middleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		if !b.allow() {
+			return nil, errors.NewErrorf(errors.ErrCodeUnavailable, "course service is unavailable")
+		}
+
+		response, err := next(ctx, request)
+		b.record(err)
+		return response, err
+	}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.maxFailures {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.openTimeout {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil || !transient(err) {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.maxFailures {
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+)
+
+type tracingCourseClient struct {
+	next domain.CourseClient
+}
+
+// NewTracingCourseClient starts a client span for every call to the course service, the gRPC
+// client propagates it in the metadata of the request
+func NewTracingCourseClient(next domain.CourseClient) domain.CourseClient {
+	return &tracingCourseClient{next: next}
+}
+
+func (c *tracingCourseClient) CourseExists(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "CourseExists", id)
+	defer func() { tracing.End(span, err) }()
+	return c.next.CourseExists(ctx, id)
+}
+
+func (c *tracingCourseClient) SubscriptionMatrix(ctx context.Context, subscriptionID uuid.UUID) (res uuid.UUID, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionMatrix", subscriptionID)
+	defer func() { tracing.End(span, err) }()
+	return c.next.SubscriptionMatrix(ctx, subscriptionID)
+}
+
+func (c *tracingCourseClient) SubscriptionUser(ctx context.Context, subscriptionID uuid.UUID) (res uuid.UUID, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionUser", subscriptionID)
+	defer func() { tracing.End(span, err) }()
+	return c.next.SubscriptionUser(ctx, subscriptionID)
+}
+
+func startSpan(ctx context.Context, method string, id uuid.UUID) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "CourseClient."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("uuid", id.String())))
+}
@@ -0,0 +1,20 @@
+package database
+
+const (
+	listCompletions  = "list completions of subscription"
+	saveCompletion   = "save completion of subscription subject"
+	deleteCompletion = "delete completion of subscription subject"
+)
+
+func queriesCompletion() map[string]string {
+	return map[string]string{
+		listCompletions: "SELECT * FROM subject_completions WHERE subscription_id = $1 AND tenant_id = $2 ORDER BY id",
+		saveCompletion: `INSERT INTO subject_completions (subscription_id, subject_id, status, grade, completed_at, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5, $6) 
+			ON CONFLICT (subscription_id, subject_id) DO UPDATE 
+			SET status = EXCLUDED.status, grade = EXCLUDED.grade, completed_at = EXCLUDED.completed_at, updated_at = NOW() 
+			WHERE subject_completions.tenant_id = EXCLUDED.tenant_id 
+			RETURNING *`,
+		deleteCompletion: "DELETE FROM subject_completions WHERE subscription_id = $1 AND subject_id = $2 AND tenant_id = $3",
+	}
+}
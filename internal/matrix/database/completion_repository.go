@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+// NewCompletionRepository creates the completion completionRepository
+func NewCompletionRepository(db *sqlx.DB) (completionRepository, error) { //nolint: revive
+	sqlStatements := make(map[string]*sqlx.Stmt)
+
+	for queryName, query := range queriesCompletion() {
+		stmt, err := postgres.Prepare(db, queryName, query)
+		if err != nil {
+			return completionRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
+		}
+		sqlStatements[queryName] = stmt
+	}
+
+	return completionRepository{
+		statements: sqlStatements,
+	}, nil
+}
+
+type completionRepository struct {
+	statements map[string]*sqlx.Stmt
+}
+
+// Completions lists the subject completions of the subscription
+func (r completionRepository) Completions(ctx context.Context, subscriptionID uuid.UUID) ([]domain.Completion, error) {
+	stmt, ok := r.statements[listCompletions]
+	if !ok {
+		return []domain.Completion{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listCompletions)
+	}
+	ctx, end := postgres.StartStatement(ctx, listCompletions)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Completion{}, err
+	}
+
+	var list []domain.Completion
+	if err := stmt.SelectContext(ctx, &list, subscriptionID, tenantID); err != nil {
+		return []domain.Completion{}, errors.WrapDatabaseErrorf(err, "error getting completions")
+	}
+	return list, nil
+}
+
+// SaveCompletion creates or replaces the completion of the subscription subject
+func (r completionRepository) SaveCompletion(ctx context.Context, c *domain.Completion) error {
+	stmt, ok := r.statements[saveCompletion]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", saveCompletion)
+	}
+	ctx, end := postgres.StartStatement(ctx, saveCompletion)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stmt.GetContext(ctx, c, c.SubscriptionID, c.SubjectID, c.Status, c.Grade, c.CompletedAt, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error saving completion")
+	}
+	return nil
+}
+
+// DeleteCompletion deletes the completion of the subscription subject
+func (r completionRepository) DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) error {
+	stmt, ok := r.statements[deleteCompletion]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteCompletion)
+	}
+	ctx, end := postgres.StartStatement(ctx, deleteCompletion)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, subscriptionID, subjectID, tenantID)
+	if err != nil {
+		return errors.WrapDatabaseErrorf(err, "error deleting completion")
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.NewErrorf(errors.ErrCodeNotFound, "subject %s has no completion", subjectID)
+	}
+	return nil
+}
@@ -4,20 +4,62 @@ const (
 	createMatrix  = "create matrix"
 	deleteMatrix  = "delete matrix by uuid"
 	getMatrix     = "get matrix by uuid"
-	listMatrix    = "list matrices"
 	updateMatrix  = "update matrix by uuid"
 	addSubject    = "adds subject to matrix"
 	removeSubject = "remove subject from matrix"
+	listSubjects  = "list subjects of matrix"
+	restoreMatrix = "restore matrix by uuid"
+	publishMatrix = "publish matrix by uuid"
+	purgeMatrices = "purge deleted matrices"
+	purgeLinks    = "purge deleted matrix subjects"
+
+	listRequisites   = "list requisites of matrix"
+	addRequisite     = "adds requisite to matrix subject"
+	removeRequisite  = "remove requisite from matrix subject"
+	unlinkRequisites = "remove requisites of matrix subject"
+	purgeRequisites  = "purge requisites of deleted matrices"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listMatrices = "list matrices"
+	patchMatrix  = "patch matrix by uuid"
+
+	matricesTable = "matrices"
 )
 
 func queriesMatrix() map[string]string {
 	return map[string]string{
-		createMatrix:  "INSERT INTO matrices (name, description) VALUES ($1, $2) RETURNING *",
-		deleteMatrix:  "UPDATE matrices SET deleted_at = NOW() WHERE uuid = $1",
-		getMatrix:     "SELECT * FROM matrices WHERE uuid = $1",
-		listMatrix:    "SELECT * FROM matrices",
-		updateMatrix:  "UPDATE matrices SET name = $1, description = $2 WHERE uuid = $3 RETURNING *",
-		addSubject:    "INSERT INTO matrix_subjects (matrix_id, subject_id) VALUES($1, $2)",
-		removeSubject: "UPDATE matrix_subjects SET deleted_at = NOW() WHERE matrix_id = $1 AND subject_id = $2",
+		createMatrix: `INSERT INTO matrices (code, name, description, course_id, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5) RETURNING *`,
+		deleteMatrix: `UPDATE matrices SET deleted_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL`,
+		getMatrix: "SELECT * FROM matrices WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		updateMatrix: `UPDATE matrices SET code = $1, name = $2, description = $3, course_id = $4, 
+			version = version + 1 
+			WHERE uuid = $5 AND tenant_id = $6 AND ($7 = 0 OR version = $7) AND deleted_at IS NULL RETURNING *`,
+		addSubject: `INSERT INTO matrix_subjects (matrix_id, subject_id, is_required, tenant_id) VALUES ($1, $2, $3, $4) 
+			ON CONFLICT (matrix_id, subject_id) WHERE deleted_at IS NULL DO NOTHING RETURNING *`,
+		removeSubject: `UPDATE matrix_subjects SET deleted_at = NOW() 
+			WHERE matrix_id = $1 AND subject_id = $2 AND tenant_id = $3 AND deleted_at IS NULL`,
+		listSubjects: `SELECT s.*, ms.is_required FROM matrix_subjects ms 
+			JOIN subjects s ON s.uuid = ms.subject_id AND s.tenant_id = ms.tenant_id 
+			WHERE ms.matrix_id = $1 AND ms.tenant_id = $2 AND ms.deleted_at IS NULL AND s.deleted_at IS NULL 
+			ORDER BY s.code`,
+		restoreMatrix: `UPDATE matrices SET deleted_at = NULL, version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL RETURNING *`,
+		publishMatrix: `UPDATE matrices SET status = 'published', published_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND status = 'draft' AND deleted_at IS NULL RETURNING *`,
+		purgeMatrices: "DELETE FROM matrices WHERE deleted_at < $1",
+		purgeLinks:    "DELETE FROM matrix_subjects WHERE deleted_at < $1",
+
+		listRequisites: "SELECT * FROM matrix_requisites WHERE matrix_id = $1 AND tenant_id = $2 ORDER BY id",
+		addRequisite: `INSERT INTO matrix_requisites (matrix_id, subject_id, requisite_id, kind, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5) 
+			ON CONFLICT (matrix_id, subject_id, requisite_id) DO NOTHING RETURNING *`,
+		removeRequisite: `DELETE FROM matrix_requisites 
+			WHERE matrix_id = $1 AND subject_id = $2 AND requisite_id = $3 AND tenant_id = $4`,
+		unlinkRequisites: `DELETE FROM matrix_requisites 
+			WHERE matrix_id = $1 AND (subject_id = $2 OR requisite_id = $2) AND tenant_id = $3`,
+		purgeRequisites: `DELETE FROM matrix_requisites 
+			WHERE matrix_id IN (SELECT uuid FROM matrices WHERE deleted_at < $1)`,
 	}
 }
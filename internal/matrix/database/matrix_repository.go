@@ -1,11 +1,21 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 )
 
 // NewMatrixRepository creates the matrix matrixRepository
@@ -13,111 +23,439 @@ func NewMatrixRepository(db *sqlx.DB) (matrixRepository, error) {
 	sqlStatements := make(map[string]*sqlx.Stmt)
 
 	for queryName, query := range queriesMatrix() {
-		stmt, err := db.Preparex(query)
+		stmt, err := postgres.Prepare(db, queryName, query)
 		if err != nil {
-			return matrixRepository{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error preparing statement %s", queryName)
+			return matrixRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
 		}
 		sqlStatements[queryName] = stmt
 	}
 
 	return matrixRepository{
+		db:         db,
 		statements: sqlStatements,
 	}, nil
 }
 
 type matrixRepository struct {
+	db         *sqlx.DB
 	statements map[string]*sqlx.Stmt
 }
 
 // Matrix get the matrix by given id
-func (r matrixRepository) Matrix(id uuid.UUID) (domain.Matrix, error) {
+func (r matrixRepository) Matrix(ctx context.Context, id uuid.UUID) (domain.Matrix, error) {
 	stmt, ok := r.statements[getMatrix]
 	if !ok {
 		return domain.Matrix{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getMatrix)
 	}
+	ctx, end := postgres.StartStatement(ctx, getMatrix)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Matrix{}, err
+	}
 
 	var m domain.Matrix
-	if err := stmt.Get(&m, id); err != nil {
-		return domain.Matrix{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting matrix")
+	if err := stmt.GetContext(ctx, &m, id, tenantID); err != nil {
+		return domain.Matrix{}, errors.WrapDatabaseErrorf(err, "error getting matrix")
 	}
 	return m, nil
 }
 
-// Matrices get the list of matrices
-func (r matrixRepository) Matrices() ([]domain.Matrix, error) {
-	stmt, ok := r.statements[listMatrix]
-	if !ok {
-		return []domain.Matrix{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listMatrix)
+// Matrices get a page of matrices matching the given query
+func (r matrixRepository) Matrices(ctx context.Context, q pagination.Query) ([]domain.Matrix, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listMatrices)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Matrix{}, pagination.Page{}, err
 	}
 
+	query, args := pagination.Select(matricesTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenantID}})
+
 	var mm []domain.Matrix
-	if err := stmt.Select(&mm); err != nil {
-		return []domain.Matrix{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting matrices")
+	if err := r.db.SelectContext(ctx, &mm, query, args...); err != nil {
+		return []domain.Matrix{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting matrices")
 	}
-	return mm, nil
+	return pagination.Paginate(q, mm)
 }
 
 // CreateMatrix create a new matrix
-func (r matrixRepository) CreateMatrix(m *domain.Matrix) error {
+func (r matrixRepository) CreateMatrix(ctx context.Context, m *domain.Matrix) error {
 	stmt, ok := r.statements[createMatrix]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createMatrix)
 	}
+	ctx, end := postgres.StartStatement(ctx, createMatrix)
+	defer end()
 
-	if err := stmt.Get(m, m.Code, m.Name, m.Description, m.CourseID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error creating matrix")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, m, m.Code, m.Name, m.Description, m.CourseID, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error creating matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixCreated, tenantID, m.UUID, m)}, nil
+	})
 }
 
 // UpdateMatrix updates the given matrix
-func (r matrixRepository) UpdateMatrix(m *domain.Matrix) error {
+func (r matrixRepository) UpdateMatrix(ctx context.Context, m *domain.Matrix) error {
 	stmt, ok := r.statements[updateMatrix]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateMatrix)
 	}
+	ctx, end := postgres.StartStatement(ctx, updateMatrix)
+	defer end()
 
-	if err := stmt.Get(m, m.Code, m.Name, m.Description, m.CourseID, m.UUID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error updating matrix")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, m,
+			m.Code, m.Name, m.Description, m.CourseID, m.UUID, tenantID, expected); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, m.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error updating matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixUpdated, tenantID, m.UUID, m)}, nil
+	})
+}
+
+// PatchMatrix writes only the given fields of the matrix
+func (r matrixRepository) PatchMatrix(ctx context.Context, m *domain.Matrix, fields []string) error {
+	ctx, end := postgres.StartStatement(ctx, patchMatrix)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]interface{}{
+		"code":        m.Code,
+		"name":        m.Name,
+		"description": m.Description,
+		"course_id":   m.CourseID,
+	}
+	expected := etag.Expected(ctx)
+	query, args, err := patch.Update(matricesTable, columns, fields, m.UUID, tenantID, expected)
+	if err != nil {
+		return err
+	}
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.GetContext(ctx, m, query, args...); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, m.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error patching matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixUpdated, tenantID, m.UUID, m)}, nil
+	})
 }
 
 // DeleteMatrix delete the given matrix by uuid
-func (r matrixRepository) DeleteMatrix(id uuid.UUID) error {
+func (r matrixRepository) DeleteMatrix(ctx context.Context, id uuid.UUID) error {
 	stmt, ok := r.statements[deleteMatrix]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteMatrix)
 	}
+	ctx, end := postgres.StartStatement(ctx, deleteMatrix)
+	defer end()
 
-	if _, err := stmt.Exec(id); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error deleting matrix")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, id, tenantID, expected)
+		if err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error deleting matrix")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, id)
+			}
+			return nil, nil
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixDeleted, tenantID, id, deleted{UUID: id})}, nil
+	})
 }
 
 // AddSubject adds the subject to the matrix
-func (r matrixRepository) AddSubject(ms *domain.MatrixSubject) error {
+func (r matrixRepository) AddSubject(ctx context.Context, ms *domain.MatrixSubject) error {
 	stmt, ok := r.statements[addSubject]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", addSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, addSubject)
+	defer end()
 
-	if _, err := stmt.Exec(ms); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error adding subject to matrix")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, ms, ms.MatrixID, ms.SubjectID, ms.IsRequired, tenantID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.NewErrorf(errors.ErrCodeConflict, "subject %s is already in matrix %s", ms.SubjectID, ms.MatrixID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error adding subject to matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixSubjectAdded, tenantID, ms.MatrixID, ms)}, nil
+	})
 }
 
 // RemoveSubject removes the subject from the matrix
-func (r matrixRepository) RemoveSubject(matrixID, subjectID uuid.UUID) error {
+func (r matrixRepository) RemoveSubject(ctx context.Context, matrixID, subjectID uuid.UUID) error {
 	stmt, ok := r.statements[removeSubject]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", removeSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, removeSubject)
+	defer end()
 
-	if _, err := stmt.Exec(matrixID, subjectID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error removing subject from matrix")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	unlink, ok := r.statements[unlinkRequisites]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", unlinkRequisites)
+	}
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, matrixID, subjectID, tenantID)
+		if err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error removing subject from matrix")
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return nil, errors.NewErrorf(errors.ErrCodeNotFound, "subject %s is not in matrix %s", subjectID, matrixID)
+		}
+
+		if _, err := tx.StmtxContext(ctx, unlink).ExecContext(ctx, matrixID, subjectID, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error removing requisites of subject")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixSubjectRemoved, tenantID, matrixID,
+			removedSubject{MatrixID: matrixID, SubjectID: subjectID})}, nil
+	})
+}
+
+// Subjects lists the subjects of the matrix
+func (r matrixRepository) Subjects(ctx context.Context, matrixID uuid.UUID) ([]domain.MatrixSubjectDetail, error) {
+	stmt, ok := r.statements[listSubjects]
+	if !ok {
+		return []domain.MatrixSubjectDetail{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listSubjects)
+	}
+	ctx, end := postgres.StartStatement(ctx, listSubjects)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.MatrixSubjectDetail{}, err
+	}
+
+	var list []domain.MatrixSubjectDetail
+	if err := stmt.SelectContext(ctx, &list, matrixID, tenantID); err != nil {
+		return []domain.MatrixSubjectDetail{}, errors.WrapDatabaseErrorf(err, "error getting subjects of matrix")
+	}
+	return list, nil
+}
+
+// RestoreMatrix restores the soft deleted matrix by uuid
+func (r matrixRepository) RestoreMatrix(ctx context.Context, id uuid.UUID) (domain.Matrix, error) {
+	stmt, ok := r.statements[restoreMatrix]
+	if !ok {
+		return domain.Matrix{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", restoreMatrix)
+	}
+	ctx, end := postgres.StartStatement(ctx, restoreMatrix)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Matrix{}, err
+	}
+
+	var m domain.Matrix
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &m, id, tenantID); err != nil {
+			if errors.DatabaseCode(err) == errors.ErrCodeConflict {
+				return nil, errors.WrapErrorf(err, errors.ErrCodeConflict, "matrix %s code is taken by another matrix", id)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error restoring matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixRestored, tenantID, m.UUID, &m)}, nil
+	})
+	if err != nil {
+		return domain.Matrix{}, err
+	}
+	return m, nil
+}
+
+// PublishMatrix publishes the draft matrix by uuid
+func (r matrixRepository) PublishMatrix(ctx context.Context, id uuid.UUID) (domain.Matrix, error) {
+	stmt, ok := r.statements[publishMatrix]
+	if !ok {
+		return domain.Matrix{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", publishMatrix)
+	}
+	ctx, end := postgres.StartStatement(ctx, publishMatrix)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Matrix{}, err
+	}
+
+	var m domain.Matrix
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &m, id, tenantID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "matrix %s is not a draft", id)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error publishing matrix")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventMatrixPublished, tenantID, m.UUID, &m)}, nil
+	})
+	if err != nil {
+		return domain.Matrix{}, err
+	}
+	return m, nil
+}
+
+// Requisites lists the requisite edges between the subjects of the matrix
+func (r matrixRepository) Requisites(ctx context.Context, matrixID uuid.UUID) ([]domain.Requisite, error) {
+	stmt, ok := r.statements[listRequisites]
+	if !ok {
+		return []domain.Requisite{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listRequisites)
+	}
+	ctx, end := postgres.StartStatement(ctx, listRequisites)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Requisite{}, err
+	}
+
+	var list []domain.Requisite
+	if err := stmt.SelectContext(ctx, &list, matrixID, tenantID); err != nil {
+		return []domain.Requisite{}, errors.WrapDatabaseErrorf(err, "error getting requisites of matrix")
+	}
+	return list, nil
+}
+
+// AddRequisite adds the requisite edge between two subjects of the matrix
+func (r matrixRepository) AddRequisite(ctx context.Context, req *domain.Requisite) error {
+	stmt, ok := r.statements[addRequisite]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", addRequisite)
+	}
+	ctx, end := postgres.StartStatement(ctx, addRequisite)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stmt.GetContext(ctx, req, req.MatrixID, req.SubjectID, req.RequisiteID, req.Kind, tenantID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NewErrorf(errors.ErrCodeConflict, "subject %s already requires %s", req.SubjectID, req.RequisiteID)
+		}
+		return errors.WrapDatabaseErrorf(err, "error adding requisite to subject")
+	}
+	return nil
+}
+
+// RemoveRequisite removes the requisite edge between two subjects of the matrix
+func (r matrixRepository) RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) error {
+	stmt, ok := r.statements[removeRequisite]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", removeRequisite)
+	}
+	ctx, end := postgres.StartStatement(ctx, removeRequisite)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, matrixID, subjectID, requisiteID, tenantID)
+	if err != nil {
+		return errors.WrapDatabaseErrorf(err, "error removing requisite from subject")
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.NewErrorf(errors.ErrCodeNotFound, "subject %s doesn't require %s", subjectID, requisiteID)
 	}
 	return nil
 }
+
+// PurgeMatrices permanently deletes the matrices and matrix subjects soft deleted before the given time
+func (r matrixRepository) PurgeMatrices(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	for _, name := range []string{purgeRequisites, purgeLinks, purgeMatrices} {
+		stmt, ok := r.statements[name]
+		if !ok {
+			return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", name)
+		}
+
+		stmtCtx, end := postgres.StartStatement(ctx, name)
+		res, err := stmt.ExecContext(stmtCtx, before)
+		end()
+		if err != nil {
+			return 0, errors.WrapDatabaseErrorf(err, "error purging matrices")
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.WrapDatabaseErrorf(err, "error purging matrices")
+		}
+		purged += n
+	}
+	return purged, nil
+}
+
+// versionMismatch tells a matrix changed since the expected version apart from a missing one,
+// the matrix is read within the transaction of the failed change
+func (r matrixRepository) versionMismatch(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
+	stmt, ok := r.statements[getMatrix]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getMatrix)
+	}
+	ctx, end := postgres.StartStatement(ctx, getMatrix)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	var current domain.Matrix
+	if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &current, id, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error getting matrix")
+	}
+	return errors.NewErrorf(errors.ErrCodePreconditionFailed, "matrix %s was changed, it is at version %d", id, current.Version)
+}
+
+// deleted is the payload of the events of the deleted records
+type deleted struct {
+	UUID uuid.UUID `json:"uuid"`
+}
+
+// removedSubject is the payload of the event of a subject removed from a matrix
+type removedSubject struct {
+	MatrixID  uuid.UUID `json:"matrix_id"`
+	SubjectID uuid.UUID `json:"subject_id"`
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -10,12 +11,18 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+	utils "github.com/sumelms/microservice-course/tests"
 	"github.com/sumelms/microservice-course/tests/database"
 )
 
 var (
+	tenantCtx  = tenant.NewContext(context.Background(), tenant.DefaultTenant)
 	now        = time.Now()
 	matrixUUID = uuid.MustParse("dd7c915b-849a-4ba4-bc09-aeecd95c40cc")
 	courseUUID = uuid.MustParse("79e1d30d-77f0-4d2f-995c-74aef97c76bf")
@@ -33,8 +40,8 @@ var (
 	emptyRows = sqlmock.NewRows([]string{})
 )
 
-func newTestDB() (db *sqlx.DB, sqlStatements map[string]*sqlmock.ExpectedPrepare) {
-	db, mock := database.NewDBMock()
+func newTestDB() (db *sqlx.DB, mock sqlmock.Sqlmock, sqlStatements map[string]*sqlmock.ExpectedPrepare) {
+	db, mock = database.NewDBMock()
 
 	sqlStatements = make(map[string]*sqlmock.ExpectedPrepare)
 	for queryName, query := range queriesMatrix() {
@@ -43,7 +50,7 @@ func newTestDB() (db *sqlx.DB, sqlStatements map[string]*sqlmock.ExpectedPrepare
 	}
 
 	mock.MatchExpectationsInOrder(false)
-	return db, sqlStatements
+	return db, mock, sqlStatements
 }
 
 func TestRepository_Matrix(t *testing.T) {
@@ -84,7 +91,7 @@ func TestRepository_Matrix(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, stmts := newTestDB()
+			db, _, stmts := newTestDB()
 			r, err := NewMatrixRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the matrixRepository", err)
@@ -94,9 +101,9 @@ func TestRepository_Matrix(t *testing.T) {
 				t.Fatalf("prepared statement %s not found", getMatrix)
 			}
 
-			prep.ExpectQuery().WithArgs(matrixUUID).WillReturnRows(tt.rows)
+			prep.ExpectQuery().WithArgs(matrixUUID, tenant.DefaultTenant).WillReturnRows(tt.rows)
 
-			got, err := r.Matrix(tt.args.id)
+			got, err := r.Matrix(tenantCtx, tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Matrix() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -142,19 +149,17 @@ func TestRepository_Matrices(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, stmts := newTestDB()
+			db, mock, _ := newTestDB()
 			r, err := NewMatrixRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when creating the matrixRepository", err)
 			}
-			prep, ok := stmts[listMatrix]
-			if !ok {
-				t.Fatalf("prepared statement %s not found", listMatrix)
-			}
 
-			prep.ExpectQuery().WillReturnRows(tt.rows)
+			q := pagination.NewQuery()
+			query, _ := pagination.Select(matricesTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenant.DefaultTenant}})
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(tt.rows)
 
-			got, err := r.Matrices()
+			got, _, err := r.Matrices(tenantCtx, q)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Matrices() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -195,10 +200,12 @@ func TestRepository_UpdateMatrix(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			db, stmts := newTestDB()
+			db, mock, stmts := newTestDB()
+			utils.ExpectOutboxTx(mock)
 			r, err := NewMatrixRepository(db)
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected creating the matrixRepository", err)
@@ -210,9 +217,121 @@ func TestRepository_UpdateMatrix(t *testing.T) {
 
 			prep.ExpectQuery().WillReturnRows(tt.rows)
 
-			if err := r.UpdateMatrix(tt.args.m); (err != nil) != tt.wantErr {
+			if err := r.UpdateMatrix(tenantCtx, tt.args.m); (err != nil) != tt.wantErr {
 				t.Errorf("UpdateMatrix() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestRepository_RestoreMatrix(t *testing.T) {
+	validRows := sqlmock.NewRows([]string{"id", "uuid", "code", "name", "description",
+		"course_id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(matrix.ID, matrix.UUID, matrix.Code, matrix.Name, matrix.Description,
+			matrix.CourseID, matrix.CreatedAt, matrix.UpdatedAt, matrix.DeletedAt)
+
+	tests := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		queryErr error
+		wantCode errors.ErrorCode
+		wantErr  bool
+	}{
+		{
+			name: "restore matrix",
+			rows: validRows,
+		},
+		{
+			name:     "matrix not deleted",
+			rows:     emptyRows,
+			wantCode: errors.ErrCodeNotFound,
+			wantErr:  true,
+		},
+		{
+			name:     "matrix code taken",
+			queryErr: &pq.Error{Code: "23505"},
+			wantCode: errors.ErrCodeConflict,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, stmts := newTestDB()
+			utils.ExpectOutboxTx(mock)
+			r, err := NewMatrixRepository(db)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected creating the matrixRepository", err)
+			}
+			prep, ok := stmts[restoreMatrix]
+			if !ok {
+				t.Fatalf("prepared statement %s not found", restoreMatrix)
+			}
+
+			query := prep.ExpectQuery().WithArgs(matrixUUID, tenant.DefaultTenant)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			_, err = r.RestoreMatrix(tenantCtx, matrixUUID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestoreMatrix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if code := errors.CodeOf(err); tt.wantErr && code != tt.wantCode {
+				t.Errorf("RestoreMatrix() error code = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRepository_AddSubject(t *testing.T) {
+	subjectUUID := uuid.MustParse("0b5b3b2c-9f1e-4a3e-8a53-6c1f0c3c9d11")
+	validRows := sqlmock.NewRows([]string{"id", "subject_id", "matrix_id", "is_required",
+		"created_at", "updated_at", "deleted_at"}).
+		AddRow(1, subjectUUID, matrixUUID, true, now, now, nil)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr bool
+	}{
+		{
+			name:    "add subject to matrix",
+			rows:    validRows,
+			wantErr: false,
+		},
+		{
+			name:    "subject already in matrix",
+			rows:    emptyRows,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, stmts := newTestDB()
+			utils.ExpectOutboxTx(mock)
+			r, err := NewMatrixRepository(db)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected creating the matrixRepository", err)
+			}
+			prep, ok := stmts[addSubject]
+			if !ok {
+				t.Fatalf("prepared statement %s not found", addSubject)
+			}
+
+			prep.ExpectQuery().WithArgs(matrixUUID, subjectUUID, true, tenant.DefaultTenant).WillReturnRows(tt.rows)
+
+			ms := &domain.MatrixSubject{MatrixID: matrixUUID, SubjectID: subjectUUID, IsRequired: true}
+			if err := r.AddSubject(tenantCtx, ms); (err != nil) != tt.wantErr {
+				t.Errorf("AddSubject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,19 +1,32 @@
 package database
 
 const (
-	createSubject = "create subject"
-	deleteSubject = "delete subject by uuid"
-	getSubject    = "get subject by uuid"
-	listSubject   = "list subject"
-	updateSubject = "update subject by uuid"
+	createSubject  = "create subject"
+	deleteSubject  = "delete subject by uuid"
+	getSubject     = "get subject by uuid"
+	updateSubject  = "update subject by uuid"
+	restoreSubject = "restore subject by uuid"
+	purgeSubjects  = "purge deleted subjects"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listAllSubjects = "list subjects"
+	patchSubject    = "patch subject by uuid"
+
+	subjectsTable = "subjects"
 )
 
 func queriesSubject() map[string]string {
 	return map[string]string{
-		createSubject: "INSERT INTO subjects (name) VALUES ($1) RETURNING *",
-		deleteSubject: "UPDATE subjects SET deleted_at = NOW() WHERE uuid = $1",
-		getSubject:    "SELECT * FROM subjects WHERE uuid = $1",
-		listSubject:   "SELECT * FROM subjects",
-		updateSubject: "UPDATE subjects SET name = $1 WHERE uuid = $2 RETURNING *",
+		createSubject: `INSERT INTO subjects (code, name, objective, credit, workload, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`,
+		deleteSubject: `UPDATE subjects SET deleted_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL`,
+		getSubject: "SELECT * FROM subjects WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		updateSubject: `UPDATE subjects SET code = $1, name = $2, objective = $3, credit = $4, workload = $5, 
+			version = version + 1 
+			WHERE uuid = $6 AND tenant_id = $7 AND ($8 = 0 OR version = $8) AND deleted_at IS NULL RETURNING *`,
+		restoreSubject: `UPDATE subjects SET deleted_at = NULL, version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL RETURNING *`,
+		purgeSubjects: "DELETE FROM subjects WHERE deleted_at < $1",
 	}
 }
@@ -1,11 +1,21 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/tenant"
 )
 
 // NewSubjectRepository creates the subject subjectRepository
@@ -13,80 +23,235 @@ func NewSubjectRepository(db *sqlx.DB) (subjectRepository, error) { //nolint: re
 	sqlStatements := make(map[string]*sqlx.Stmt)
 
 	for queryName, query := range queriesSubject() {
-		stmt, err := db.Preparex(query)
+		stmt, err := postgres.Prepare(db, queryName, query)
 		if err != nil {
-			return subjectRepository{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error preparing statement %s", queryName)
+			return subjectRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
 		}
 		sqlStatements[queryName] = stmt
 	}
 
 	return subjectRepository{
+		db:         db,
 		statements: sqlStatements,
 	}, nil
 }
 
 type subjectRepository struct {
+	db         *sqlx.DB
 	statements map[string]*sqlx.Stmt
 }
 
-func (r subjectRepository) Subject(id uuid.UUID) (domain.Subject, error) {
+func (r subjectRepository) Subject(ctx context.Context, id uuid.UUID) (domain.Subject, error) {
 	stmt, ok := r.statements[getSubject]
 	if !ok {
 		return domain.Subject{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, getSubject)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Subject{}, err
+	}
 
 	var sub domain.Subject
-	if err := stmt.Get(&sub, id); err != nil {
-		return domain.Subject{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting subject")
+	if err := stmt.GetContext(ctx, &sub, id, tenantID); err != nil {
+		return domain.Subject{}, errors.WrapDatabaseErrorf(err, "error getting subject")
 	}
 	return sub, nil
 }
 
-func (r subjectRepository) Subjects() ([]domain.Subject, error) {
-	stmt, ok := r.statements[listSubject]
-	if !ok {
-		return []domain.Subject{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", listSubject)
+func (r subjectRepository) Subjects(ctx context.Context, q pagination.Query) ([]domain.Subject, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listAllSubjects)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Subject{}, pagination.Page{}, err
 	}
 
+	query, args := pagination.Select(subjectsTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenantID}})
+
 	var subs []domain.Subject
-	if err := stmt.Select(&subs); err != nil {
-		return []domain.Subject{}, errors.WrapErrorf(err, errors.ErrCodeUnknown, "error getting subjects")
+	if err := r.db.SelectContext(ctx, &subs, query, args...); err != nil {
+		return []domain.Subject{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting subjects")
 	}
-	return subs, nil
+	return pagination.Paginate(q, subs)
 }
 
-func (r subjectRepository) CreateSubject(sub *domain.Subject) error {
+func (r subjectRepository) CreateSubject(ctx context.Context, sub *domain.Subject) error {
 	stmt, ok := r.statements[createSubject]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, createSubject)
+	defer end()
 
-	if err := stmt.Get(sub, sub.Name); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error creating subject")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, sub,
+			sub.Code, sub.Name, sub.Objective, sub.Credit, sub.Workload, tenantID); err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error creating subject")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubjectCreated, tenantID, sub.UUID, sub)}, nil
+	})
 }
 
-func (r subjectRepository) UpdateSubject(sub *domain.Subject) error {
+func (r subjectRepository) UpdateSubject(ctx context.Context, sub *domain.Subject) error {
 	stmt, ok := r.statements[updateSubject]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, updateSubject)
+	defer end()
 
-	if err := stmt.Get(sub, sub.UUID); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error updating subject")
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, sub,
+			sub.Code, sub.Name, sub.Objective, sub.Credit, sub.Workload, sub.UUID, tenantID, expected); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, sub.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error updating subject")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubjectUpdated, tenantID, sub.UUID, sub)}, nil
+	})
 }
 
-func (r subjectRepository) DeleteSubject(id uuid.UUID) error {
+// PatchSubject writes only the given fields of the subject
+func (r subjectRepository) PatchSubject(ctx context.Context, s *domain.Subject, fields []string) error {
+	ctx, end := postgres.StartStatement(ctx, patchSubject)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]interface{}{
+		"code":      s.Code,
+		"name":      s.Name,
+		"objective": s.Objective,
+		"credit":    s.Credit,
+		"workload":  s.Workload,
+	}
+	expected := etag.Expected(ctx)
+	query, args, err := patch.Update(subjectsTable, columns, fields, s.UUID, tenantID, expected)
+	if err != nil {
+		return err
+	}
+
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.GetContext(ctx, s, query, args...); err != nil {
+			if err == sql.ErrNoRows && expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, s.UUID)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error patching subject")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubjectUpdated, tenantID, s.UUID, s)}, nil
+	})
+}
+
+func (r subjectRepository) DeleteSubject(ctx context.Context, id uuid.UUID) error {
 	stmt, ok := r.statements[deleteSubject]
 	if !ok {
 		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteSubject)
 	}
+	ctx, end := postgres.StartStatement(ctx, deleteSubject)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	return outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, id, tenantID, expected)
+		if err != nil {
+			return nil, errors.WrapDatabaseErrorf(err, "error deleting subject")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if expected != 0 {
+				return nil, r.versionMismatch(ctx, tx, id)
+			}
+			return nil, nil
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubjectDeleted, tenantID, id, deleted{UUID: id})}, nil
+	})
+}
+
+func (r subjectRepository) RestoreSubject(ctx context.Context, id uuid.UUID) (domain.Subject, error) {
+	stmt, ok := r.statements[restoreSubject]
+	if !ok {
+		return domain.Subject{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", restoreSubject)
+	}
+	ctx, end := postgres.StartStatement(ctx, restoreSubject)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Subject{}, err
+	}
+
+	var sub domain.Subject
+	err = outbox.WithTx(ctx, r.db, func(tx *sqlx.Tx) ([]outbox.Event, error) {
+		if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &sub, id, tenantID); err != nil {
+			if errors.DatabaseCode(err) == errors.ErrCodeConflict {
+				return nil, errors.WrapErrorf(err, errors.ErrCodeConflict, "subject %s code is taken by another subject", id)
+			}
+			return nil, errors.WrapDatabaseErrorf(err, "error restoring subject")
+		}
+		return []outbox.Event{outbox.NewEvent(domain.EventSubjectRestored, tenantID, sub.UUID, &sub)}, nil
+	})
+	if err != nil {
+		return domain.Subject{}, err
+	}
+	return sub, nil
+}
+
+func (r subjectRepository) PurgeSubjects(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := r.statements[purgeSubjects]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeSubjects)
+	}
+	ctx, end := postgres.StartStatement(ctx, purgeSubjects)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging subjects")
+	}
+	return res.RowsAffected()
+}
+
+// versionMismatch tells a subject changed since the expected version apart from a missing one,
+// the subject is read within the transaction of the failed change
+func (r subjectRepository) versionMismatch(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
+	stmt, ok := r.statements[getSubject]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getSubject)
+	}
+	ctx, end := postgres.StartStatement(ctx, getSubject)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
 
-	if _, err := stmt.Exec(id); err != nil {
-		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error deleting subject")
+	var current domain.Subject
+	if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, &current, id, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error getting subject")
 	}
-	return nil
+	return errors.NewErrorf(errors.ErrCodePreconditionFailed, "subject %s was changed, it is at version %d", id, current.Version)
 }
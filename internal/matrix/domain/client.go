@@ -8,4 +8,6 @@ import (
 
 type CourseClient interface {
 	CourseExists(ctx context.Context, id uuid.UUID) error
+	SubscriptionMatrix(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error)
+	SubscriptionUser(ctx context.Context, subscriptionID uuid.UUID) (uuid.UUID, error)
 }
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompletionStatus is how far a learner got in a subject
+type CompletionStatus string
+
+const (
+	CompletionInProgress CompletionStatus = "in_progress"
+	CompletionPassed     CompletionStatus = "passed"
+	CompletionFailed     CompletionStatus = "failed"
+)
+
+// Valid tells whether the status is one of the known completion statuses
+func (s CompletionStatus) Valid() bool {
+	switch s {
+	case CompletionInProgress, CompletionPassed, CompletionFailed:
+		return true
+	}
+	return false
+}
+
+// Completion records the result of a subject taken by a subscribed learner
+type Completion struct {
+	ID             uint             `json:"id"`
+	TenantID       string           `db:"tenant_id" json:"-"`
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	SubjectID      uuid.UUID        `db:"subject_id" json:"subject_id"`
+	Status         CompletionStatus `json:"status"`
+	Grade          *float32         `json:"grade"`
+	CompletedAt    *time.Time       `db:"completed_at" json:"completed_at"`
+	CreatedAt      time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+// Progress is where a subscribed learner stands in the matrix of the subscription
+type Progress struct {
+	SubscriptionID    uuid.UUID             `json:"subscription_id"`
+	MatrixID          uuid.UUID             `json:"matrix_id"`
+	CreditsEarned     float32               `json:"credits_earned"`
+	CreditsNeeded     float32               `json:"credits_needed"`
+	RemainingRequired []MatrixSubjectDetail `json:"remaining_required"`
+	Eligible          bool                  `json:"eligible"`
+}
+
+// EvaluateProgress compares the passed subjects against the matrix subjects. Credits are earned
+// by passing any subject of the matrix, and the learner is eligible to graduate once every
+// required subject is passed and the needed credits are earned. The needed credits are the
+// credits of the required subjects, raised to minCredits when that is higher. A matrix without
+// required subjects doesn't lead to graduation, so nobody is eligible in it.
+func EvaluateProgress(subjects []MatrixSubjectDetail, completions []Completion, minCredits float32) Progress {
+	passed := make(map[uuid.UUID]bool, len(completions))
+	for _, c := range completions {
+		if c.Status == CompletionPassed {
+			passed[c.SubjectID] = true
+		}
+	}
+
+	progress := Progress{RemainingRequired: []MatrixSubjectDetail{}}
+	required := 0
+	for _, s := range subjects {
+		if s.IsRequired {
+			progress.CreditsNeeded += s.Credit
+			required++
+		}
+		switch {
+		case passed[s.UUID]:
+			progress.CreditsEarned += s.Credit
+		case s.IsRequired:
+			progress.RemainingRequired = append(progress.RemainingRequired, s)
+		}
+	}
+	if minCredits > progress.CreditsNeeded {
+		progress.CreditsNeeded = minCredits
+	}
+
+	progress.Eligible = required > 0 && len(progress.RemainingRequired) == 0 &&
+		progress.CreditsEarned >= progress.CreditsNeeded
+	return progress
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CompletionRepository interface {
+	Completions(ctx context.Context, subscriptionID uuid.UUID) ([]Completion, error)
+	SaveCompletion(ctx context.Context, completion *Completion) error
+	DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) error
+}
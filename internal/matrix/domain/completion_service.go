@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+func (s *Service) Completions(ctx context.Context, subscriptionID uuid.UUID) ([]Completion, error) {
+	if _, err := s.courses.SubscriptionMatrix(ctx, subscriptionID); err != nil {
+		return []Completion{}, fmt.Errorf("service can't find subscription: %w", err)
+	}
+
+	list, err := s.completions.Completions(ctx, subscriptionID)
+	if err != nil {
+		return []Completion{}, fmt.Errorf("service didn't found any completion: %w", err)
+	}
+	return list, nil
+}
+
+// CompleteSubject records the result of a subject of the subscription matrix
+func (s *Service) CompleteSubject(ctx context.Context, c *Completion) error {
+	if !c.Status.Valid() {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid completion status %s", c.Status)
+	}
+
+	matrixID, err := s.courses.SubscriptionMatrix(ctx, c.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("service can't complete subject: %w", err)
+	}
+
+	subjects, err := s.MatrixSubjects(ctx, matrixID)
+	if err != nil {
+		return fmt.Errorf("service can't complete subject: %w", err)
+	}
+	if !hasSubject(subjects, c.SubjectID) {
+		return errors.NewErrorf(errors.ErrCodeNotFound, "subject %s is not in matrix %s", c.SubjectID, matrixID)
+	}
+
+	if c.Status != CompletionInProgress && c.CompletedAt == nil {
+		now := time.Now()
+		c.CompletedAt = &now
+	}
+
+	if err := s.completions.SaveCompletion(ctx, c); err != nil {
+		return fmt.Errorf("service can't complete subject: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) error {
+	if err := s.completions.DeleteCompletion(ctx, subscriptionID, subjectID); err != nil {
+		return fmt.Errorf("service can't delete completion: %w", err)
+	}
+	return nil
+}
+
+// Progress evaluates the subscription completions against the subjects of its matrix
+func (s *Service) Progress(ctx context.Context, subscriptionID uuid.UUID) (Progress, error) {
+	matrixID, err := s.courses.SubscriptionMatrix(ctx, subscriptionID)
+	if err != nil {
+		return Progress{}, fmt.Errorf("service can't find subscription: %w", err)
+	}
+
+	// The matrix may have been deleted since the subscription, its subjects are gone with it
+	subjects, err := s.MatrixSubjects(ctx, matrixID)
+	if err != nil {
+		return Progress{}, fmt.Errorf("service can't evaluate progress: %w", err)
+	}
+	completions, err := s.completions.Completions(ctx, subscriptionID)
+	if err != nil {
+		return Progress{}, fmt.Errorf("service can't evaluate progress: %w", err)
+	}
+
+	progress := EvaluateProgress(subjects, completions, s.rules.MinCredits)
+	progress.SubscriptionID = subscriptionID
+	progress.MatrixID = matrixID
+	return progress, nil
+}
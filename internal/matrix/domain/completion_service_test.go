@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// stubMatrices only knows the live matrices, the deleted ones aren't found like in the database
+type stubMatrices struct {
+	MatrixRepository
+	matrices map[uuid.UUID]Matrix
+	subjects map[uuid.UUID][]MatrixSubjectDetail
+	err      error
+}
+
+func (r *stubMatrices) Matrix(_ context.Context, id uuid.UUID) (Matrix, error) {
+	if r.err != nil {
+		return Matrix{}, r.err
+	}
+	m, ok := r.matrices[id]
+	if !ok {
+		return Matrix{}, errors.NewErrorf(errors.ErrCodeNotFound, "error getting matrix")
+	}
+	return m, nil
+}
+
+func (r *stubMatrices) Subjects(_ context.Context, matrixID uuid.UUID) ([]MatrixSubjectDetail, error) {
+	return r.subjects[matrixID], nil
+}
+
+type stubCompletions struct {
+	CompletionRepository
+	saved []Completion
+}
+
+func (r *stubCompletions) Completions(context.Context, uuid.UUID) ([]Completion, error) {
+	return []Completion{}, nil
+}
+
+func (r *stubCompletions) SaveCompletion(_ context.Context, c *Completion) error {
+	r.saved = append(r.saved, *c)
+	return nil
+}
+
+type stubCourses struct {
+	CourseClient
+	matrices map[uuid.UUID]uuid.UUID
+	users    map[uuid.UUID]uuid.UUID
+}
+
+func (c *stubCourses) SubscriptionMatrix(_ context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	return c.matrices[subscriptionID], nil
+}
+
+func (c *stubCourses) SubscriptionUser(_ context.Context, subscriptionID uuid.UUID) (uuid.UUID, error) {
+	return c.users[subscriptionID], nil
+}
+
+func TestService_Progress(t *testing.T) {
+	var (
+		liveMatrix    = uuid.New()
+		deletedMatrix = uuid.New()
+		emptyMatrix   = uuid.New()
+		live          = uuid.New()
+		deleted       = uuid.New()
+		empty         = uuid.New()
+		algebra       = MatrixSubjectDetail{Subject: Subject{UUID: uuid.New(), Credit: 4}, IsRequired: true}
+	)
+	matrices := &stubMatrices{
+		matrices: map[uuid.UUID]Matrix{liveMatrix: {UUID: liveMatrix}, emptyMatrix: {UUID: emptyMatrix}},
+		subjects: map[uuid.UUID][]MatrixSubjectDetail{
+			liveMatrix:    {algebra},
+			deletedMatrix: {algebra},
+		},
+	}
+	courses := &stubCourses{matrices: map[uuid.UUID]uuid.UUID{
+		live: liveMatrix, deleted: deletedMatrix, empty: emptyMatrix,
+	}}
+
+	tests := []struct {
+		name         string
+		subscription uuid.UUID
+		wantCode     errors.ErrorCode
+		wantErr      bool
+		wantEligible bool
+	}{
+		{name: "live matrix", subscription: live},
+		{name: "deleted matrix", subscription: deleted, wantErr: true, wantCode: errors.ErrCodeNotFound},
+		{name: "matrix without subjects", subscription: empty},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, _ := NewService(WithMatrixRepository(matrices), WithCompletionRepository(&stubCompletions{}),
+				WithCourseClient(courses))
+			got, err := svc.Progress(context.Background(), tt.subscription)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Progress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && errors.CodeOf(err) != tt.wantCode {
+				t.Errorf("Progress() code = %v, want %v", errors.CodeOf(err), tt.wantCode)
+			}
+			if got.Eligible != tt.wantEligible {
+				t.Errorf("Progress() eligible = %v, want %v", got.Eligible, tt.wantEligible)
+			}
+		})
+	}
+}
+
+func TestService_CompleteSubject_DeletedMatrix(t *testing.T) {
+	subscription, deletedMatrix := uuid.New(), uuid.New()
+	completions := &stubCompletions{}
+	svc, _ := NewService(
+		WithMatrixRepository(&stubMatrices{subjects: map[uuid.UUID][]MatrixSubjectDetail{}}),
+		WithCompletionRepository(completions),
+		WithCourseClient(&stubCourses{matrices: map[uuid.UUID]uuid.UUID{subscription: deletedMatrix}}))
+
+	err := svc.CompleteSubject(context.Background(),
+		&Completion{SubscriptionID: subscription, SubjectID: uuid.New(), Status: CompletionPassed})
+	if errors.CodeOf(err) != errors.ErrCodeNotFound {
+		t.Errorf("CompleteSubject() error = %v, want not found", err)
+	}
+	if len(completions.saved) != 0 {
+		t.Errorf("CompleteSubject() saved a completion of a deleted matrix")
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEvaluateProgress(t *testing.T) {
+	var (
+		algebra  = MatrixSubjectDetail{Subject: Subject{UUID: uuid.New(), Code: "ALG", Credit: 4}, IsRequired: true}
+		calculus = MatrixSubjectDetail{Subject: Subject{UUID: uuid.New(), Code: "CALC", Credit: 6}, IsRequired: true}
+		music    = MatrixSubjectDetail{Subject: Subject{UUID: uuid.New(), Code: "MUS", Credit: 2}}
+	)
+	subjects := []MatrixSubjectDetail{algebra, calculus, music}
+
+	completed := func(s MatrixSubjectDetail, status CompletionStatus) Completion {
+		return Completion{SubjectID: s.UUID, Status: status}
+	}
+
+	tests := []struct {
+		name          string
+		subjects      []MatrixSubjectDetail
+		completions   []Completion
+		minCredits    float32
+		wantEarned    float32
+		wantNeeded    float32
+		wantRemaining int
+		wantEligible  bool
+	}{
+		{
+			name:          "nothing completed",
+			wantNeeded:    10,
+			wantRemaining: 2,
+		},
+		{
+			name: "required subject failed",
+			completions: []Completion{
+				completed(algebra, CompletionPassed),
+				completed(calculus, CompletionFailed),
+				completed(music, CompletionPassed),
+			},
+			wantEarned:    6,
+			wantNeeded:    10,
+			wantRemaining: 1,
+		},
+		{
+			name: "every required subject passed",
+			completions: []Completion{
+				completed(algebra, CompletionPassed),
+				completed(calculus, CompletionPassed),
+			},
+			wantEarned:   10,
+			wantNeeded:   10,
+			wantEligible: true,
+		},
+		{
+			name: "minimum credits not earned",
+			completions: []Completion{
+				completed(algebra, CompletionPassed),
+				completed(calculus, CompletionPassed),
+				completed(music, CompletionInProgress),
+			},
+			minCredits: 12,
+			wantEarned: 10,
+			wantNeeded: 12,
+		},
+		{
+			name:        "no required subject",
+			subjects:    []MatrixSubjectDetail{music},
+			completions: []Completion{completed(music, CompletionPassed)},
+			wantEarned:  2,
+		},
+		{
+			name:     "matrix without subjects",
+			subjects: []MatrixSubjectDetail{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matrixSubjects := subjects
+			if tt.subjects != nil {
+				matrixSubjects = tt.subjects
+			}
+			got := EvaluateProgress(matrixSubjects, tt.completions, tt.minCredits)
+			if got.CreditsEarned != tt.wantEarned {
+				t.Errorf("EvaluateProgress() credits earned = %v, want %v", got.CreditsEarned, tt.wantEarned)
+			}
+			if got.CreditsNeeded != tt.wantNeeded {
+				t.Errorf("EvaluateProgress() credits needed = %v, want %v", got.CreditsNeeded, tt.wantNeeded)
+			}
+			if len(got.RemainingRequired) != tt.wantRemaining {
+				t.Errorf("EvaluateProgress() remaining = %v, want %v", len(got.RemainingRequired), tt.wantRemaining)
+			}
+			if got.Eligible != tt.wantEligible {
+				t.Errorf("EvaluateProgress() eligible = %v, want %v", got.Eligible, tt.wantEligible)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Types of the events written to the outbox along with the changes of the matrices and
+// subjects, their payload is the changed record
+const (
+	EventMatrixCreated        = "matrix.created"
+	EventMatrixUpdated        = "matrix.updated"
+	EventMatrixDeleted        = "matrix.deleted"
+	EventMatrixRestored       = "matrix.restored"
+	EventMatrixPublished      = "matrix.published"
+	EventMatrixSubjectAdded   = "matrix.subject_added"
+	EventMatrixSubjectRemoved = "matrix.subject_removed"
+
+	EventSubjectCreated  = "subject.created"
+	EventSubjectUpdated  = "subject.updated"
+	EventSubjectDeleted  = "subject.deleted"
+	EventSubjectRestored = "subject.restored"
+)
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type instrumentingService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	next           ServiceInterface
+}
+
+// NewInstrumentingService counts the calls to every method of the service and records their latency
+func NewInstrumentingService(next ServiceInterface, counter metrics.Counter, latency metrics.Histogram) ServiceInterface {
+	return &instrumentingService{requestCount: counter, requestLatency: latency, next: next}
+}
+
+func (s *instrumentingService) observe(method string, begin time.Time, err error) {
+	labels := []string{"method", method, "error", strconv.FormatBool(err != nil)}
+	s.requestCount.With(labels...).Add(1)
+	s.requestLatency.With(labels...).Observe(time.Since(begin).Seconds())
+}
+
+func (s *instrumentingService) Matrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	defer func(begin time.Time) { s.observe("Matrix", begin, err) }(time.Now())
+	return s.next.Matrix(ctx, id)
+}
+
+func (s *instrumentingService) Matrices(ctx context.Context, q pagination.Query) (list []Matrix, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Matrices", begin, err) }(time.Now())
+	return s.next.Matrices(ctx, q)
+}
+
+func (s *instrumentingService) CreateMatrix(ctx context.Context, matrix *Matrix) (err error) {
+	defer func(begin time.Time) { s.observe("CreateMatrix", begin, err) }(time.Now())
+	return s.next.CreateMatrix(ctx, matrix)
+}
+
+func (s *instrumentingService) UpdateMatrix(ctx context.Context, matrix *Matrix) (err error) {
+	defer func(begin time.Time) { s.observe("UpdateMatrix", begin, err) }(time.Now())
+	return s.next.UpdateMatrix(ctx, matrix)
+}
+
+func (s *instrumentingService) PatchMatrix(ctx context.Context, matrix *Matrix, fields []string) (err error) {
+	defer func(begin time.Time) { s.observe("PatchMatrix", begin, err) }(time.Now())
+	return s.next.PatchMatrix(ctx, matrix, fields)
+}
+
+func (s *instrumentingService) DeleteMatrix(ctx context.Context, id uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteMatrix", begin, err) }(time.Now())
+	return s.next.DeleteMatrix(ctx, id)
+}
+
+func (s *instrumentingService) RestoreMatrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	defer func(begin time.Time) { s.observe("RestoreMatrix", begin, err) }(time.Now())
+	return s.next.RestoreMatrix(ctx, id)
+}
+
+func (s *instrumentingService) MatrixSummary(ctx context.Context, id uuid.UUID) (res MatrixSummary, err error) {
+	defer func(begin time.Time) { s.observe("MatrixSummary", begin, err) }(time.Now())
+	return s.next.MatrixSummary(ctx, id)
+}
+
+func (s *instrumentingService) PublishMatrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	defer func(begin time.Time) { s.observe("PublishMatrix", begin, err) }(time.Now())
+	return s.next.PublishMatrix(ctx, id)
+}
+
+func (s *instrumentingService) AddSubject(ctx context.Context, matrixSubject *MatrixSubject) (err error) {
+	defer func(begin time.Time) { s.observe("AddSubject", begin, err) }(time.Now())
+	return s.next.AddSubject(ctx, matrixSubject)
+}
+
+func (s *instrumentingService) RemoveSubject(ctx context.Context, matrixID, SubjectID uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("RemoveSubject", begin, err) }(time.Now())
+	return s.next.RemoveSubject(ctx, matrixID, SubjectID)
+}
+
+func (s *instrumentingService) MatrixSubjects(ctx context.Context, matrixID uuid.UUID) (res []MatrixSubjectDetail, err error) {
+	defer func(begin time.Time) { s.observe("MatrixSubjects", begin, err) }(time.Now())
+	return s.next.MatrixSubjects(ctx, matrixID)
+}
+
+func (s *instrumentingService) Requisites(ctx context.Context, matrixID uuid.UUID) (res []Requisite, err error) {
+	defer func(begin time.Time) { s.observe("Requisites", begin, err) }(time.Now())
+	return s.next.Requisites(ctx, matrixID)
+}
+
+func (s *instrumentingService) AddRequisite(ctx context.Context, requisite *Requisite) (err error) {
+	defer func(begin time.Time) { s.observe("AddRequisite", begin, err) }(time.Now())
+	return s.next.AddRequisite(ctx, requisite)
+}
+
+func (s *instrumentingService) RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("RemoveRequisite", begin, err) }(time.Now())
+	return s.next.RemoveRequisite(ctx, matrixID, subjectID, requisiteID)
+}
+
+func (s *instrumentingService) StudyPlan(ctx context.Context, matrixID uuid.UUID) (res []StudyTerm, err error) {
+	defer func(begin time.Time) { s.observe("StudyPlan", begin, err) }(time.Now())
+	return s.next.StudyPlan(ctx, matrixID)
+}
+
+func (s *instrumentingService) Subject(ctx context.Context, id uuid.UUID) (res Subject, err error) {
+	defer func(begin time.Time) { s.observe("Subject", begin, err) }(time.Now())
+	return s.next.Subject(ctx, id)
+}
+
+func (s *instrumentingService) Subjects(ctx context.Context, q pagination.Query) (list []Subject, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Subjects", begin, err) }(time.Now())
+	return s.next.Subjects(ctx, q)
+}
+
+func (s *instrumentingService) CreateSubject(ctx context.Context, subject *Subject) (err error) {
+	defer func(begin time.Time) { s.observe("CreateSubject", begin, err) }(time.Now())
+	return s.next.CreateSubject(ctx, subject)
+}
+
+func (s *instrumentingService) UpdateSubject(ctx context.Context, subject *Subject) (err error) {
+	defer func(begin time.Time) { s.observe("UpdateSubject", begin, err) }(time.Now())
+	return s.next.UpdateSubject(ctx, subject)
+}
+
+func (s *instrumentingService) PatchSubject(ctx context.Context, subject *Subject, fields []string) (err error) {
+	defer func(begin time.Time) { s.observe("PatchSubject", begin, err) }(time.Now())
+	return s.next.PatchSubject(ctx, subject, fields)
+}
+
+func (s *instrumentingService) DeleteSubject(ctx context.Context, id uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteSubject", begin, err) }(time.Now())
+	return s.next.DeleteSubject(ctx, id)
+}
+
+func (s *instrumentingService) RestoreSubject(ctx context.Context, id uuid.UUID) (res Subject, err error) {
+	defer func(begin time.Time) { s.observe("RestoreSubject", begin, err) }(time.Now())
+	return s.next.RestoreSubject(ctx, id)
+}
+
+func (s *instrumentingService) Completions(ctx context.Context, subscriptionID uuid.UUID) (res []Completion, err error) {
+	defer func(begin time.Time) { s.observe("Completions", begin, err) }(time.Now())
+	return s.next.Completions(ctx, subscriptionID)
+}
+
+func (s *instrumentingService) CompleteSubject(ctx context.Context, completion *Completion) (err error) {
+	defer func(begin time.Time) { s.observe("CompleteSubject", begin, err) }(time.Now())
+	return s.next.CompleteSubject(ctx, completion)
+}
+
+func (s *instrumentingService) DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteCompletion", begin, err) }(time.Now())
+	return s.next.DeleteCompletion(ctx, subscriptionID, subjectID)
+}
+
+func (s *instrumentingService) Progress(ctx context.Context, subscriptionID uuid.UUID) (res Progress, err error) {
+	defer func(begin time.Time) { s.observe("Progress", begin, err) }(time.Now())
+	return s.next.Progress(ctx, subscriptionID)
+}
@@ -4,23 +4,53 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+// MatrixStatus is the publication state of a matrix
+type MatrixStatus string
+
+const (
+	MatrixDraft     MatrixStatus = "draft"
+	MatrixPublished MatrixStatus = "published"
 )
 
 type Matrix struct {
-	ID          uint       `json:"id"`
-	UUID        uuid.UUID  `json:"uuid"`
-	Code        string     `json:"code"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	CourseID    uuid.UUID  `db:"course_id" json:"course_id"`
-	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
-	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at"`
+	ID          uint         `json:"id"`
+	TenantID    string       `db:"tenant_id" json:"-"`
+	UUID        uuid.UUID    `json:"uuid"`
+	Code        string       `json:"code"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	CourseID    uuid.UUID    `db:"course_id" json:"course_id"`
+	Status      MatrixStatus `json:"status"`
+	PublishedAt *time.Time   `db:"published_at" json:"published_at"`
+	Version     int          `json:"version"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time   `db:"deleted_at" json:"deleted_at"`
+}
+
+// MatrixListFields are the matrix fields allowed to filter and sort the listing
+var MatrixListFields = pagination.Fields{
+	Filters: []string{"code", "name", "course_id", "status"},
+	Sorts:   []string{"code", "name", "created_at", "updated_at"},
 }
 
 type MatrixSubject struct {
-	ID         uint      `json:"id"`
-	SubjectID  uuid.UUID `db:"subject_id" json:"subject_id"`
-	MatrixID   uuid.UUID `db:"matrix_id" json:"matrix_id"`
-	IsRequired bool      `db:"is_required" json:"is_required"`
+	ID         uint       `json:"id"`
+	TenantID   string     `db:"tenant_id" json:"-"`
+	SubjectID  uuid.UUID  `db:"subject_id" json:"subject_id"`
+	MatrixID   uuid.UUID  `db:"matrix_id" json:"matrix_id"`
+	IsRequired bool       `db:"is_required" json:"is_required"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// MatrixSubjectDetail is a subject along with how it is linked to a matrix
+type MatrixSubjectDetail struct {
+	Subject
+	IsRequired bool `db:"is_required" json:"is_required"`
 }
@@ -1,13 +1,28 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
 
 type MatrixRepository interface {
-	Matrix(id uuid.UUID) (Matrix, error)
-	Matrices() ([]Matrix, error)
-	CreateMatrix(matrix *Matrix) error
-	UpdateMatrix(matrix *Matrix) error
-	DeleteMatrix(id uuid.UUID) error
-	AddSubject(matrixSubject *MatrixSubject) error
-	RemoveSubject(matrixID, subjectID uuid.UUID) error
+	Matrix(ctx context.Context, id uuid.UUID) (Matrix, error)
+	Matrices(ctx context.Context, q pagination.Query) ([]Matrix, pagination.Page, error)
+	CreateMatrix(ctx context.Context, matrix *Matrix) error
+	UpdateMatrix(ctx context.Context, matrix *Matrix) error
+	PatchMatrix(ctx context.Context, matrix *Matrix, fields []string) error
+	DeleteMatrix(ctx context.Context, id uuid.UUID) error
+	AddSubject(ctx context.Context, matrixSubject *MatrixSubject) error
+	RemoveSubject(ctx context.Context, matrixID, subjectID uuid.UUID) error
+	Subjects(ctx context.Context, matrixID uuid.UUID) ([]MatrixSubjectDetail, error)
+	Requisites(ctx context.Context, matrixID uuid.UUID) ([]Requisite, error)
+	AddRequisite(ctx context.Context, requisite *Requisite) error
+	RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) error
+	RestoreMatrix(ctx context.Context, id uuid.UUID) (Matrix, error)
+	PublishMatrix(ctx context.Context, id uuid.UUID) (Matrix, error)
+	PurgeMatrices(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,95 @@
+package domain
+
+import "fmt"
+
+// MatrixRules are the constraints a matrix must meet to be published. A zero value disables the rule.
+type MatrixRules struct {
+	MinCredits         float32
+	MaxCredits         float32
+	MinRequiredCredits float32
+	MaxTermCredits     float32
+	MaxTermWorkload    float32
+}
+
+// Violation describes a matrix rule the matrix doesn't meet
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// MatrixSummary aggregates the credits and workload of the subjects of a matrix
+type MatrixSummary struct {
+	Subjects         int         `json:"subjects"`
+	TotalCredits     float32     `json:"total_credits"`
+	RequiredCredits  float32     `json:"required_credits"`
+	ElectiveCredits  float32     `json:"elective_credits"`
+	TotalWorkload    float32     `json:"total_workload"`
+	RequiredWorkload float32     `json:"required_workload"`
+	ElectiveWorkload float32     `json:"elective_workload"`
+	Violations       []Violation `json:"violations"`
+}
+
+// Summarize sums up the credits and workload of the subjects, split into required and elective ones
+func Summarize(subjects []MatrixSubjectDetail) MatrixSummary {
+	summary := MatrixSummary{Subjects: len(subjects), Violations: []Violation{}}
+	for _, s := range subjects {
+		summary.TotalCredits += s.Credit
+		summary.TotalWorkload += s.Workload
+		if s.IsRequired {
+			summary.RequiredCredits += s.Credit
+			summary.RequiredWorkload += s.Workload
+		} else {
+			summary.ElectiveCredits += s.Credit
+			summary.ElectiveWorkload += s.Workload
+		}
+	}
+	return summary
+}
+
+// Check lists the rules broken by the matrix summary and its study plan. Only required subjects
+// count towards the term limits, since electives are up to each student.
+func (r MatrixRules) Check(summary MatrixSummary, plan []StudyTerm) []Violation {
+	violations := []Violation{}
+	if r.MinCredits > 0 && summary.TotalCredits < r.MinCredits {
+		violations = append(violations, Violation{
+			Rule:    "min_credits",
+			Message: fmt.Sprintf("matrix has %g credits, at least %g are needed", summary.TotalCredits, r.MinCredits),
+		})
+	}
+	if r.MaxCredits > 0 && summary.TotalCredits > r.MaxCredits {
+		violations = append(violations, Violation{
+			Rule:    "max_credits",
+			Message: fmt.Sprintf("matrix has %g credits, at most %g are allowed", summary.TotalCredits, r.MaxCredits),
+		})
+	}
+	if r.MinRequiredCredits > 0 && summary.RequiredCredits < r.MinRequiredCredits {
+		violations = append(violations, Violation{
+			Rule: "min_required_credits",
+			Message: fmt.Sprintf("matrix has %g required credits, at least %g are needed",
+				summary.RequiredCredits, r.MinRequiredCredits),
+		})
+	}
+
+	for _, t := range plan {
+		var credits, workload float32
+		for _, s := range t.Subjects {
+			if s.IsRequired {
+				credits += s.Credit
+				workload += s.Workload
+			}
+		}
+		if r.MaxTermCredits > 0 && credits > r.MaxTermCredits {
+			violations = append(violations, Violation{
+				Rule:    "max_term_credits",
+				Message: fmt.Sprintf("term %d has %g credits, at most %g are allowed", t.Term, credits, r.MaxTermCredits),
+			})
+		}
+		if r.MaxTermWorkload > 0 && workload > r.MaxTermWorkload {
+			violations = append(violations, Violation{
+				Rule:    "max_term_workload",
+				Message: fmt.Sprintf("term %d has %g hours, at most %g are allowed", t.Term, workload, r.MaxTermWorkload),
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatrixRules_Check(t *testing.T) {
+	var (
+		algebra  = MatrixSubjectDetail{Subject: Subject{Code: "ALG", Credit: 4, Workload: 60}, IsRequired: true}
+		calculus = MatrixSubjectDetail{Subject: Subject{Code: "CALC", Credit: 6, Workload: 90}, IsRequired: true}
+		music    = MatrixSubjectDetail{Subject: Subject{Code: "MUS", Credit: 2, Workload: 30}}
+	)
+	subjects := []MatrixSubjectDetail{algebra, calculus, music}
+	plan := []StudyTerm{{Term: 1, Subjects: subjects}}
+
+	summary := Summarize(subjects)
+	want := MatrixSummary{
+		Subjects:         3,
+		TotalCredits:     12,
+		RequiredCredits:  10,
+		ElectiveCredits:  2,
+		TotalWorkload:    180,
+		RequiredWorkload: 150,
+		ElectiveWorkload: 30,
+		Violations:       []Violation{},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Fatalf("Summarize() got = %v, want %v", summary, want)
+	}
+
+	tests := []struct {
+		name  string
+		rules MatrixRules
+		want  []string
+	}{
+		{
+			name:  "no rules",
+			rules: MatrixRules{},
+			want:  []string{},
+		},
+		{
+			name:  "rules met",
+			rules: MatrixRules{MinCredits: 12, MaxCredits: 12, MinRequiredCredits: 10, MaxTermWorkload: 150},
+			want:  []string{},
+		},
+		{
+			name:  "credit rules broken",
+			rules: MatrixRules{MinCredits: 20, MaxCredits: 10, MinRequiredCredits: 11},
+			want:  []string{"min_credits", "max_credits", "min_required_credits"},
+		},
+		{
+			name:  "term rules broken",
+			rules: MatrixRules{MaxTermCredits: 8, MaxTermWorkload: 120},
+			want:  []string{"max_term_credits", "max_term_workload"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := []string{}
+			for _, v := range tt.rules.Check(summary, plan) {
+				got = append(got, v.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,24 +3,29 @@ package domain
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
-func (s *Service) Matrix(_ context.Context, id uuid.UUID) (Matrix, error) {
-	m, err := s.matrices.Matrix(id)
+func (s *Service) Matrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	m, err := s.matrices.Matrix(ctx, id)
 	if err != nil {
 		return Matrix{}, fmt.Errorf("service can't find matrix: %w", err)
 	}
 	return m, nil
 }
 
-func (s *Service) Matrices(_ context.Context) ([]Matrix, error) {
-	mm, err := s.matrices.Matrices()
+func (s *Service) Matrices(ctx context.Context, q pagination.Query) ([]Matrix, pagination.Page, error) {
+	mm, page, err := s.matrices.Matrices(ctx, q)
 	if err != nil {
-		return []Matrix{}, fmt.Errorf("service didn't found any matrix: %w", err)
+		return []Matrix{}, pagination.Page{}, fmt.Errorf("service didn't found any matrix: %w", err)
 	}
-	return mm, nil
+	return mm, page, nil
 }
 
 func (s *Service) CreateMatrix(ctx context.Context, m *Matrix) error {
@@ -28,36 +33,142 @@ func (s *Service) CreateMatrix(ctx context.Context, m *Matrix) error {
 	if err != nil {
 		return fmt.Errorf("service can't create: %w", err)
 	}
-	if err := s.matrices.CreateMatrix(m); err != nil {
+	if err := s.matrices.CreateMatrix(ctx, m); err != nil {
 		return fmt.Errorf("service can't create matrix: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) UpdateMatrix(_ context.Context, m *Matrix) error {
-	if err := s.matrices.UpdateMatrix(m); err != nil {
+func (s *Service) UpdateMatrix(ctx context.Context, m *Matrix) error {
+	if err := s.matrixNoneMatch(ctx, m.UUID); err != nil {
+		return err
+	}
+	if err := s.matrices.UpdateMatrix(ctx, m); err != nil {
 		return fmt.Errorf("service can't update matrix: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) DeleteMatrix(_ context.Context, id uuid.UUID) error {
-	if err := s.matrices.DeleteMatrix(id); err != nil {
+// PatchMatrix writes only the given fields of the matrix
+func (s *Service) PatchMatrix(ctx context.Context, m *Matrix, fields []string) error {
+	if err := s.matrixNoneMatch(ctx, m.UUID); err != nil {
+		return err
+	}
+	if err := s.matrices.PatchMatrix(ctx, m, fields); err != nil {
+		return fmt.Errorf("service can't patch matrix: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteMatrix(ctx context.Context, id uuid.UUID) error {
+	if err := s.matrixNoneMatch(ctx, id); err != nil {
+		return err
+	}
+	if err := s.matrices.DeleteMatrix(ctx, id); err != nil {
 		return fmt.Errorf("service can't delete matrix: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) AddSubject(_ context.Context, ms *MatrixSubject) error {
-	if err := s.matrices.AddSubject(ms); err != nil {
+func (s *Service) RestoreMatrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	m, err := s.matrices.RestoreMatrix(ctx, id)
+	if err != nil {
+		return Matrix{}, fmt.Errorf("service can't restore matrix: %w", err)
+	}
+	return m, nil
+}
+
+// MatrixSummary sums up the credits and workload of the matrix and reports the rules it breaks
+func (s *Service) MatrixSummary(ctx context.Context, id uuid.UUID) (MatrixSummary, error) {
+	subjects, err := s.MatrixSubjects(ctx, id)
+	if err != nil {
+		return MatrixSummary{}, err
+	}
+
+	requisites, err := s.matrices.Requisites(ctx, id)
+	if err != nil {
+		return MatrixSummary{}, fmt.Errorf("service can't summarize matrix: %w", err)
+	}
+
+	summary := Summarize(subjects)
+	plan, err := PlanStudy(subjects, requisites)
+	if err != nil {
+		summary.Violations = append(summary.Violations, Violation{Rule: "requisites", Message: err.Error()})
+	}
+	summary.Violations = append(summary.Violations, s.rules.Check(summary, plan)...)
+	return summary, nil
+}
+
+// PublishMatrix publishes the draft matrix when it meets every matrix rule
+func (s *Service) PublishMatrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	summary, err := s.MatrixSummary(ctx, id)
+	if err != nil {
+		return Matrix{}, err
+	}
+	if len(summary.Violations) > 0 {
+		messages := make([]string, len(summary.Violations))
+		for i, v := range summary.Violations {
+			messages[i] = v.Message
+		}
+		return Matrix{}, errors.NewErrorf(errors.ErrCodeInvalidArgument,
+			"matrix %s breaks %d rules: %s", id, len(messages), strings.Join(messages, "; "))
+	}
+
+	m, err := s.matrices.PublishMatrix(ctx, id)
+	if err != nil {
+		return Matrix{}, fmt.Errorf("service can't publish matrix: %w", err)
+	}
+	return m, nil
+}
+
+func (s *Service) AddSubject(ctx context.Context, ms *MatrixSubject) error {
+	if _, err := s.matrices.Matrix(ctx, ms.MatrixID); err != nil {
+		return lookupError(err, "matrix", ms.MatrixID)
+	}
+	if _, err := s.subjects.Subject(ctx, ms.SubjectID); err != nil {
+		return lookupError(err, "subject", ms.SubjectID)
+	}
+	if err := s.matrices.AddSubject(ctx, ms); err != nil {
 		return fmt.Errorf("service can't adds the subject to matrix: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) RemoveSubject(_ context.Context, matrixID, subjectID uuid.UUID) error {
-	if err := s.matrices.RemoveSubject(matrixID, subjectID); err != nil {
+func (s *Service) RemoveSubject(ctx context.Context, matrixID, subjectID uuid.UUID) error {
+	if err := s.matrices.RemoveSubject(ctx, matrixID, subjectID); err != nil {
 		return fmt.Errorf("service can't removes the subject from matrix: %w", err)
 	}
 	return nil
 }
+
+func (s *Service) MatrixSubjects(ctx context.Context, matrixID uuid.UUID) ([]MatrixSubjectDetail, error) {
+	if _, err := s.matrices.Matrix(ctx, matrixID); err != nil {
+		return []MatrixSubjectDetail{}, lookupError(err, "matrix", matrixID)
+	}
+
+	list, err := s.matrices.Subjects(ctx, matrixID)
+	if err != nil {
+		return []MatrixSubjectDetail{}, fmt.Errorf("service didn't found any subject of matrix: %w", err)
+	}
+	return list, nil
+}
+
+// lookupError tells a missing record apart from a failed lookup, whose code is kept so a
+// database failure or a timeout isn't reported as not found
+func lookupError(err error, kind string, id uuid.UUID) error {
+	if errors.CodeOf(err) == errors.ErrCodeNotFound {
+		return errors.WrapErrorf(err, errors.ErrCodeNotFound, "%s %s not found", kind, id)
+	}
+	return fmt.Errorf("service can't find %s %s: %w", kind, id, err)
+}
+
+// matrixNoneMatch fails when the If-None-Match of the change matches the current matrix
+func (s *Service) matrixNoneMatch(ctx context.Context, id uuid.UUID) error {
+	return etag.CheckNoneMatch(ctx, func() (int, error) {
+		current, err := s.matrices.Matrix(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("service can't find matrix: %w", err)
+		}
+		return current.Version, nil
+	})
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+func TestService_MatrixLookups(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode errors.ErrorCode
+	}{
+		{name: "missing matrix", err: errors.NewErrorf(errors.ErrCodeNotFound, "error getting matrix"),
+			wantCode: errors.ErrCodeNotFound},
+		{name: "database failure", err: errors.NewErrorf(errors.ErrCodeUnknown, "error getting matrix"),
+			wantCode: errors.ErrCodeUnknown},
+		{name: "missing tenant", err: errors.NewErrorf(errors.ErrCodeInvalidArgument, "missing tenant"),
+			wantCode: errors.ErrCodeInvalidArgument},
+		{name: "statement timeout", err: errors.NewErrorf(errors.ErrCodeTimeout, "error getting matrix"),
+			wantCode: errors.ErrCodeTimeout},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, _ := NewService(WithMatrixRepository(&stubMatrices{err: tt.err}))
+			_, err := svc.MatrixSubjects(context.Background(), uuid.New())
+			if got := errors.CodeOf(err); got != tt.wantCode {
+				t.Errorf("MatrixSubjects() code = %v, want %v", got, tt.wantCode)
+			}
+			_, err = svc.Requisites(context.Background(), uuid.New())
+			if got := errors.CodeOf(err); got != tt.wantCode {
+				t.Errorf("Requisites() code = %v, want %v", got, tt.wantCode)
+			}
+			err = svc.AddRequisite(context.Background(), &Requisite{MatrixID: uuid.New(), SubjectID: uuid.New(),
+				RequisiteID: uuid.New(), Kind: Prerequisite})
+			if got := errors.CodeOf(err); got != tt.wantCode {
+				t.Errorf("AddRequisite() code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+const (
+	ScopeMatrixWrite   = "matrix:write"
+	ScopeSubjectWrite  = "subject:write"
+	ScopeProgressRead  = "progress:read"
+	ScopeProgressWrite = "progress:write"
+
+	RoleInstructor = "instructor"
+)
+
+// The instructor role is granted by the issuer of the tokens for the whole tenant rather than for
+// a course, so the instructors read and record the progress of every subscription of the tenant.
+var (
+	readPolicy          = auth.Policy{}
+	matrixWritePolicy   = auth.Policy{Scopes: []string{ScopeMatrixWrite}}
+	subjectWritePolicy  = auth.Policy{Scopes: []string{ScopeSubjectWrite}}
+	progressReadPolicy  = auth.Policy{Scopes: []string{ScopeProgressRead, ScopeProgressWrite}, Roles: []string{RoleInstructor}}
+	progressWritePolicy = auth.Policy{Scopes: []string{ScopeProgressWrite}, Roles: []string{RoleInstructor}}
+)
+
+type policyService struct {
+	next    ServiceInterface
+	courses CourseClient
+}
+
+// NewPolicyService puts the authorization policies in front of the service. Learners without
+// the progress scopes only read the completions and progress of their own subscriptions.
+func NewPolicyService(next ServiceInterface, courses CourseClient) ServiceInterface {
+	return &policyService{next: next, courses: courses}
+}
+
+func (s *policyService) Matrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return Matrix{}, err
+	}
+	return s.next.Matrix(ctx, id)
+}
+
+func (s *policyService) Matrices(ctx context.Context, q pagination.Query) ([]Matrix, pagination.Page, error) {
+	if err := readPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Matrix{}, pagination.Page{}, err
+	}
+	return s.next.Matrices(ctx, q)
+}
+
+func (s *policyService) CreateMatrix(ctx context.Context, m *Matrix) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.CreateMatrix(ctx, m)
+}
+
+func (s *policyService) UpdateMatrix(ctx context.Context, m *Matrix) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.UpdateMatrix(ctx, m)
+}
+
+func (s *policyService) PatchMatrix(ctx context.Context, m *Matrix, fields []string) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.PatchMatrix(ctx, m, fields)
+}
+
+func (s *policyService) DeleteMatrix(ctx context.Context, id uuid.UUID) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.DeleteMatrix(ctx, id)
+}
+
+func (s *policyService) RestoreMatrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return Matrix{}, err
+	}
+	return s.next.RestoreMatrix(ctx, id)
+}
+
+func (s *policyService) MatrixSummary(ctx context.Context, id uuid.UUID) (MatrixSummary, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return MatrixSummary{}, err
+	}
+	return s.next.MatrixSummary(ctx, id)
+}
+
+func (s *policyService) PublishMatrix(ctx context.Context, id uuid.UUID) (Matrix, error) {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return Matrix{}, err
+	}
+	return s.next.PublishMatrix(ctx, id)
+}
+
+func (s *policyService) AddSubject(ctx context.Context, ms *MatrixSubject) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.AddSubject(ctx, ms)
+}
+
+func (s *policyService) RemoveSubject(ctx context.Context, matrixID, subjectID uuid.UUID) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.RemoveSubject(ctx, matrixID, subjectID)
+}
+
+func (s *policyService) MatrixSubjects(ctx context.Context, matrixID uuid.UUID) ([]MatrixSubjectDetail, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return []MatrixSubjectDetail{}, err
+	}
+	return s.next.MatrixSubjects(ctx, matrixID)
+}
+
+func (s *policyService) Requisites(ctx context.Context, matrixID uuid.UUID) ([]Requisite, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return []Requisite{}, err
+	}
+	return s.next.Requisites(ctx, matrixID)
+}
+
+func (s *policyService) AddRequisite(ctx context.Context, req *Requisite) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.AddRequisite(ctx, req)
+}
+
+func (s *policyService) RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) error {
+	if err := matrixWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.RemoveRequisite(ctx, matrixID, subjectID, requisiteID)
+}
+
+func (s *policyService) StudyPlan(ctx context.Context, matrixID uuid.UUID) ([]StudyTerm, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return []StudyTerm{}, err
+	}
+	return s.next.StudyPlan(ctx, matrixID)
+}
+
+func (s *policyService) Subject(ctx context.Context, id uuid.UUID) (Subject, error) {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return Subject{}, err
+	}
+	return s.next.Subject(ctx, id)
+}
+
+func (s *policyService) Subjects(ctx context.Context, q pagination.Query) ([]Subject, pagination.Page, error) {
+	if err := readPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Subject{}, pagination.Page{}, err
+	}
+	return s.next.Subjects(ctx, q)
+}
+
+func (s *policyService) CreateSubject(ctx context.Context, subject *Subject) error {
+	if err := subjectWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.CreateSubject(ctx, subject)
+}
+
+func (s *policyService) UpdateSubject(ctx context.Context, subject *Subject) error {
+	if err := subjectWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.UpdateSubject(ctx, subject)
+}
+
+func (s *policyService) PatchSubject(ctx context.Context, subject *Subject, fields []string) error {
+	if err := subjectWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.PatchSubject(ctx, subject, fields)
+}
+
+func (s *policyService) DeleteSubject(ctx context.Context, id uuid.UUID) error {
+	if err := subjectWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.DeleteSubject(ctx, id)
+}
+
+func (s *policyService) RestoreSubject(ctx context.Context, id uuid.UUID) (Subject, error) {
+	if err := subjectWritePolicy.Authorize(ctx); err != nil {
+		return Subject{}, err
+	}
+	return s.next.RestoreSubject(ctx, id)
+}
+
+func (s *policyService) Completions(ctx context.Context, subscriptionID uuid.UUID) ([]Completion, error) {
+	if err := s.authorizeLearner(ctx, subscriptionID); err != nil {
+		return []Completion{}, err
+	}
+	return s.next.Completions(ctx, subscriptionID)
+}
+
+func (s *policyService) CompleteSubject(ctx context.Context, c *Completion) error {
+	if err := progressWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.CompleteSubject(ctx, c)
+}
+
+func (s *policyService) DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) error {
+	if err := progressWritePolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.DeleteCompletion(ctx, subscriptionID, subjectID)
+}
+
+func (s *policyService) Progress(ctx context.Context, subscriptionID uuid.UUID) (Progress, error) {
+	if err := s.authorizeLearner(ctx, subscriptionID); err != nil {
+		return Progress{}, err
+	}
+	return s.next.Progress(ctx, subscriptionID)
+}
+
+// authorizeLearner lets the subscribed learner through, anyone else must be granted the progress read policy
+func (s *policyService) authorizeLearner(ctx context.Context, subscriptionID uuid.UUID) error {
+	if err := readPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	if progressReadPolicy.Allows(ctx) {
+		return nil
+	}
+
+	userID, err := auth.SubjectID(ctx)
+	if err != nil {
+		return err
+	}
+	owner, err := s.courses.SubscriptionUser(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return errors.NewErrorf(errors.ErrCodeForbidden, "subscription belongs to another user")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+)
+
+type stubService struct {
+	ServiceInterface
+}
+
+func (s *stubService) Progress(_ context.Context, _ uuid.UUID) (Progress, error) {
+	return Progress{}, nil
+}
+
+func (s *stubService) CompleteSubject(_ context.Context, _ *Completion) error {
+	return nil
+}
+
+func (s *stubService) DeleteCompletion(_ context.Context, _, _ uuid.UUID) error {
+	return nil
+}
+
+func TestPolicyService_Progress(t *testing.T) {
+	var (
+		learner      = uuid.MustParse("d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d")
+		other        = uuid.MustParse("0c2e7a51-8d4b-4d55-9b5c-8a1f3e2d4c6b")
+		subscription = uuid.MustParse("5e9d2c1b-7a3f-4e8d-b6c5-2f1a0e9d8c7b")
+	)
+	ctxFor := func(scope string, roles ...string) context.Context {
+		return auth.NewContext(context.Background(), &auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: learner.String()},
+			Scope:            scope,
+			Roles:            roles,
+		})
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		owner     uuid.UUID
+		wantRead  bool
+		wantWrite bool
+	}{
+		{name: "learner reads own progress", ctx: ctxFor(""), owner: learner, wantRead: true},
+		{name: "learner reads another progress", ctx: ctxFor(""), owner: other},
+		{name: "staff reads progress", ctx: ctxFor(ScopeProgressRead), owner: other, wantRead: true},
+		{name: "staff records progress", ctx: ctxFor(ScopeProgressWrite), owner: other, wantRead: true, wantWrite: true},
+		{name: "instructor of the tenant", ctx: ctxFor("", RoleInstructor), owner: other, wantRead: true, wantWrite: true},
+		{name: "anonymous caller", ctx: context.Background(), owner: learner},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			courses := &stubCourses{users: map[uuid.UUID]uuid.UUID{subscription: tt.owner}}
+			s := NewPolicyService(&stubService{}, courses)
+
+			if _, err := s.Progress(tt.ctx, subscription); (err == nil) != tt.wantRead {
+				t.Errorf("Progress() error = %v, wantRead %v", err, tt.wantRead)
+			}
+			errComplete := s.CompleteSubject(tt.ctx, &Completion{SubscriptionID: subscription})
+			errDelete := s.DeleteCompletion(tt.ctx, subscription, uuid.New())
+			if gotWrite := errComplete == nil && errDelete == nil; gotWrite != tt.wantWrite {
+				t.Errorf("PolicyService write errors = %v, %v, wantWrite %v", errComplete, errDelete, tt.wantWrite)
+			}
+		})
+	}
+}
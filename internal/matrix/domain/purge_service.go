@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PurgeDeleted permanently deletes the matrices and subjects soft deleted longer than the retention period
+func (s *Service) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+
+	matrices, err := s.matrices.PurgeMatrices(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge matrices: %w", err)
+	}
+	subjects, err := s.subjects.PurgeSubjects(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge subjects: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Log("msg", "purged deleted records", "matrices", matrices, "subjects", subjects) //nolint: errcheck
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequisiteKind tells how a subject depends on its requisite
+type RequisiteKind string
+
+const (
+	// Prerequisite subjects must be completed in an earlier term
+	Prerequisite RequisiteKind = "prerequisite"
+	// Corequisite subjects are taken in the same term
+	Corequisite RequisiteKind = "corequisite"
+)
+
+// Valid tells whether the kind is one of the known requisite kinds
+func (k RequisiteKind) Valid() bool {
+	return k == Prerequisite || k == Corequisite
+}
+
+// Requisite is an edge of the subject graph of a matrix, where the subject requires the requisite subject
+type Requisite struct {
+	ID          uint          `json:"id"`
+	TenantID    string        `db:"tenant_id" json:"-"`
+	MatrixID    uuid.UUID     `db:"matrix_id" json:"matrix_id"`
+	SubjectID   uuid.UUID     `db:"subject_id" json:"subject_id"`
+	RequisiteID uuid.UUID     `db:"requisite_id" json:"requisite_id"`
+	Kind        RequisiteKind `json:"kind"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+func (s *Service) Requisites(ctx context.Context, matrixID uuid.UUID) ([]Requisite, error) {
+	if _, err := s.matrices.Matrix(ctx, matrixID); err != nil {
+		return []Requisite{}, lookupError(err, "matrix", matrixID)
+	}
+
+	list, err := s.matrices.Requisites(ctx, matrixID)
+	if err != nil {
+		return []Requisite{}, fmt.Errorf("service didn't found any requisite of matrix: %w", err)
+	}
+	return list, nil
+}
+
+// AddRequisite links two subjects of the matrix, refusing edges that would make the study plan impossible
+func (s *Service) AddRequisite(ctx context.Context, req *Requisite) error {
+	if !req.Kind.Valid() {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid requisite kind %s", req.Kind)
+	}
+	if req.SubjectID == req.RequisiteID {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "subject %s can't require itself", req.SubjectID)
+	}
+	if _, err := s.matrices.Matrix(ctx, req.MatrixID); err != nil {
+		return lookupError(err, "matrix", req.MatrixID)
+	}
+
+	subjects, err := s.matrices.Subjects(ctx, req.MatrixID)
+	if err != nil {
+		return fmt.Errorf("service can't adds the requisite: %w", err)
+	}
+	for _, id := range []uuid.UUID{req.SubjectID, req.RequisiteID} {
+		if !hasSubject(subjects, id) {
+			return errors.NewErrorf(errors.ErrCodeNotFound, "subject %s is not in matrix %s", id, req.MatrixID)
+		}
+	}
+
+	requisites, err := s.matrices.Requisites(ctx, req.MatrixID)
+	if err != nil {
+		return fmt.Errorf("service can't adds the requisite: %w", err)
+	}
+	if _, err := PlanStudy(subjects, append(requisites, *req)); err != nil {
+		return fmt.Errorf("service can't adds the requisite: %w", err)
+	}
+
+	if err := s.matrices.AddRequisite(ctx, req); err != nil {
+		return fmt.Errorf("service can't adds the requisite: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) error {
+	if err := s.matrices.RemoveRequisite(ctx, matrixID, subjectID, requisiteID); err != nil {
+		return fmt.Errorf("service can't removes the requisite: %w", err)
+	}
+	return nil
+}
+
+// StudyPlan orders the subjects of the matrix in terms following their requisites
+func (s *Service) StudyPlan(ctx context.Context, matrixID uuid.UUID) ([]StudyTerm, error) {
+	subjects, err := s.MatrixSubjects(ctx, matrixID)
+	if err != nil {
+		return []StudyTerm{}, err
+	}
+
+	requisites, err := s.matrices.Requisites(ctx, matrixID)
+	if err != nil {
+		return []StudyTerm{}, fmt.Errorf("service can't plan the study: %w", err)
+	}
+
+	plan, err := PlanStudy(subjects, requisites)
+	if err != nil {
+		return []StudyTerm{}, fmt.Errorf("service can't plan the study: %w", err)
+	}
+	return plan, nil
+}
+
+func hasSubject(subjects []MatrixSubjectDetail, id uuid.UUID) bool {
+	for _, s := range subjects {
+		if s.UUID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -5,31 +5,51 @@ import (
 
 	"github.com/go-kit/log"
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 type ServiceInterface interface {
 	Matrix(ctx context.Context, id uuid.UUID) (Matrix, error)
-	Matrices(ctx context.Context) ([]Matrix, error)
+	Matrices(ctx context.Context, q pagination.Query) ([]Matrix, pagination.Page, error)
 	CreateMatrix(ctx context.Context, matrix *Matrix) error
 	UpdateMatrix(ctx context.Context, matrix *Matrix) error
+	PatchMatrix(ctx context.Context, matrix *Matrix, fields []string) error
 	DeleteMatrix(ctx context.Context, id uuid.UUID) error
+	RestoreMatrix(ctx context.Context, id uuid.UUID) (Matrix, error)
+	MatrixSummary(ctx context.Context, id uuid.UUID) (MatrixSummary, error)
+	PublishMatrix(ctx context.Context, id uuid.UUID) (Matrix, error)
 	AddSubject(ctx context.Context, matrixSubject *MatrixSubject) error
 	RemoveSubject(ctx context.Context, matrixID, SubjectID uuid.UUID) error
+	MatrixSubjects(ctx context.Context, matrixID uuid.UUID) ([]MatrixSubjectDetail, error)
+	Requisites(ctx context.Context, matrixID uuid.UUID) ([]Requisite, error)
+	AddRequisite(ctx context.Context, requisite *Requisite) error
+	RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) error
+	StudyPlan(ctx context.Context, matrixID uuid.UUID) ([]StudyTerm, error)
 
 	Subject(ctx context.Context, id uuid.UUID) (Subject, error)
-	Subjects(ctx context.Context) ([]Subject, error)
+	Subjects(ctx context.Context, q pagination.Query) ([]Subject, pagination.Page, error)
 	CreateSubject(ctx context.Context, subject *Subject) error
 	UpdateSubject(ctx context.Context, subject *Subject) error
+	PatchSubject(ctx context.Context, subject *Subject, fields []string) error
 	DeleteSubject(ctx context.Context, id uuid.UUID) error
+	RestoreSubject(ctx context.Context, id uuid.UUID) (Subject, error)
+
+	Completions(ctx context.Context, subscriptionID uuid.UUID) ([]Completion, error)
+	CompleteSubject(ctx context.Context, completion *Completion) error
+	DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) error
+	Progress(ctx context.Context, subscriptionID uuid.UUID) (Progress, error)
 }
 
 type serviceConfiguration func(svc *Service) error
 
 type Service struct {
-	matrices MatrixRepository
-	subjects SubjectRepository
-	courses  CourseClient
-	logger   log.Logger
+	matrices    MatrixRepository
+	subjects    SubjectRepository
+	completions CompletionRepository
+	courses     CourseClient
+	rules       MatrixRules
+	logger      log.Logger
 }
 
 // NewService creates a new domain Service instance
@@ -60,6 +80,14 @@ func WithSubjectRepository(sr SubjectRepository) serviceConfiguration {
 	}
 }
 
+// WithCompletionRepository injects the completion repository to the domain Service
+func WithCompletionRepository(cr CompletionRepository) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.completions = cr
+		return nil
+	}
+}
+
 // WithLogger injects the logger to the domain Service
 func WithLogger(l log.Logger) serviceConfiguration {
 	return func(svc *Service) error {
@@ -74,3 +102,11 @@ func WithCourseClient(c CourseClient) serviceConfiguration {
 		return nil
 	}
 }
+
+// WithMatrixRules injects the rules checked before publishing a matrix to the domain Service
+func WithMatrixRules(r MatrixRules) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.rules = r
+		return nil
+	}
+}
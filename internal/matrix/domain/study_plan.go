@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// StudyTerm groups the subjects of a matrix that can be taken together
+type StudyTerm struct {
+	Term     int                   `json:"term"`
+	Subjects []MatrixSubjectDetail `json:"subjects"`
+}
+
+// PlanStudy orders the subjects of a matrix in terms following their requisites. Co-requisite
+// subjects are kept in the same term and every prerequisite lands in an earlier term than the
+// subjects requiring it. Subjects keep their given order within a term. An error is returned
+// when the requisites form a cycle.
+func PlanStudy(subjects []MatrixSubjectDetail, requisites []Requisite) ([]StudyTerm, error) {
+	index := make(map[uuid.UUID]int, len(subjects))
+	for i, s := range subjects {
+		index[s.UUID] = i
+	}
+
+	// co-requisites are merged into groups that are planned as a single node
+	group := make([]int, len(subjects))
+	for i := range group {
+		group[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if group[i] != i {
+			group[i] = find(group[i])
+		}
+		return group[i]
+	}
+	for _, r := range requisites {
+		from, okFrom := index[r.RequisiteID]
+		to, okTo := index[r.SubjectID]
+		if r.Kind != Corequisite || !okFrom || !okTo {
+			continue
+		}
+		group[find(from)] = find(to)
+	}
+
+	next := make(map[int][]int)
+	inDegree := make(map[int]int)
+	for _, r := range requisites {
+		from, okFrom := index[r.RequisiteID]
+		to, okTo := index[r.SubjectID]
+		if r.Kind != Prerequisite || !okFrom || !okTo {
+			continue
+		}
+		gFrom, gTo := find(from), find(to)
+		if gFrom == gTo {
+			return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument,
+				"subject %s can't be both a prerequisite and a co-requisite of %s", r.RequisiteID, r.SubjectID)
+		}
+		next[gFrom] = append(next[gFrom], gTo)
+		inDegree[gTo]++
+	}
+
+	var current []int
+	for i := range subjects {
+		if find(i) == i && inDegree[i] == 0 {
+			current = append(current, i)
+		}
+	}
+
+	term := make(map[int]int, len(subjects))
+	planned := 0
+	for t := 1; len(current) > 0; t++ {
+		var following []int
+		for _, g := range current {
+			term[g] = t
+			planned++
+			for _, n := range next[g] {
+				inDegree[n]--
+				if inDegree[n] == 0 {
+					following = append(following, n)
+				}
+			}
+		}
+		current = following
+	}
+
+	groups := 0
+	for i := range subjects {
+		if find(i) == i {
+			groups++
+		}
+	}
+	if planned != groups {
+		return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "subject requisites form a cycle")
+	}
+
+	plan := []StudyTerm{}
+	for i, s := range subjects {
+		t := term[find(i)]
+		for len(plan) < t {
+			plan = append(plan, StudyTerm{Term: len(plan) + 1})
+		}
+		plan[t-1].Subjects = append(plan[t-1].Subjects, s)
+	}
+	return plan, nil
+}
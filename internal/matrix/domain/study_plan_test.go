@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPlanStudy(t *testing.T) {
+	var (
+		calc1   = MatrixSubjectDetail{Subject: Subject{UUID: uuid.MustParse("1f7e0fe0-5a3c-4d8b-9d0b-1c2d3e4f5a01"), Code: "CALC1"}}
+		calc2   = MatrixSubjectDetail{Subject: Subject{UUID: uuid.MustParse("1f7e0fe0-5a3c-4d8b-9d0b-1c2d3e4f5a02"), Code: "CALC2"}}
+		physics = MatrixSubjectDetail{Subject: Subject{UUID: uuid.MustParse("1f7e0fe0-5a3c-4d8b-9d0b-1c2d3e4f5a03"), Code: "PHYS1"}}
+		lab     = MatrixSubjectDetail{Subject: Subject{UUID: uuid.MustParse("1f7e0fe0-5a3c-4d8b-9d0b-1c2d3e4f5a04"), Code: "PHYSLAB"}}
+	)
+	subjects := []MatrixSubjectDetail{calc1, calc2, physics, lab}
+
+	requires := func(subject, requisite MatrixSubjectDetail, kind RequisiteKind) Requisite {
+		return Requisite{SubjectID: subject.UUID, RequisiteID: requisite.UUID, Kind: kind}
+	}
+
+	tests := []struct {
+		name       string
+		requisites []Requisite
+		want       [][]string
+		wantErr    bool
+	}{
+		{
+			name: "no requisites",
+			want: [][]string{{"CALC1", "CALC2", "PHYS1", "PHYSLAB"}},
+		},
+		{
+			name: "prerequisites and co-requisites",
+			requisites: []Requisite{
+				requires(calc2, calc1, Prerequisite),
+				requires(physics, calc1, Prerequisite),
+				requires(physics, lab, Corequisite),
+			},
+			want: [][]string{{"CALC1"}, {"CALC2", "PHYS1", "PHYSLAB"}},
+		},
+		{
+			name: "prerequisite chain",
+			requisites: []Requisite{
+				requires(calc2, calc1, Prerequisite),
+				requires(physics, calc2, Prerequisite),
+				requires(lab, physics, Prerequisite),
+			},
+			want: [][]string{{"CALC1"}, {"CALC2"}, {"PHYS1"}, {"PHYSLAB"}},
+		},
+		{
+			name: "prerequisite cycle",
+			requisites: []Requisite{
+				requires(calc2, calc1, Prerequisite),
+				requires(physics, calc2, Prerequisite),
+				requires(calc1, physics, Prerequisite),
+			},
+			wantErr: true,
+		},
+		{
+			name: "prerequisite between co-requisites",
+			requisites: []Requisite{
+				requires(physics, lab, Corequisite),
+				requires(lab, physics, Prerequisite),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := PlanStudy(subjects, tt.requisites)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PlanStudy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := make([][]string, len(plan))
+			for i, term := range plan {
+				for _, s := range term.Subjects {
+					got[i] = append(got[i], s.Code)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanStudy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,18 +4,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 // Subject struct
 type Subject struct {
 	ID        uint       `json:"id"`
+	TenantID  string     `db:"tenant_id" json:"-"`
 	UUID      uuid.UUID  `json:"uuid"`
 	Code      string     `json:"code"`
 	Name      string     `json:"name"`
 	Objective string     `json:"objective"`
 	Credit    float32    `json:"credit"`
 	Workload  float32    `json:"workload"`
+	Version   int        `json:"version"`
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
 	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
 }
+
+// SubjectListFields are the subject fields allowed to filter and sort the listing
+var SubjectListFields = pagination.Fields{
+	Filters: []string{"code", "name"},
+	Sorts:   []string{"code", "name", "credit", "workload", "created_at", "updated_at"},
+}
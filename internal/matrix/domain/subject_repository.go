@@ -1,13 +1,21 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 type SubjectRepository interface {
-	Subject(uuid.UUID) (Subject, error)
-	Subjects() ([]Subject, error)
-	CreateSubject(*Subject) error
-	UpdateSubject(*Subject) error
-	DeleteSubject(uuid.UUID) error
+	Subject(context.Context, uuid.UUID) (Subject, error)
+	Subjects(context.Context, pagination.Query) ([]Subject, pagination.Page, error)
+	CreateSubject(context.Context, *Subject) error
+	UpdateSubject(context.Context, *Subject) error
+	PatchSubject(context.Context, *Subject, []string) error
+	DeleteSubject(context.Context, uuid.UUID) error
+	RestoreSubject(context.Context, uuid.UUID) (Subject, error)
+	PurgeSubjects(context.Context, time.Time) (int64, error)
 }
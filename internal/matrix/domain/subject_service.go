@@ -5,41 +5,80 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
-func (s *Service) Subject(_ context.Context, id uuid.UUID) (Subject, error) {
-	sub, err := s.subjects.Subject(id)
+func (s *Service) Subject(ctx context.Context, id uuid.UUID) (Subject, error) {
+	sub, err := s.subjects.Subject(ctx, id)
 	if err != nil {
 		return Subject{}, fmt.Errorf("service can't find subject: %w", err)
 	}
 	return sub, nil
 }
 
-func (s *Service) Subjects(_ context.Context) ([]Subject, error) {
-	subs, err := s.subjects.Subjects()
+func (s *Service) Subjects(ctx context.Context, q pagination.Query) ([]Subject, pagination.Page, error) {
+	subs, page, err := s.subjects.Subjects(ctx, q)
 	if err != nil {
-		return []Subject{}, fmt.Errorf("service didn't found any subject: %w", err)
+		return []Subject{}, pagination.Page{}, fmt.Errorf("service didn't found any subject: %w", err)
 	}
-	return subs, nil
+	return subs, page, nil
 }
 
-func (s *Service) CreateSubject(_ context.Context, sub *Subject) error {
-	if err := s.subjects.CreateSubject(sub); err != nil {
+func (s *Service) CreateSubject(ctx context.Context, sub *Subject) error {
+	if err := s.subjects.CreateSubject(ctx, sub); err != nil {
 		return fmt.Errorf("service can't create course: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) UpdateSubject(_ context.Context, sub *Subject) error {
-	if err := s.subjects.UpdateSubject(sub); err != nil {
+func (s *Service) UpdateSubject(ctx context.Context, sub *Subject) error {
+	if err := s.subjectNoneMatch(ctx, sub.UUID); err != nil {
+		return err
+	}
+	if err := s.subjects.UpdateSubject(ctx, sub); err != nil {
 		return fmt.Errorf("service can't update course: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) DeleteSubject(_ context.Context, id uuid.UUID) error {
-	if err := s.subjects.DeleteSubject(id); err != nil {
+// PatchSubject writes only the given fields of the subject
+func (s *Service) PatchSubject(ctx context.Context, sub *Subject, fields []string) error {
+	if err := s.subjectNoneMatch(ctx, sub.UUID); err != nil {
+		return err
+	}
+	if err := s.subjects.PatchSubject(ctx, sub, fields); err != nil {
+		return fmt.Errorf("service can't patch subject: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteSubject(ctx context.Context, id uuid.UUID) error {
+	if err := s.subjectNoneMatch(ctx, id); err != nil {
+		return err
+	}
+	if err := s.subjects.DeleteSubject(ctx, id); err != nil {
 		return fmt.Errorf("service can't delete course: %w", err)
 	}
 	return nil
 }
+
+func (s *Service) RestoreSubject(ctx context.Context, id uuid.UUID) (Subject, error) {
+	sub, err := s.subjects.RestoreSubject(ctx, id)
+	if err != nil {
+		return Subject{}, fmt.Errorf("service can't restore subject: %w", err)
+	}
+	return sub, nil
+}
+
+// subjectNoneMatch fails when the If-None-Match of the change matches the current subject
+func (s *Service) subjectNoneMatch(ctx context.Context, id uuid.UUID) error {
+	return etag.CheckNoneMatch(ctx, func() (int, error) {
+		current, err := s.subjects.Subject(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("service can't find subject: %w", err)
+		}
+		return current.Version, nil
+	})
+}
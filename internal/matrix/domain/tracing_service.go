@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+)
+
+type tracingService struct {
+	next ServiceInterface
+}
+
+// NewTracingService starts a span for every call to the service, the parent of the spans of the
+// course client and of the statements it runs
+func NewTracingService(next ServiceInterface) ServiceInterface {
+	return &tracingService{next: next}
+}
+
+func (s *tracingService) Matrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Matrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Matrix(ctx, id)
+}
+
+func (s *tracingService) Matrices(ctx context.Context, q pagination.Query) (list []Matrix, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Matrices")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Matrices(ctx, q)
+}
+
+func (s *tracingService) CreateMatrix(ctx context.Context, matrix *Matrix) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.CreateMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateMatrix(ctx, matrix)
+}
+
+func (s *tracingService) UpdateMatrix(ctx context.Context, matrix *Matrix) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.UpdateMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateMatrix(ctx, matrix)
+}
+
+func (s *tracingService) PatchMatrix(ctx context.Context, matrix *Matrix, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.PatchMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.PatchMatrix(ctx, matrix, fields)
+}
+
+func (s *tracingService) DeleteMatrix(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.DeleteMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteMatrix(ctx, id)
+}
+
+func (s *tracingService) RestoreMatrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.RestoreMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RestoreMatrix(ctx, id)
+}
+
+func (s *tracingService) MatrixSummary(ctx context.Context, id uuid.UUID) (res MatrixSummary, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.MatrixSummary")
+	defer func() { tracing.End(span, err) }()
+	return s.next.MatrixSummary(ctx, id)
+}
+
+func (s *tracingService) PublishMatrix(ctx context.Context, id uuid.UUID) (res Matrix, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.PublishMatrix")
+	defer func() { tracing.End(span, err) }()
+	return s.next.PublishMatrix(ctx, id)
+}
+
+func (s *tracingService) AddSubject(ctx context.Context, matrixSubject *MatrixSubject) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.AddSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.AddSubject(ctx, matrixSubject)
+}
+
+func (s *tracingService) RemoveSubject(ctx context.Context, matrixID, SubjectID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.RemoveSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RemoveSubject(ctx, matrixID, SubjectID)
+}
+
+func (s *tracingService) MatrixSubjects(ctx context.Context, matrixID uuid.UUID) (res []MatrixSubjectDetail, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.MatrixSubjects")
+	defer func() { tracing.End(span, err) }()
+	return s.next.MatrixSubjects(ctx, matrixID)
+}
+
+func (s *tracingService) Requisites(ctx context.Context, matrixID uuid.UUID) (res []Requisite, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Requisites")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Requisites(ctx, matrixID)
+}
+
+func (s *tracingService) AddRequisite(ctx context.Context, requisite *Requisite) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.AddRequisite")
+	defer func() { tracing.End(span, err) }()
+	return s.next.AddRequisite(ctx, requisite)
+}
+
+func (s *tracingService) RemoveRequisite(ctx context.Context, matrixID, subjectID, requisiteID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.RemoveRequisite")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RemoveRequisite(ctx, matrixID, subjectID, requisiteID)
+}
+
+func (s *tracingService) StudyPlan(ctx context.Context, matrixID uuid.UUID) (res []StudyTerm, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.StudyPlan")
+	defer func() { tracing.End(span, err) }()
+	return s.next.StudyPlan(ctx, matrixID)
+}
+
+func (s *tracingService) Subject(ctx context.Context, id uuid.UUID) (res Subject, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Subject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Subject(ctx, id)
+}
+
+func (s *tracingService) Subjects(ctx context.Context, q pagination.Query) (list []Subject, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Subjects")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Subjects(ctx, q)
+}
+
+func (s *tracingService) CreateSubject(ctx context.Context, subject *Subject) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.CreateSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateSubject(ctx, subject)
+}
+
+func (s *tracingService) UpdateSubject(ctx context.Context, subject *Subject) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.UpdateSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateSubject(ctx, subject)
+}
+
+func (s *tracingService) PatchSubject(ctx context.Context, subject *Subject, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.PatchSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.PatchSubject(ctx, subject, fields)
+}
+
+func (s *tracingService) DeleteSubject(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.DeleteSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteSubject(ctx, id)
+}
+
+func (s *tracingService) RestoreSubject(ctx context.Context, id uuid.UUID) (res Subject, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.RestoreSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RestoreSubject(ctx, id)
+}
+
+func (s *tracingService) Completions(ctx context.Context, subscriptionID uuid.UUID) (res []Completion, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Completions")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Completions(ctx, subscriptionID)
+}
+
+func (s *tracingService) CompleteSubject(ctx context.Context, completion *Completion) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.CompleteSubject")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CompleteSubject(ctx, completion)
+}
+
+func (s *tracingService) DeleteCompletion(ctx context.Context, subscriptionID, subjectID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "matrix.DeleteCompletion")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteCompletion(ctx, subscriptionID, subjectID)
+}
+
+func (s *tracingService) Progress(ctx context.Context, subscriptionID uuid.UUID) (res Progress, err error) {
+	ctx, span := tracing.Start(ctx, "matrix.Progress")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Progress(ctx, subscriptionID)
+}
@@ -0,0 +1,101 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type addRequisiteRequest struct {
+	MatrixID    uuid.UUID `json:"matrix_id" validate:"required"`
+	SubjectID   uuid.UUID `json:"subject_id" validate:"required"`
+	RequisiteID uuid.UUID `json:"requisite_id" validate:"required"`
+	Kind        string    `json:"kind" validate:"required,oneof=prerequisite corequisite"`
+}
+
+// NewAddRequisiteHandler creates the handler adding a requisite to a subject of the matrix
+// @Summary      Add requisite to matrix subject
+// @Description  Make a subject of the matrix require another one, refusing cycles
+// @Tags         matrix
+// @Accept       json
+// @Produce      json
+// @Param        uuid          path      string               true  "Matrix UUID"
+// @Param        subject_uuid  path      string               true  "Subject UUID"
+// @Param        requisite     body      addRequisiteRequest  true  "Add Requisite"
+// @Success      200      {object}  requisiteResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /matrices/{uuid}/subjects/{subject_uuid}/requisites [post]
+func NewAddRequisiteHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeAddRequisiteEndpoint(s),
+		decodeAddRequisiteRequest,
+		encodeAddRequisiteResponse,
+		opts...,
+	)
+}
+
+func makeAddRequisiteEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(addRequisiteRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		r := &domain.Requisite{
+			MatrixID:    req.MatrixID,
+			SubjectID:   req.SubjectID,
+			RequisiteID: req.RequisiteID,
+			Kind:        domain.RequisiteKind(req.Kind),
+		}
+		if err := s.AddRequisite(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return requisiteResponse{
+			SubjectID:   r.SubjectID,
+			RequisiteID: r.RequisiteID,
+			Kind:        string(r.Kind),
+			CreatedAt:   r.CreatedAt,
+		}, nil
+	}
+}
+
+func decodeAddRequisiteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	subjectID, err := pathUUID(r, "subject_uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	var req addRequisiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	req.MatrixID = id
+	req.SubjectID = subjectID
+
+	return req, nil
+}
+
+func encodeAddRequisiteResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
@@ -15,12 +16,30 @@ import (
 )
 
 type addSubjectRequest struct {
-	MatrixID  uuid.UUID `json:"matrix_id" validate:"required"`
-	SubjectID uuid.UUID `json:"subject_id" validate:"required"`
+	MatrixID   uuid.UUID `json:"matrix_id" validate:"required"`
+	SubjectID  uuid.UUID `json:"subject_id" validate:"required"`
+	IsRequired *bool     `json:"is_required"`
 }
 
-type addSubjectResponse struct{}
+type addSubjectResponse struct {
+	MatrixID   uuid.UUID `json:"matrix_id"`
+	SubjectID  uuid.UUID `json:"subject_id"`
+	IsRequired bool      `json:"is_required"`
+}
 
+// NewAddSubjectHandler creates the handler linking a subject to a matrix
+// @Summary      Add subject to matrix
+// @Description  Link an existing subject to the matrix
+// @Tags         matrix
+// @Accept       json
+// @Produce      json
+// @Param        uuid          path      string  true  "Matrix UUID"
+// @Param        subject_uuid  path      string  true  "Subject UUID"
+// @Success      200      {object}  addSubjectResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /matrices/{uuid}/subjects/{subject_uuid} [post]
 func NewAddSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
 	return kithttp.NewServer(
 		makeAddSubjectEndpoint(s),
@@ -43,21 +62,40 @@ func makeAddSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, err
 		}
 
-		ms := &domain.MatrixSubject{MatrixID: req.MatrixID, SubjectID: req.SubjectID}
+		ms := &domain.MatrixSubject{MatrixID: req.MatrixID, SubjectID: req.SubjectID, IsRequired: true}
+		if req.IsRequired != nil {
+			ms.IsRequired = *req.IsRequired
+		}
 		if err := s.AddSubject(ctx, ms); err != nil {
 			return nil, err
 		}
 
-		return addSubjectResponse{}, nil
+		return addSubjectResponse{
+			MatrixID:   ms.MatrixID,
+			SubjectID:  ms.SubjectID,
+			IsRequired: ms.IsRequired,
+		}, nil
 	}
 }
 
 func decodeAddSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	var req addSubjectRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	subjectID, err := pathUUID(r, "subject_uuid")
 	if err != nil {
 		return nil, err
 	}
+
+	var req addSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	req.MatrixID = id
+	req.SubjectID = subjectID
+
 	return req, nil
 }
 
@@ -0,0 +1,98 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type completeSubjectRequest struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id" validate:"required"`
+	SubjectID      uuid.UUID  `json:"subject_id" validate:"required"`
+	Status         string     `json:"status" validate:"required,oneof=in_progress passed failed"`
+	Grade          *float32   `json:"grade" validate:"omitempty,gte=0,lte=100"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+// NewCompleteSubjectHandler creates the handler recording the result of a subscription subject
+// @Summary      Complete subject
+// @Description  Record the status, grade from 0 to 100 and date of a subject taken by the subscribed learner
+// @Tags         progress
+// @Accept       json
+// @Produce      json
+// @Param        uuid          path      string                  true  "Subscription UUID"
+// @Param        subject_uuid  path      string                  true  "Subject UUID"
+// @Param        completion    body      completeSubjectRequest  true  "Complete Subject"
+// @Success      200      {object}  completionResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /subscriptions/{uuid}/completions/{subject_uuid} [put]
+func NewCompleteSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeCompleteSubjectEndpoint(s),
+		decodeCompleteSubjectRequest,
+		encodeCompleteSubjectResponse,
+		opts...,
+	)
+}
+
+func makeCompleteSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(completeSubjectRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		c := domain.Completion{}
+		data, _ := json.Marshal(req)
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, err
+		}
+
+		if err := s.CompleteSubject(ctx, &c); err != nil {
+			return nil, err
+		}
+
+		return newCompletionResponse(c), nil
+	}
+}
+
+func decodeCompleteSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	subjectID, err := pathUUID(r, "subject_uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	var req completeSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	req.SubscriptionID = id
+	req.SubjectID = subjectID
+
+	return req, nil
+}
+
+func encodeCompleteSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,42 @@
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+func TestCompleteSubjectRequest_Grade(t *testing.T) {
+	grade := func(g float32) *float32 { return &g }
+
+	tests := []struct {
+		name    string
+		grade   *float32
+		wantErr bool
+	}{
+		{name: "no grade"},
+		{name: "lowest grade", grade: grade(0)},
+		{name: "highest grade", grade: grade(100)},
+		{name: "negative grade", grade: grade(-1), wantErr: true},
+		{name: "grade out of the scale", grade: grade(1000), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := completeSubjectRequest{
+				SubscriptionID: uuid.New(),
+				SubjectID:      uuid.New(),
+				Status:         "passed",
+				Grade:          tt.grade,
+			}
+			if err := validator.NewValidator().Validate(req); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
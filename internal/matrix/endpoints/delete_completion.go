@@ -0,0 +1,70 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type deleteCompletionRequest struct {
+	SubscriptionID uuid.UUID `json:"subscription_id" validate:"required"`
+	SubjectID      uuid.UUID `json:"subject_id" validate:"required"`
+}
+
+type deleteCompletionResponse struct{}
+
+func NewDeleteCompletionHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeDeleteCompletionEndpoint(s),
+		decodeDeleteCompletionRequest,
+		encodeDeleteCompletionResponse,
+		opts...,
+	)
+}
+
+func makeDeleteCompletionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(deleteCompletionRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		if err := s.DeleteCompletion(ctx, req.SubscriptionID, req.SubjectID); err != nil {
+			return nil, err
+		}
+
+		return deleteCompletionResponse{}, nil
+	}
+}
+
+func decodeDeleteCompletionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	subjectID, err := pathUUID(r, "subject_uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return deleteCompletionRequest{
+		SubscriptionID: id,
+		SubjectID:      subjectID,
+	}, nil
+}
+
+func encodeDeleteCompletionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
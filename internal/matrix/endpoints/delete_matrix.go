@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -43,15 +42,12 @@ func makeDeleteMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 }
 
 func decodeDeleteMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return deleteMatrixRequest{UUID: uid}, nil
+	return deleteMatrixRequest{UUID: id}, nil
 }
 
 func encodeDeleteMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
@@ -8,7 +8,6 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
 )
@@ -42,15 +41,12 @@ func makeDeleteSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 }
 
 func decodeDeleteSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return deleteSubjectRequest{UUID: uid}, nil
+	return deleteSubjectRequest{UUID: id}, nil
 }
 
 func encodeDeleteSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
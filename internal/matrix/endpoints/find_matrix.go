@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/go-kit/kit/endpoint"
 
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type findMatrixRequest struct {
@@ -21,13 +21,16 @@ type findMatrixRequest struct {
 }
 
 type findMatrixResponse struct {
-	UUID        uuid.UUID `json:"uuid"`
-	Code        string    `json:"code,omitempty"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CourseID    uuid.UUID `json:"course_id"`
+	UUID        uuid.UUID  `json:"uuid"`
+	Code        string     `json:"code,omitempty"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Version     int        `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CourseID    uuid.UUID  `json:"course_id"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
 }
 
 func NewFindMatrixHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
@@ -52,6 +55,7 @@ func makeFindMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 		}
 
 		return &findMatrixResponse{
+			Version:     m.Version,
 			UUID:        m.UUID,
 			Code:        m.Code,
 			Name:        m.Name,
@@ -59,22 +63,25 @@ func makeFindMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			CreatedAt:   m.CreatedAt,
 			UpdatedAt:   m.UpdatedAt,
 			CourseID:    m.CourseID,
+			Status:      string(m.Status),
+			PublishedAt: m.PublishedAt,
 		}, nil
 	}
 }
 
 func decodeFindMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return findMatrixRequest{UUID: uid}, nil
+	return findMatrixRequest{UUID: id}, nil
 }
 
 func encodeFindMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(*findMatrixResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
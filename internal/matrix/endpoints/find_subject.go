@@ -9,9 +9,9 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type findSubjectRequest struct {
@@ -25,6 +25,7 @@ type findSubjectResponse struct {
 	Objective string    `json:"objective,omitempty" validate:"max=245"`
 	Credit    float32   `json:"credit,omitempty"`
 	Workload  float32   `json:"workload,omitempty"`
+	Version   int       `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -51,6 +52,7 @@ func makeFindSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 		}
 
 		return &findSubjectResponse{
+			Version:   c.Version,
 			UUID:      c.UUID,
 			Code:      c.Code,
 			Name:      c.Name,
@@ -64,17 +66,18 @@ func makeFindSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 }
 
 func decodeFindSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
-	uid := uuid.MustParse(id)
-
-	return findSubjectRequest{UUID: uid}, nil
+	return findSubjectRequest{UUID: id}, nil
 }
 
 func encodeFindSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(*findSubjectResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
@@ -0,0 +1,237 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	pb "github.com/sumelms/microservice-course/proto/matrix"
+)
+
+// The gRPC handlers reuse the endpoints of the HTTP handlers, only the decoding of the
+// protobuf requests and the encoding of the responses differ.
+
+func NewGRPCCreateMatrixHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeCreateMatrixEndpoint(s), decodeGRPCCreateMatrixRequest, encodeGRPCMatrixResponse, opts...)
+}
+
+func NewGRPCFindMatrixHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeFindMatrixEndpoint(s), decodeGRPCFindMatrixRequest, encodeGRPCMatrixResponse, opts...)
+}
+
+func NewGRPCUpdateMatrixHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeUpdateMatrixEndpoint(s), decodeGRPCUpdateMatrixRequest, encodeGRPCMatrixResponse, opts...)
+}
+
+func NewGRPCDeleteMatrixHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeDeleteMatrixEndpoint(s), decodeGRPCDeleteMatrixRequest,
+		func(context.Context, interface{}) (interface{}, error) {
+			return &pb.DeleteMatrixResponse{}, nil
+		}, opts...)
+}
+
+func NewGRPCCreateSubjectHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeCreateSubjectEndpoint(s), decodeGRPCCreateSubjectRequest, encodeGRPCSubjectResponse, opts...)
+}
+
+func NewGRPCFindSubjectHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeFindSubjectEndpoint(s), decodeGRPCFindSubjectRequest, encodeGRPCSubjectResponse, opts...)
+}
+
+func NewGRPCDeleteSubjectHandler(s domain.ServiceInterface, opts ...kitgrpc.ServerOption) *kitgrpc.Server {
+	return kitgrpc.NewServer(makeDeleteSubjectEndpoint(s), decodeGRPCDeleteSubjectRequest,
+		func(context.Context, interface{}) (interface{}, error) {
+			return &pb.DeleteSubjectResponse{}, nil
+		}, opts...)
+}
+
+func decodeGRPCCreateMatrixRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.CreateMatrixRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	courseID, err := parseUUID("course_id", req.CourseId)
+	if err != nil {
+		return nil, err
+	}
+	return createMatrixRequest{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		CourseID:    courseID,
+	}, nil
+}
+
+func decodeGRPCFindMatrixRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.FindMatrixRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return findMatrixRequest{UUID: id}, nil
+}
+
+func decodeGRPCUpdateMatrixRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.UpdateMatrixRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	courseID, err := parseUUID("course_id", req.CourseId)
+	if err != nil {
+		return nil, err
+	}
+	return updateMatrixRequest{
+		UUID:        id,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		CourseID:    courseID,
+	}, nil
+}
+
+func decodeGRPCDeleteMatrixRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.DeleteMatrixRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return deleteMatrixRequest{UUID: id}, nil
+}
+
+func decodeGRPCCreateSubjectRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.CreateSubjectRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	return createSubjectRequest{
+		Code:      req.Code,
+		Name:      req.Name,
+		Objective: req.Objective,
+		Credit:    req.Credit,
+		Workload:  req.Workload,
+	}, nil
+}
+
+func decodeGRPCFindSubjectRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.FindSubjectRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return findSubjectRequest{UUID: id}, nil
+}
+
+func decodeGRPCDeleteSubjectRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*pb.DeleteSubjectRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument")
+	}
+	id, err := parseUUID("uuid", req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return deleteSubjectRequest{UUID: id}, nil
+}
+
+func encodeGRPCMatrixResponse(_ context.Context, response interface{}) (interface{}, error) {
+	switch r := response.(type) {
+	case createMatrixResponse:
+		return &pb.Matrix{
+			Uuid:        r.UUID.String(),
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: r.Description,
+			CourseId:    r.CourseID.String(),
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	case *findMatrixResponse:
+		return &pb.Matrix{
+			Uuid:        r.UUID.String(),
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: r.Description,
+			CourseId:    r.CourseID.String(),
+			Status:      r.Status,
+			Version:     int32(r.Version),
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	case updateMatrixResponse:
+		return &pb.Matrix{
+			Uuid:        r.UUID.String(),
+			Code:        r.Code,
+			Name:        r.Name,
+			Description: r.Description,
+			CourseId:    r.CourseID.String(),
+			Version:     int32(r.Version),
+			CreatedAt:   timestamp(r.CreatedAt),
+			UpdatedAt:   timestamp(r.UpdatedAt),
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid response")
+}
+
+func encodeGRPCSubjectResponse(_ context.Context, response interface{}) (interface{}, error) {
+	switch r := response.(type) {
+	case createSubjectResponse:
+		return &pb.Subject{
+			Uuid:      r.UUID.String(),
+			Code:      r.Code,
+			Name:      r.Name,
+			Objective: r.Objective,
+			Credit:    r.Credit,
+			Workload:  r.Workload,
+			CreatedAt: timestamp(r.CreatedAt),
+			UpdatedAt: timestamp(r.UpdatedAt),
+		}, nil
+	case *findSubjectResponse:
+		return &pb.Subject{
+			Uuid:      r.UUID.String(),
+			Code:      r.Code,
+			Name:      r.Name,
+			Objective: r.Objective,
+			Credit:    r.Credit,
+			Workload:  r.Workload,
+			Version:   int32(r.Version),
+			CreatedAt: timestamp(r.CreatedAt),
+			UpdatedAt: timestamp(r.UpdatedAt),
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid response")
+}
+
+func parseUUID(field, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "%s must be a valid UUID", field)
+	}
+	return id, nil
+}
+
+// timestamp leaves the zero time unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
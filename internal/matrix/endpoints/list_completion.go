@@ -0,0 +1,85 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type listCompletionRequest struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+}
+
+type completionResponse struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	SubjectID      uuid.UUID  `json:"subject_id"`
+	Status         string     `json:"status"`
+	Grade          *float32   `json:"grade,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type listCompletionResponse struct {
+	Completions []completionResponse `json:"completions"`
+}
+
+func NewListCompletionHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListCompletionEndpoint(s),
+		decodeListCompletionRequest,
+		encodeListCompletionResponse,
+		opts...,
+	)
+}
+
+func makeListCompletionEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listCompletionRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		completions, err := s.Completions(ctx, req.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]completionResponse, 0, len(completions))
+		for i := range completions {
+			list = append(list, newCompletionResponse(completions[i]))
+		}
+
+		return &listCompletionResponse{Completions: list}, nil
+	}
+}
+
+func newCompletionResponse(c domain.Completion) completionResponse {
+	return completionResponse{
+		SubscriptionID: c.SubscriptionID,
+		SubjectID:      c.SubjectID,
+		Status:         string(c.Status),
+		Grade:          c.Grade,
+		CompletedAt:    c.CompletedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
+
+func decodeListCompletionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return listCompletionRequest{SubscriptionID: id}, nil
+}
+
+func encodeListCompletionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -7,17 +7,18 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
-	"github.com/google/uuid"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
 type listMatrixRequest struct {
-	CourseID uuid.UUID `json:"course_id,omitempty"`
+	Query pagination.Query
 }
 
 type listMatrixResponse struct {
 	Matrices []findMatrixResponse `json:"matrices"`
+	pagination.Page
 }
 
 func NewListMatrixHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
@@ -36,13 +37,7 @@ func makeListMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, fmt.Errorf("invalid argument")
 		}
 
-		filters := make(map[string]interface{})
-		if req.CourseID != uuid.Nil {
-			filters["course_id"] = req.CourseID
-		}
-
-		// @TODO Implement filters to service -- WIP
-		matrices, err := s.Matrices(ctx)
+		matrices, page, err := s.Matrices(ctx, req.Query)
 		if err != nil {
 			return nil, err
 		}
@@ -57,18 +52,21 @@ func makeListMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 				CreatedAt:   m.CreatedAt,
 				UpdatedAt:   m.UpdatedAt,
 				CourseID:    m.CourseID,
+				Status:      string(m.Status),
+				PublishedAt: m.PublishedAt,
 			})
 		}
 
-		return &listMatrixResponse{Matrices: list}, nil
+		return &listMatrixResponse{Matrices: list, Page: page}, nil
 	}
 }
 
 func decodeListMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	courseID := r.FormValue("course_id")
-	cuid := uuid.MustParse(courseID)
-
-	return listMatrixRequest{CourseID: cuid}, nil
+	q, err := pagination.ParseQuery(r, domain.MatrixListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listMatrixRequest{Query: q}, nil
 }
 
 func encodeListMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
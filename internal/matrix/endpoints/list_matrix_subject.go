@@ -0,0 +1,85 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type listMatrixSubjectRequest struct {
+	MatrixID uuid.UUID `json:"matrix_id"`
+}
+
+type matrixSubjectResponse struct {
+	findSubjectResponse
+	IsRequired bool `json:"is_required"`
+}
+
+type listMatrixSubjectResponse struct {
+	Subjects []matrixSubjectResponse `json:"subjects"`
+}
+
+func NewListMatrixSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListMatrixSubjectEndpoint(s),
+		decodeListMatrixSubjectRequest,
+		encodeListMatrixSubjectResponse,
+		opts...,
+	)
+}
+
+func makeListMatrixSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listMatrixSubjectRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		subjects, err := s.MatrixSubjects(ctx, req.MatrixID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &listMatrixSubjectResponse{Subjects: newMatrixSubjectResponses(subjects)}, nil
+	}
+}
+
+func newMatrixSubjectResponses(subjects []domain.MatrixSubjectDetail) []matrixSubjectResponse {
+	list := make([]matrixSubjectResponse, 0, len(subjects))
+	for i := range subjects {
+		c := subjects[i]
+		list = append(list, matrixSubjectResponse{
+			findSubjectResponse: findSubjectResponse{
+				UUID:      c.UUID,
+				Code:      c.Code,
+				Name:      c.Name,
+				Objective: c.Objective,
+				Credit:    c.Credit,
+				Workload:  c.Workload,
+				CreatedAt: c.CreatedAt,
+				UpdatedAt: c.UpdatedAt,
+			},
+			IsRequired: c.IsRequired,
+		})
+	}
+	return list
+}
+
+func decodeListMatrixSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return listMatrixSubjectRequest{MatrixID: id}, nil
+}
+
+func encodeListMatrixSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
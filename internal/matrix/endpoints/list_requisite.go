@@ -0,0 +1,78 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type listRequisiteRequest struct {
+	MatrixID uuid.UUID `json:"matrix_id"`
+}
+
+type requisiteResponse struct {
+	SubjectID   uuid.UUID `json:"subject_id"`
+	RequisiteID uuid.UUID `json:"requisite_id"`
+	Kind        string    `json:"kind"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type listRequisiteResponse struct {
+	Requisites []requisiteResponse `json:"requisites"`
+}
+
+func NewListRequisiteHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListRequisiteEndpoint(s),
+		decodeListRequisiteRequest,
+		encodeListRequisiteResponse,
+		opts...,
+	)
+}
+
+func makeListRequisiteEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listRequisiteRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		requisites, err := s.Requisites(ctx, req.MatrixID)
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]requisiteResponse, 0, len(requisites))
+		for i := range requisites {
+			r := requisites[i]
+			list = append(list, requisiteResponse{
+				SubjectID:   r.SubjectID,
+				RequisiteID: r.RequisiteID,
+				Kind:        string(r.Kind),
+				CreatedAt:   r.CreatedAt,
+			})
+		}
+
+		return &listRequisiteResponse{Requisites: list}, nil
+	}
+}
+
+func decodeListRequisiteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return listRequisiteRequest{MatrixID: id}, nil
+}
+
+func encodeListRequisiteResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -2,16 +2,23 @@ package endpoints
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
 )
 
+type listSubjectRequest struct {
+	Query pagination.Query
+}
+
 type listSubjectResponse struct {
 	Subjects []findSubjectResponse `json:"subjects"`
+	pagination.Page
 }
 
 func NewListSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
@@ -25,7 +32,12 @@ func NewListSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOpti
 
 func makeListSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		cc, err := s.Subjects(ctx)
+		req, ok := request.(listSubjectRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		cc, page, err := s.Subjects(ctx, req.Query)
 		if err != nil {
 			return nil, err
 		}
@@ -45,12 +57,16 @@ func makeListSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			})
 		}
 
-		return &listSubjectResponse{Subjects: list}, nil
+		return &listSubjectResponse{Subjects: list, Page: page}, nil
 	}
 }
 
-func decodeListSubjectRequest(_ context.Context, _ *http.Request) (interface{}, error) {
-	return nil, nil
+func decodeListSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := pagination.ParseQuery(r, domain.SubjectListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listSubjectRequest{Query: q}, nil
 }
 
 func encodeListSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/patch"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type patchSubjectRequest struct {
+	UUID  uuid.UUID       `json:"uuid" validate:"required"`
+	Patch json.RawMessage `json:"patch" validate:"required"`
+}
+
+func NewPatchSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makePatchSubjectEndpoint(s),
+		decodePatchSubjectRequest,
+		encodeUpdateSubjectResponse,
+		opts...,
+	)
+}
+
+//nolint:dupl
+func makePatchSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(patchSubjectRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		subject, err := s.Subject(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if err := etag.Check(ctx, subject.Version); err != nil {
+			return nil, err
+		}
+
+		// The patch is merged into the fields the update accepts, so it is validated the same way
+		var update updateSubjectRequest
+		data, _ := json.Marshal(subject)
+		if err := json.Unmarshal(data, &update); err != nil {
+			return nil, err
+		}
+		fields, err := patch.Apply(&update, req.Patch)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			return newUpdateSubjectResponse(subject), nil
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(update); err != nil {
+			return nil, err
+		}
+
+		data, _ = json.Marshal(update)
+		if err := json.Unmarshal(data, &subject); err != nil {
+			return nil, err
+		}
+		subject.UUID = req.UUID
+
+		if err := s.PatchSubject(ctx, &subject, fields); err != nil {
+			return nil, err
+		}
+
+		return newUpdateSubjectResponse(subject), nil
+	}
+}
+
+func decodePatchSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return patchSubjectRequest{UUID: id, Patch: data}, nil
+}
@@ -0,0 +1,82 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type progressRequest struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+}
+
+type progressResponse struct {
+	SubscriptionID    uuid.UUID               `json:"subscription_id"`
+	MatrixID          uuid.UUID               `json:"matrix_id"`
+	CreditsEarned     float32                 `json:"credits_earned"`
+	CreditsNeeded     float32                 `json:"credits_needed"`
+	RemainingRequired []matrixSubjectResponse `json:"remaining_required"`
+	Eligible          bool                    `json:"eligible"`
+}
+
+// NewProgressHandler creates the handler for the curriculum progress of a subscription
+// @Summary      Subscription progress
+// @Description  Compare the subjects completed by the learner with the subscription matrix
+// @Tags         progress
+// @Produce      json
+// @Param        uuid  path      string  true  "Subscription UUID"
+// @Success      200      {object}  progressResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /subscriptions/{uuid}/progress [get]
+func NewProgressHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeProgressEndpoint(s),
+		decodeProgressRequest,
+		encodeProgressResponse,
+		opts...,
+	)
+}
+
+func makeProgressEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(progressRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		p, err := s.Progress(ctx, req.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &progressResponse{
+			SubscriptionID:    p.SubscriptionID,
+			MatrixID:          p.MatrixID,
+			CreditsEarned:     p.CreditsEarned,
+			CreditsNeeded:     p.CreditsNeeded,
+			RemainingRequired: newMatrixSubjectResponses(p.RemainingRequired),
+			Eligible:          p.Eligible,
+		}, nil
+	}
+}
+
+func decodeProgressRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return progressRequest{SubscriptionID: id}, nil
+}
+
+func encodeProgressResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,65 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type publishMatrixRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+func NewPublishMatrixHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makePublishMatrixEndpoint(s),
+		decodePublishMatrixRequest,
+		encodePublishMatrixResponse,
+		opts...,
+	)
+}
+
+func makePublishMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(publishMatrixRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		m, err := s.PublishMatrix(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &findMatrixResponse{
+			UUID:        m.UUID,
+			Code:        m.Code,
+			Name:        m.Name,
+			Description: m.Description,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+			CourseID:    m.CourseID,
+			Status:      string(m.Status),
+			PublishedAt: m.PublishedAt,
+		}, nil
+	}
+}
+
+func decodePublishMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return publishMatrixRequest{UUID: id}, nil
+}
+
+func encodePublishMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
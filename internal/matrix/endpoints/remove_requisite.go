@@ -0,0 +1,76 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type removeRequisiteRequest struct {
+	MatrixID    uuid.UUID `json:"matrix_id" validate:"required"`
+	SubjectID   uuid.UUID `json:"subject_id" validate:"required"`
+	RequisiteID uuid.UUID `json:"requisite_id" validate:"required"`
+}
+
+type removeRequisiteResponse struct{}
+
+func NewRemoveRequisiteHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRemoveRequisiteEndpoint(s),
+		decodeRemoveRequisiteRequest,
+		encodeRemoveRequisiteResponse,
+		opts...,
+	)
+}
+
+func makeRemoveRequisiteEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(removeRequisiteRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		if err := s.RemoveRequisite(ctx, req.MatrixID, req.SubjectID, req.RequisiteID); err != nil {
+			return nil, err
+		}
+
+		return removeRequisiteResponse{}, nil
+	}
+}
+
+func decodeRemoveRequisiteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	subjectID, err := pathUUID(r, "subject_uuid")
+	if err != nil {
+		return nil, err
+	}
+	requisiteID, err := pathUUID(r, "requisite_uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return removeRequisiteRequest{
+		MatrixID:    id,
+		SubjectID:   subjectID,
+		RequisiteID: requisiteID,
+	}, nil
+}
+
+func encodeRemoveRequisiteResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -2,7 +2,6 @@ package endpoints
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -51,12 +50,19 @@ func makeRemoveSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 }
 
 func decodeRemoveSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	var req removeSubjectRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	id, err := pathUUID(r, "uuid")
 	if err != nil {
 		return nil, err
 	}
-	return req, nil
+	subjectID, err := pathUUID(r, "subject_uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return removeSubjectRequest{
+		MatrixID:  id,
+		SubjectID: subjectID,
+	}, nil
 }
 
 func encodeRemoveSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
@@ -0,0 +1,65 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type restoreMatrixRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+func NewRestoreMatrixHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRestoreMatrixEndpoint(s),
+		decodeRestoreMatrixRequest,
+		encodeRestoreMatrixResponse,
+		opts...,
+	)
+}
+
+func makeRestoreMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(restoreMatrixRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		m, err := s.RestoreMatrix(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &findMatrixResponse{
+			UUID:        m.UUID,
+			Code:        m.Code,
+			Name:        m.Name,
+			Description: m.Description,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+			CourseID:    m.CourseID,
+			Status:      string(m.Status),
+			PublishedAt: m.PublishedAt,
+		}, nil
+	}
+}
+
+func decodeRestoreMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreMatrixRequest{UUID: id}, nil
+}
+
+func encodeRestoreMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
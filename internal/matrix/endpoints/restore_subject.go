@@ -0,0 +1,64 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type restoreSubjectRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+func NewRestoreSubjectHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRestoreSubjectEndpoint(s),
+		decodeRestoreSubjectRequest,
+		encodeRestoreSubjectResponse,
+		opts...,
+	)
+}
+
+func makeRestoreSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(restoreSubjectRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		c, err := s.RestoreSubject(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &findSubjectResponse{
+			UUID:      c.UUID,
+			Code:      c.Code,
+			Name:      c.Name,
+			Objective: c.Objective,
+			Credit:    c.Credit,
+			Workload:  c.Workload,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}, nil
+	}
+}
+
+func decodeRestoreSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreSubjectRequest{UUID: id}, nil
+}
+
+func encodeRestoreSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,83 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type studyPlanRequest struct {
+	MatrixID uuid.UUID `json:"matrix_id"`
+}
+
+type studyTermResponse struct {
+	Term     int                     `json:"term"`
+	Subjects []matrixSubjectResponse `json:"subjects"`
+}
+
+type studyPlanResponse struct {
+	Terms []studyTermResponse `json:"terms"`
+}
+
+// NewStudyPlanHandler creates the handler for the study plan of a matrix
+// @Summary      Matrix study plan
+// @Description  List the subjects of the matrix in terms ordered by their requisites
+// @Tags         matrix
+// @Produce      json
+// @Param        uuid  path      string  true  "Matrix UUID"
+// @Success      200      {object}  studyPlanResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /matrices/{uuid}/study-plan [get]
+func NewStudyPlanHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeStudyPlanEndpoint(s),
+		decodeStudyPlanRequest,
+		encodeStudyPlanResponse,
+		opts...,
+	)
+}
+
+func makeStudyPlanEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(studyPlanRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		plan, err := s.StudyPlan(ctx, req.MatrixID)
+		if err != nil {
+			return nil, err
+		}
+
+		terms := make([]studyTermResponse, 0, len(plan))
+		for _, t := range plan {
+			terms = append(terms, studyTermResponse{
+				Term:     t.Term,
+				Subjects: newMatrixSubjectResponses(t.Subjects),
+			})
+		}
+
+		return &studyPlanResponse{Terms: terms}, nil
+	}
+}
+
+func decodeStudyPlanRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return studyPlanRequest{MatrixID: id}, nil
+}
+
+func encodeStudyPlanResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
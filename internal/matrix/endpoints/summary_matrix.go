@@ -0,0 +1,97 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+)
+
+type summaryMatrixRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+type violationResponse struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type summaryMatrixResponse struct {
+	Subjects         int                 `json:"subjects"`
+	TotalCredits     float32             `json:"total_credits"`
+	RequiredCredits  float32             `json:"required_credits"`
+	ElectiveCredits  float32             `json:"elective_credits"`
+	TotalWorkload    float32             `json:"total_workload"`
+	RequiredWorkload float32             `json:"required_workload"`
+	ElectiveWorkload float32             `json:"elective_workload"`
+	Publishable      bool                `json:"publishable"`
+	Violations       []violationResponse `json:"violations"`
+}
+
+// NewSummaryMatrixHandler creates the handler for the matrix summary
+// @Summary      Matrix summary
+// @Description  Sum up the credits and workload of the matrix and report the rules it breaks
+// @Tags         matrix
+// @Produce      json
+// @Param        uuid  path      string  true  "Matrix UUID"
+// @Success      200      {object}  summaryMatrixResponse
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /matrices/{uuid}/summary [get]
+func NewSummaryMatrixHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeSummaryMatrixEndpoint(s),
+		decodeSummaryMatrixRequest,
+		encodeSummaryMatrixResponse,
+		opts...,
+	)
+}
+
+func makeSummaryMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(summaryMatrixRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		summary, err := s.MatrixSummary(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		violations := make([]violationResponse, 0, len(summary.Violations))
+		for _, v := range summary.Violations {
+			violations = append(violations, violationResponse{Rule: v.Rule, Message: v.Message})
+		}
+
+		return &summaryMatrixResponse{
+			Subjects:         summary.Subjects,
+			TotalCredits:     summary.TotalCredits,
+			RequiredCredits:  summary.RequiredCredits,
+			ElectiveCredits:  summary.ElectiveCredits,
+			TotalWorkload:    summary.TotalWorkload,
+			RequiredWorkload: summary.RequiredWorkload,
+			ElectiveWorkload: summary.ElectiveWorkload,
+			Publishable:      len(violations) == 0,
+			Violations:       violations,
+		}, nil
+	}
+}
+
+func decodeSummaryMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	return summaryMatrixRequest{UUID: id}, nil
+}
+
+func encodeSummaryMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/go-kit/kit/endpoint"
 
@@ -17,6 +16,7 @@ import (
 	kithttp "github.com/go-kit/kit/transport/http"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 )
 
 type updateMatrixRequest struct {
@@ -32,6 +32,7 @@ type updateMatrixResponse struct {
 	Code        string    `json:"code,omitempty"`
 	Name        string    `json:"name"`
 	Description string    `json:"description,omitempty"`
+	Version     int       `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	CourseID    uuid.UUID `json:"course_id"`
@@ -69,35 +70,42 @@ func makeUpdateMatrixEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return updateMatrixResponse{
-			UUID:        m.UUID,
-			Name:        m.Name,
-			Description: m.Description,
-			CreatedAt:   m.CreatedAt,
-			UpdatedAt:   m.UpdatedAt,
-			CourseID:    m.CourseID,
-		}, nil
+		return newUpdateMatrixResponse(m), nil
+	}
+}
+
+func newUpdateMatrixResponse(m domain.Matrix) updateMatrixResponse {
+	return updateMatrixResponse{
+		Version:     m.Version,
+		UUID:        m.UUID,
+		Name:        m.Name,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		CourseID:    m.CourseID,
 	}
 }
 
 func decodeUpdateMatrixRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
 	var req updateMatrixRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, err
 	}
 
-	req.UUID = uuid.MustParse(id)
+	req.UUID = id
 
 	return req, nil
 }
 
 func encodeUpdateMatrixResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(updateMatrixResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
@@ -10,9 +10,9 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/google/uuid"
-	"github.com/gorilla/mux"
 
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
 	"github.com/sumelms/microservice-course/pkg/validator"
 )
 
@@ -32,6 +32,7 @@ type updateSubjectResponse struct {
 	Objective string    `json:"objective,omitempty" validate:"max=245"`
 	Credit    float32   `json:"credit,omitempty"`
 	Workload  float32   `json:"workload,omitempty"`
+	Version   int       `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -68,37 +69,44 @@ func makeUpdateSubjectEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return updateSubjectResponse{
-			UUID:      c.UUID,
-			Code:      c.Code,
-			Name:      c.Name,
-			Objective: c.Objective,
-			Credit:    c.Credit,
-			Workload:  c.Workload,
-			CreatedAt: c.CreatedAt,
-			UpdatedAt: c.UpdatedAt,
-		}, nil
+		return newUpdateSubjectResponse(c), nil
+	}
+}
+
+func newUpdateSubjectResponse(c domain.Subject) updateSubjectResponse {
+	return updateSubjectResponse{
+		Version:   c.Version,
+		UUID:      c.UUID,
+		Code:      c.Code,
+		Name:      c.Name,
+		Objective: c.Objective,
+		Credit:    c.Credit,
+		Workload:  c.Workload,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
 	}
 }
 
 func decodeUpdateSubjectRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	vars := mux.Vars(r)
-	id, ok := vars["uuid"]
-	if !ok {
-		return nil, fmt.Errorf("invalid argument")
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
 	}
 
 	var req updateSubjectRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, err
 	}
 
-	req.UUID = uuid.MustParse(id)
+	req.UUID = id
 
 	return req, nil
 }
 
 func encodeUpdateSubjectResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
-	return kithttp.EncodeJSONResponse(ctx, w, response)
+	r, ok := response.(updateSubjectResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
 }
@@ -4,13 +4,16 @@ import (
 	"github.com/go-kit/log"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
 
 	"github.com/sumelms/microservice-course/internal/matrix/database"
 	"github.com/sumelms/microservice-course/internal/matrix/domain"
 	"github.com/sumelms/microservice-course/internal/matrix/transport"
+	"github.com/sumelms/microservice-course/pkg/config"
+	pb "github.com/sumelms/microservice-course/proto/matrix"
 )
 
-func NewService(db *sqlx.DB, logger log.Logger, course domain.CourseClient) (*domain.Service, error) {
+func NewService(db *sqlx.DB, logger log.Logger, course domain.CourseClient, rules *config.MatrixRules) (*domain.Service, error) {
 	matrix, err := database.NewMatrixRepository(db)
 	if err != nil {
 		return nil, err
@@ -19,12 +22,29 @@ func NewService(db *sqlx.DB, logger log.Logger, course domain.CourseClient) (*do
 	if err != nil {
 		return nil, err
 	}
+	completion, err := database.NewCompletionRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var matrixRules domain.MatrixRules
+	if rules != nil {
+		matrixRules = domain.MatrixRules{
+			MinCredits:         rules.MinCredits,
+			MaxCredits:         rules.MaxCredits,
+			MinRequiredCredits: rules.MinRequiredCredits,
+			MaxTermCredits:     rules.MaxTermCredits,
+			MaxTermWorkload:    rules.MaxTermWorkload,
+		}
+	}
 
 	service, err := domain.NewService(
 		domain.WithLogger(logger),
 		domain.WithMatrixRepository(matrix),
 		domain.WithSubjectRepository(subject),
-		domain.WithCourseClient(course))
+		domain.WithCompletionRepository(completion),
+		domain.WithCourseClient(course),
+		domain.WithMatrixRules(matrixRules))
 	if err != nil {
 		return nil, err
 	}
@@ -35,3 +55,8 @@ func NewHTTPService(router *mux.Router, service domain.ServiceInterface, logger
 	transport.NewHTTPHandler(router, service, logger)
 	return nil
 }
+
+func NewGRPCService(server *grpc.Server, service domain.ServiceInterface, logger log.Logger) error {
+	pb.RegisterMatrixServiceServer(server, transport.NewGRPCServer(service, logger))
+	return nil
+}
@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"context"
+
+	kittransport "github.com/go-kit/kit/transport"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-kit/log"
+
+	"github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/internal/matrix/endpoints"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	pb "github.com/sumelms/microservice-course/proto/matrix"
+)
+
+type grpcServer struct {
+	pb.UnimplementedMatrixServiceServer
+
+	createMatrix  kitgrpc.Handler
+	findMatrix    kitgrpc.Handler
+	updateMatrix  kitgrpc.Handler
+	deleteMatrix  kitgrpc.Handler
+	createSubject kitgrpc.Handler
+	findSubject   kitgrpc.Handler
+	deleteSubject kitgrpc.Handler
+}
+
+func NewGRPCServer(s domain.ServiceInterface, logger log.Logger) pb.MatrixServiceServer {
+	opts := []kitgrpc.ServerOption{
+		kitgrpc.ServerBefore(tracing.GRPCToContext),
+		kitgrpc.ServerFinalizer(tracing.GRPCFinalizer),
+		kitgrpc.ServerErrorHandler(kittransport.NewLogErrorHandler(logger)),
+	}
+
+	return &grpcServer{
+		createMatrix:  endpoints.NewGRPCCreateMatrixHandler(s, opts...),
+		findMatrix:    endpoints.NewGRPCFindMatrixHandler(s, opts...),
+		updateMatrix:  endpoints.NewGRPCUpdateMatrixHandler(s, opts...),
+		deleteMatrix:  endpoints.NewGRPCDeleteMatrixHandler(s, opts...),
+		createSubject: endpoints.NewGRPCCreateSubjectHandler(s, opts...),
+		findSubject:   endpoints.NewGRPCFindSubjectHandler(s, opts...),
+		deleteSubject: endpoints.NewGRPCDeleteSubjectHandler(s, opts...),
+	}
+}
+
+func (s *grpcServer) CreateMatrix(ctx context.Context, req *pb.CreateMatrixRequest) (*pb.Matrix, error) {
+	_, resp, err := s.createMatrix.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Matrix), nil
+}
+
+func (s *grpcServer) FindMatrix(ctx context.Context, req *pb.FindMatrixRequest) (*pb.Matrix, error) {
+	_, resp, err := s.findMatrix.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Matrix), nil
+}
+
+func (s *grpcServer) UpdateMatrix(ctx context.Context, req *pb.UpdateMatrixRequest) (*pb.Matrix, error) {
+	_, resp, err := s.updateMatrix.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Matrix), nil
+}
+
+func (s *grpcServer) DeleteMatrix(ctx context.Context, req *pb.DeleteMatrixRequest) (*pb.DeleteMatrixResponse, error) {
+	_, resp, err := s.deleteMatrix.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeleteMatrixResponse), nil
+}
+
+func (s *grpcServer) CreateSubject(ctx context.Context, req *pb.CreateSubjectRequest) (*pb.Subject, error) {
+	_, resp, err := s.createSubject.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Subject), nil
+}
+
+func (s *grpcServer) FindSubject(ctx context.Context, req *pb.FindSubjectRequest) (*pb.Subject, error) {
+	_, resp, err := s.findSubject.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.Subject), nil
+}
+
+func (s *grpcServer) DeleteSubject(ctx context.Context,
+	req *pb.DeleteSubjectRequest) (*pb.DeleteSubjectResponse, error) {
+	_, resp, err := s.deleteSubject.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeleteSubjectResponse), nil
+}
@@ -5,6 +5,10 @@ import (
 
 	"github.com/sumelms/microservice-course/internal/matrix/endpoints"
 	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/metrics"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	"github.com/sumelms/microservice-course/pkg/validator"
 
 	kittransport "github.com/go-kit/kit/transport"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -16,31 +20,75 @@ import (
 
 func NewHTTPHandler(r *mux.Router, s domain.ServiceInterface, logger log.Logger) {
 	opts := []kithttp.ServerOption{
+		kithttp.ServerBefore(tracing.HTTPToContext, kithttp.PopulateRequestContext, validator.PopulateLanguage,
+			etag.PopulatePreconditions),
+		kithttp.ServerFinalizer(tracing.HTTPFinalizer),
 		kithttp.ServerErrorHandler(kittransport.NewLogErrorHandler(logger)),
 		kithttp.ServerErrorEncoder(errors.EncodeError),
 	}
 
-	listMatrixHandler := endpoints.NewListMatrixHandler(s, opts...)
-	createMatrixHandler := endpoints.NewCreateMatrixHandler(s, opts...)
-	findMatrixHandler := endpoints.NewFindMatrixHandler(s, opts...)
-	updateMatrixHandler := endpoints.NewUpdateMatrixHandler(s, opts...)
-	deleteMatrixHandler := endpoints.NewDeleteMatrixHandler(s, opts...)
+	listMatrixHandler := metrics.NewHTTPHandler(endpoints.NewListMatrixHandler(s, opts...))
+	createMatrixHandler := metrics.NewHTTPHandler(endpoints.NewCreateMatrixHandler(s, opts...))
+	findMatrixHandler := metrics.NewHTTPHandler(endpoints.NewFindMatrixHandler(s, opts...))
+	updateMatrixHandler := metrics.NewHTTPHandler(endpoints.NewUpdateMatrixHandler(s, opts...))
+	patchMatrixHandler := metrics.NewHTTPHandler(endpoints.NewPatchMatrixHandler(s, opts...))
+	deleteMatrixHandler := metrics.NewHTTPHandler(endpoints.NewDeleteMatrixHandler(s, opts...))
+	restoreMatrixHandler := metrics.NewHTTPHandler(endpoints.NewRestoreMatrixHandler(s, opts...))
+	summaryMatrixHandler := metrics.NewHTTPHandler(endpoints.NewSummaryMatrixHandler(s, opts...))
+	publishMatrixHandler := metrics.NewHTTPHandler(endpoints.NewPublishMatrixHandler(s, opts...))
 
 	r.Handle("/matrices", listMatrixHandler).Methods(http.MethodGet)
 	r.Handle("/matrices", createMatrixHandler).Methods(http.MethodPost)
 	r.Handle("/matrices/{uuid}", findMatrixHandler).Methods(http.MethodGet)
 	r.Handle("/matrices/{uuid}", updateMatrixHandler).Methods(http.MethodPut)
+	r.Handle("/matrices/{uuid}", patchMatrixHandler).Methods(http.MethodPatch)
 	r.Handle("/matrices/{uuid}", deleteMatrixHandler).Methods(http.MethodDelete)
+	r.Handle("/matrices/{uuid}/restore", restoreMatrixHandler).Methods(http.MethodPost)
+	r.Handle("/matrices/{uuid}/summary", summaryMatrixHandler).Methods(http.MethodGet)
+	r.Handle("/matrices/{uuid}/publish", publishMatrixHandler).Methods(http.MethodPost)
 
-	listSubjectHandler := endpoints.NewListSubjectHandler(s, opts...)
-	createSubjectHandler := endpoints.NewCreateSubjectHandler(s, opts...)
-	findSubjectHandler := endpoints.NewFindSubjectHandler(s, opts...)
-	updateSubjectHandler := endpoints.NewUpdateSubjectHandler(s, opts...)
-	deleteSubjectHandler := endpoints.NewDeleteSubjectHandler(s, opts...)
+	listMatrixSubjectHandler := metrics.NewHTTPHandler(endpoints.NewListMatrixSubjectHandler(s, opts...))
+	addSubjectHandler := metrics.NewHTTPHandler(endpoints.NewAddSubjectHandler(s, opts...))
+	removeSubjectHandler := metrics.NewHTTPHandler(endpoints.NewRemoveSubjectHandler(s, opts...))
+
+	r.Handle("/matrices/{uuid}/subjects", listMatrixSubjectHandler).Methods(http.MethodGet)
+	r.Handle("/matrices/{uuid}/subjects/{subject_uuid}", addSubjectHandler).Methods(http.MethodPost)
+	r.Handle("/matrices/{uuid}/subjects/{subject_uuid}", removeSubjectHandler).Methods(http.MethodDelete)
+
+	listRequisiteHandler := metrics.NewHTTPHandler(endpoints.NewListRequisiteHandler(s, opts...))
+	addRequisiteHandler := metrics.NewHTTPHandler(endpoints.NewAddRequisiteHandler(s, opts...))
+	removeRequisiteHandler := metrics.NewHTTPHandler(endpoints.NewRemoveRequisiteHandler(s, opts...))
+	studyPlanHandler := metrics.NewHTTPHandler(endpoints.NewStudyPlanHandler(s, opts...))
+
+	r.Handle("/matrices/{uuid}/requisites", listRequisiteHandler).Methods(http.MethodGet)
+	r.Handle("/matrices/{uuid}/subjects/{subject_uuid}/requisites", addRequisiteHandler).Methods(http.MethodPost)
+	r.Handle("/matrices/{uuid}/subjects/{subject_uuid}/requisites/{requisite_uuid}",
+		removeRequisiteHandler).Methods(http.MethodDelete)
+	r.Handle("/matrices/{uuid}/study-plan", studyPlanHandler).Methods(http.MethodGet)
+
+	listSubjectHandler := metrics.NewHTTPHandler(endpoints.NewListSubjectHandler(s, opts...))
+	createSubjectHandler := metrics.NewHTTPHandler(endpoints.NewCreateSubjectHandler(s, opts...))
+	findSubjectHandler := metrics.NewHTTPHandler(endpoints.NewFindSubjectHandler(s, opts...))
+	updateSubjectHandler := metrics.NewHTTPHandler(endpoints.NewUpdateSubjectHandler(s, opts...))
+	patchSubjectHandler := metrics.NewHTTPHandler(endpoints.NewPatchSubjectHandler(s, opts...))
+	deleteSubjectHandler := metrics.NewHTTPHandler(endpoints.NewDeleteSubjectHandler(s, opts...))
+	restoreSubjectHandler := metrics.NewHTTPHandler(endpoints.NewRestoreSubjectHandler(s, opts...))
 
 	r.Handle("/subjects", createSubjectHandler).Methods(http.MethodPost)
 	r.Handle("/subjects", listSubjectHandler).Methods(http.MethodGet)
 	r.Handle("/subjects/{uuid}", findSubjectHandler).Methods(http.MethodGet)
 	r.Handle("/subjects/{uuid}", updateSubjectHandler).Methods(http.MethodPut)
+	r.Handle("/subjects/{uuid}", patchSubjectHandler).Methods(http.MethodPatch)
 	r.Handle("/subjects/{uuid}", deleteSubjectHandler).Methods(http.MethodDelete)
+	r.Handle("/subjects/{uuid}/restore", restoreSubjectHandler).Methods(http.MethodPost)
+
+	listCompletionHandler := metrics.NewHTTPHandler(endpoints.NewListCompletionHandler(s, opts...))
+	completeSubjectHandler := metrics.NewHTTPHandler(endpoints.NewCompleteSubjectHandler(s, opts...))
+	deleteCompletionHandler := metrics.NewHTTPHandler(endpoints.NewDeleteCompletionHandler(s, opts...))
+	progressHandler := metrics.NewHTTPHandler(endpoints.NewProgressHandler(s, opts...))
+
+	r.Handle("/subscriptions/{uuid}/completions", listCompletionHandler).Methods(http.MethodGet)
+	r.Handle("/subscriptions/{uuid}/completions/{subject_uuid}", completeSubjectHandler).Methods(http.MethodPut)
+	r.Handle("/subscriptions/{uuid}/completions/{subject_uuid}", deleteCompletionHandler).Methods(http.MethodDelete)
+	r.Handle("/subscriptions/{uuid}/progress", progressHandler).Methods(http.MethodGet)
 }
@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the timestamp and the body, keyed by the
+	// secret of the webhook
+	SignatureHeader = "X-Sumelms-Signature"
+	TimestampHeader = "X-Sumelms-Timestamp"
+	EventHeader     = "X-Sumelms-Event"
+	DeliveryHeader  = "X-Sumelms-Delivery"
+
+	// maxResponseBody is how much of the response body is kept to explain a failure
+	maxResponseBody = 512
+)
+
+// httpSender posts the deliveries to the URL of their webhook
+type httpSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *httpSender {
+	return &httpSender{client: &http.Client{Timeout: timeout}}
+}
+
+// Sign returns the signature of the body sent at the timestamp. The receivers compute it over
+// the raw body and compare it, they should also reject the stale timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp)) //nolint: errcheck
+	mac.Write([]byte("."))       //nolint: errcheck
+	mac.Write(body)              //nolint: errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the delivery, any response other than a 2xx is a failure
+func (s httpSender) Send(ctx context.Context, w domain.Webhook, d domain.Delivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid webhook url %s", w.URL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sumelms-webhooks")
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, d.Payload))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(EventHeader, d.EventType)
+	req.Header.Set(DeliveryHeader, d.UUID.String())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.WrapErrorf(err, errors.ErrCodeUnavailable, "error sending delivery to %s", w.URL)
+	}
+	defer res.Body.Close() //nolint: errcheck
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, errors.NewErrorf(errors.ErrCodeUnavailable, "webhook responded %d: %s", res.StatusCode, bytes.TrimSpace(body))
+	}
+	return res.StatusCode, nil
+}
@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+)
+
+const secret = "a-very-long-webhook-secret"
+
+// receiver stands in for a partner system, it checks the signature of the deliveries and fails
+// the first ones
+type receiver struct {
+	*httptest.Server
+	failures int
+
+	mu    sync.Mutex
+	calls int
+}
+
+func newReceiver(t *testing.T, failures int) *receiver {
+	t.Helper()
+
+	rc := &receiver{failures: failures}
+	rc.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := Sign(secret, r.Header.Get(TimestampHeader), body)
+		if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(want)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		rc.calls++
+		if rc.calls <= rc.failures {
+			http.Error(w, "try again later", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(rc.Close)
+	return rc
+}
+
+type memoryWebhooks struct {
+	domain.WebhookRepository
+	webhook domain.Webhook
+}
+
+func (r memoryWebhooks) Webhook(context.Context, uuid.UUID) (domain.Webhook, error) {
+	return r.webhook, nil
+}
+
+// memoryDeliveries claims the due deliveries like the database does
+type memoryDeliveries struct {
+	domain.DeliveryRepository
+	delivery domain.Delivery
+}
+
+func (r *memoryDeliveries) ClaimDeliveries(_ context.Context, now time.Time, lease time.Duration, _ int) ([]domain.Delivery, error) {
+	d := r.delivery
+	due := d.Status == domain.DeliveryPending || d.Status == domain.DeliveryFailed
+	if !due || d.NextAttemptAt.After(now) {
+		return nil, nil
+	}
+	r.delivery.NextAttemptAt = now.Add(lease)
+	return []domain.Delivery{d}, nil
+}
+
+func (r *memoryDeliveries) SaveAttempt(_ context.Context, d *domain.Delivery) error {
+	r.delivery = *d
+	return nil
+}
+
+func TestHTTPSender_Send(t *testing.T) {
+	rc := newReceiver(t, 0)
+	d := domain.Delivery{UUID: uuid.New(), EventType: "course.created", Payload: []byte(`{"type":"course.created"}`)}
+
+	tests := []struct {
+		name       string
+		secret     string
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "signed delivery", secret: secret, wantStatus: http.StatusNoContent},
+		{name: "wrong secret", secret: "another-webhook-secret", wantStatus: http.StatusUnauthorized, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		status, err := NewHTTPSender(time.Second).Send(context.Background(), domain.Webhook{URL: rc.URL, Secret: tt.secret}, d)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Send() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if status != tt.wantStatus {
+			t.Errorf("%s: Send() status = %d, want %d", tt.name, status, tt.wantStatus)
+		}
+	}
+}
+
+func TestHTTPSender_Dispatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		wantStatus   domain.DeliveryStatus
+		wantAttempts int
+	}{
+		{name: "delivered at once", failures: 0, wantStatus: domain.DeliverySucceeded, wantAttempts: 1},
+		{name: "delivered after retries", failures: 2, wantStatus: domain.DeliverySucceeded, wantAttempts: 3},
+		{name: "dead after the max attempts", failures: 5, wantStatus: domain.DeliveryDead, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rc := newReceiver(t, tt.failures)
+			w := domain.Webhook{UUID: uuid.New(), URL: rc.URL, Secret: secret, Active: true}
+			deliveries := &memoryDeliveries{delivery: domain.Delivery{
+				ID: 1, UUID: uuid.New(), TenantID: "default", WebhookID: w.UUID, EventType: "course.created",
+				Payload: []byte(`{"type":"course.created"}`), Status: domain.DeliveryPending,
+			}}
+			s, _ := domain.NewService(
+				domain.WithWebhookRepository(memoryWebhooks{webhook: w}),
+				domain.WithDeliveryRepository(deliveries),
+				domain.WithSender(NewHTTPSender(time.Second)),
+				domain.WithRetryPolicy(domain.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Lease: time.Minute, BatchSize: 10}))
+
+			// Every round waits out the backoff of the previous failure
+			for i := 0; i < 5; i++ {
+				if _, err := s.Dispatch(context.Background()); err != nil {
+					t.Fatalf("Dispatch() error = %v", err)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			d := deliveries.delivery
+			if d.Status != tt.wantStatus || d.Attempts != tt.wantAttempts {
+				t.Errorf("delivery is %s after %d attempts, want %s after %d", d.Status, d.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if rc.calls != tt.wantAttempts {
+				t.Errorf("receiver got %d calls, want %d", rc.calls, tt.wantAttempts)
+			}
+			if d.Status == domain.DeliveryDead && (d.ResponseStatus == nil || *d.ResponseStatus != http.StatusServiceUnavailable) {
+				t.Errorf("delivery response status = %v, want the last one", d.ResponseStatus)
+			}
+		})
+	}
+}
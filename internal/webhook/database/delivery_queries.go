@@ -0,0 +1,37 @@
+package database
+
+const (
+	createDelivery  = "create delivery"
+	getDelivery     = "get delivery by uuid"
+	claimDeliveries = "claim due deliveries"
+	saveAttempt     = "save delivery attempt"
+	retryDelivery   = "retry delivery by uuid"
+	purgeDeliveries = "purge finished deliveries"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listDeliveries = "list deliveries of webhook"
+
+	deliveriesTable = "webhook_deliveries"
+)
+
+func queriesDelivery() map[string]string {
+	return map[string]string{
+		// The relay may publish an event more than once, it is delivered once to each webhook
+		createDelivery: `INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		getDelivery: "SELECT * FROM webhook_deliveries WHERE uuid = $1 AND webhook_id = $2 AND tenant_id = $3",
+		// The claimed deliveries are held until the lease ends, other dispatchers skip them
+		claimDeliveries: `UPDATE webhook_deliveries SET next_attempt_at = $2 
+			WHERE id IN (SELECT id FROM webhook_deliveries 
+				WHERE status IN ('pending', 'failed') AND next_attempt_at <= $1 
+				ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING *`,
+		saveAttempt: `UPDATE webhook_deliveries 
+			SET status = $1, attempts = $2, next_attempt_at = $3, response_status = $4, last_error = $5, delivered_at = $6, 
+			updated_at = NOW() 
+			WHERE id = $7`,
+		retryDelivery: `UPDATE webhook_deliveries 
+			SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW() 
+			WHERE uuid = $1 AND webhook_id = $2 AND tenant_id = $3 AND status IN ('failed', 'dead') RETURNING *`,
+		purgeDeliveries: "DELETE FROM webhook_deliveries WHERE status IN ('succeeded', 'dead') AND updated_at < $1",
+	}
+}
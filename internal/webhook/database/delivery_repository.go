@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+// NewDeliveryRepository creates the delivery deliveryRepository
+func NewDeliveryRepository(db *sqlx.DB) (deliveryRepository, error) { //nolint: revive
+	sqlStatements := make(map[string]*sqlx.Stmt)
+
+	for queryName, query := range queriesDelivery() {
+		stmt, err := postgres.Prepare(db, queryName, query)
+		if err != nil {
+			return deliveryRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
+		}
+		sqlStatements[queryName] = stmt
+	}
+
+	return deliveryRepository{
+		db:         db,
+		statements: sqlStatements,
+	}, nil
+}
+
+type deliveryRepository struct {
+	db         *sqlx.DB
+	statements map[string]*sqlx.Stmt
+}
+
+func (r deliveryRepository) Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) ([]domain.Delivery, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listDeliveries)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Delivery{}, pagination.Page{}, err
+	}
+
+	// The deliveries are never soft deleted
+	q.IncludeDeleted = true
+	query, args := pagination.Select(deliveriesTable, q, pagination.Condition{
+		Clause: "tenant_id = ? AND webhook_id = ?",
+		Args:   []interface{}{tenantID, webhookID},
+	})
+
+	var ds []domain.Delivery
+	if err := r.db.SelectContext(ctx, &ds, query, args...); err != nil {
+		return []domain.Delivery{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting deliveries")
+	}
+	return pagination.Paginate(q, ds)
+}
+
+// CreateDelivery queues the event for the webhook, an event already queued for it is ignored
+func (r deliveryRepository) CreateDelivery(ctx context.Context, d *domain.Delivery) error {
+	stmt, ok := r.statements[createDelivery]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createDelivery)
+	}
+	ctx, end := postgres.StartStatement(ctx, createDelivery)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := stmt.ExecContext(ctx, d.WebhookID, d.EventID, d.EventType, []byte(d.Payload), tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error creating delivery")
+	}
+	return nil
+}
+
+// ClaimDeliveries leases the due deliveries of every tenant until now plus the lease
+func (r deliveryRepository) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.Delivery, error) {
+	stmt, ok := r.statements[claimDeliveries]
+	if !ok {
+		return []domain.Delivery{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", claimDeliveries)
+	}
+	ctx, end := postgres.StartStatement(ctx, claimDeliveries)
+	defer end()
+
+	var ds []domain.Delivery
+	if err := stmt.SelectContext(ctx, &ds, now, now.Add(lease), limit); err != nil {
+		return []domain.Delivery{}, errors.WrapDatabaseErrorf(err, "error claiming deliveries")
+	}
+	return ds, nil
+}
+
+func (r deliveryRepository) SaveAttempt(ctx context.Context, d *domain.Delivery) error {
+	stmt, ok := r.statements[saveAttempt]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", saveAttempt)
+	}
+	ctx, end := postgres.StartStatement(ctx, saveAttempt)
+	defer end()
+
+	if _, err := stmt.ExecContext(ctx, d.Status, d.Attempts, d.NextAttemptAt, d.ResponseStatus, d.LastError, d.DeliveredAt, d.ID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error saving delivery attempt")
+	}
+	return nil
+}
+
+// RetryDelivery queues again a failed or dead delivery
+func (r deliveryRepository) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (domain.Delivery, error) {
+	stmt, ok := r.statements[retryDelivery]
+	if !ok {
+		return domain.Delivery{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", retryDelivery)
+	}
+	ctx, end := postgres.StartStatement(ctx, retryDelivery)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Delivery{}, err
+	}
+
+	var d domain.Delivery
+	if err := stmt.GetContext(ctx, &d, id, webhookID, tenantID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Delivery{}, r.notRetryable(ctx, webhookID, id)
+		}
+		return domain.Delivery{}, errors.WrapDatabaseErrorf(err, "error retrying delivery")
+	}
+	return d, nil
+}
+
+func (r deliveryRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := r.statements[purgeDeliveries]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeDeliveries)
+	}
+	ctx, end := postgres.StartStatement(ctx, purgeDeliveries)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging deliveries")
+	}
+	return res.RowsAffected()
+}
+
+// notRetryable tells a delivery that is still pending or has succeeded apart from a missing one
+func (r deliveryRepository) notRetryable(ctx context.Context, webhookID, id uuid.UUID) error {
+	stmt, ok := r.statements[getDelivery]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getDelivery)
+	}
+	ctx, end := postgres.StartStatement(ctx, getDelivery)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	var current domain.Delivery
+	if err := stmt.GetContext(ctx, &current, id, webhookID, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error getting delivery")
+	}
+	return errors.NewErrorf(errors.ErrCodeConflict, "delivery %s is %s, only failed or dead deliveries are retried", id, current.Status)
+}
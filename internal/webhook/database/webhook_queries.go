@@ -0,0 +1,33 @@
+package database
+
+const (
+	createWebhook = "create webhook"
+	getWebhook    = "get webhook by uuid"
+	updateWebhook = "update webhook by uuid"
+	deleteWebhook = "delete webhook by uuid"
+	subscribers   = "list webhooks by event type"
+	purgeWebhooks = "purge deleted webhooks"
+
+	// The built queries, named for the spans and the timeouts of their statements
+	listWebhooks = "list webhooks"
+
+	webhooksTable = "webhooks"
+)
+
+func queriesWebhook() map[string]string {
+	return map[string]string{
+		createWebhook: `INSERT INTO webhooks (url, secret, event_types, active, tenant_id) 
+			VALUES ($1, $2, $3, $4, $5) RETURNING *`,
+		getWebhook: "SELECT * FROM webhooks WHERE uuid = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		// An empty secret keeps the current one
+		updateWebhook: `UPDATE webhooks 
+			SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), event_types = $3, active = $4, 
+			version = version + 1, updated_at = NOW() 
+			WHERE uuid = $5 AND tenant_id = $6 AND ($7 = 0 OR version = $7) AND deleted_at IS NULL RETURNING *`,
+		deleteWebhook: `UPDATE webhooks SET deleted_at = NOW(), version = version + 1 
+			WHERE uuid = $1 AND tenant_id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL`,
+		subscribers: `SELECT * FROM webhooks 
+			WHERE tenant_id = $1 AND $2 = ANY(event_types) AND active AND deleted_at IS NULL ORDER BY id`,
+		purgeWebhooks: "DELETE FROM webhooks WHERE deleted_at < $1",
+	}
+}
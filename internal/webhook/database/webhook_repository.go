@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+// NewWebhookRepository creates the webhook webhookRepository
+func NewWebhookRepository(db *sqlx.DB) (webhookRepository, error) { //nolint: revive
+	sqlStatements := make(map[string]*sqlx.Stmt)
+
+	for queryName, query := range queriesWebhook() {
+		stmt, err := postgres.Prepare(db, queryName, query)
+		if err != nil {
+			return webhookRepository{}, errors.WrapDatabaseErrorf(err, "error preparing statement %s", queryName)
+		}
+		sqlStatements[queryName] = stmt
+	}
+
+	return webhookRepository{
+		db:         db,
+		statements: sqlStatements,
+	}, nil
+}
+
+type webhookRepository struct {
+	db         *sqlx.DB
+	statements map[string]*sqlx.Stmt
+}
+
+func (r webhookRepository) Webhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	stmt, ok := r.statements[getWebhook]
+	if !ok {
+		return domain.Webhook{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getWebhook)
+	}
+	ctx, end := postgres.StartStatement(ctx, getWebhook)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	var w domain.Webhook
+	if err := stmt.GetContext(ctx, &w, id, tenantID); err != nil {
+		return domain.Webhook{}, errors.WrapDatabaseErrorf(err, "error getting webhook")
+	}
+	return w, nil
+}
+
+func (r webhookRepository) Webhooks(ctx context.Context, q pagination.Query) ([]domain.Webhook, pagination.Page, error) {
+	ctx, end := postgres.StartStatement(ctx, listWebhooks)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Webhook{}, pagination.Page{}, err
+	}
+
+	query, args := pagination.Select(webhooksTable, q, pagination.Condition{Clause: "tenant_id = ?", Args: []interface{}{tenantID}})
+
+	var ws []domain.Webhook
+	if err := r.db.SelectContext(ctx, &ws, query, args...); err != nil {
+		return []domain.Webhook{}, pagination.Page{}, errors.WrapDatabaseErrorf(err, "error getting webhooks")
+	}
+	return pagination.Paginate(q, ws)
+}
+
+func (r webhookRepository) CreateWebhook(ctx context.Context, w *domain.Webhook) error {
+	stmt, ok := r.statements[createWebhook]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", createWebhook)
+	}
+	ctx, end := postgres.StartStatement(ctx, createWebhook)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stmt.GetContext(ctx, w, w.URL, w.Secret, w.EventTypes, w.Active, tenantID); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error creating webhook")
+	}
+	return nil
+}
+
+func (r webhookRepository) UpdateWebhook(ctx context.Context, w *domain.Webhook) error {
+	stmt, ok := r.statements[updateWebhook]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", updateWebhook)
+	}
+	ctx, end := postgres.StartStatement(ctx, updateWebhook)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	if err := stmt.GetContext(ctx, w, w.URL, w.Secret, w.EventTypes, w.Active, w.UUID, tenantID, expected); err != nil {
+		if err == sql.ErrNoRows && expected != 0 {
+			return r.versionMismatch(ctx, w.UUID)
+		}
+		return errors.WrapDatabaseErrorf(err, "error updating webhook")
+	}
+	return nil
+}
+
+func (r webhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	stmt, ok := r.statements[deleteWebhook]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", deleteWebhook)
+	}
+	ctx, end := postgres.StartStatement(ctx, deleteWebhook)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	expected := etag.Expected(ctx)
+	res, err := stmt.ExecContext(ctx, id, tenantID, expected)
+	if err != nil {
+		return errors.WrapDatabaseErrorf(err, "error deleting webhook")
+	}
+	if n, _ := res.RowsAffected(); n == 0 && expected != 0 {
+		return r.versionMismatch(ctx, id)
+	}
+	return nil
+}
+
+// Subscribers lists the active webhooks of the tenant subscribed to the event type
+func (r webhookRepository) Subscribers(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	stmt, ok := r.statements[subscribers]
+	if !ok {
+		return []domain.Webhook{}, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", subscribers)
+	}
+	ctx, end := postgres.StartStatement(ctx, subscribers)
+	defer end()
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return []domain.Webhook{}, err
+	}
+
+	var ws []domain.Webhook
+	if err := stmt.SelectContext(ctx, &ws, tenantID, eventType); err != nil {
+		return []domain.Webhook{}, errors.WrapDatabaseErrorf(err, "error getting webhooks of %s", eventType)
+	}
+	return ws, nil
+}
+
+func (r webhookRepository) PurgeWebhooks(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := r.statements[purgeWebhooks]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeWebhooks)
+	}
+	ctx, end := postgres.StartStatement(ctx, purgeWebhooks)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging webhooks")
+	}
+	return res.RowsAffected()
+}
+
+// versionMismatch tells a webhook changed since the expected version apart from a missing one
+func (r webhookRepository) versionMismatch(ctx context.Context, id uuid.UUID) error {
+	current, err := r.Webhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	return errors.NewErrorf(errors.ErrCodePreconditionFailed, "webhook %s was changed, it is at version %d", id, current.Version)
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+	utils "github.com/sumelms/microservice-course/tests"
+)
+
+var (
+	tenantCtx   = tenant.NewContext(context.Background(), tenant.DefaultTenant)
+	webhookUUID = uuid.MustParse("5d0a3f7e-2b6c-4e8d-9a1f-3c7b5e2d4f60")
+)
+
+func TestRepository_Subscribers(t *testing.T) {
+	rows := sqlmock.NewRows([]string{"id", "uuid", "tenant_id", "url", "secret", "event_types", "active"}).
+		AddRow(1, webhookUUID, tenant.DefaultTenant, "https://partner.example/hooks", "a-very-long-webhook-secret",
+			pq.StringArray{domain.EventTypes[0]}, true)
+
+	db, _, stmts := utils.NewTestDB(queriesWebhook())
+	r, err := NewWebhookRepository(db)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the repository", err)
+	}
+
+	stmts[subscribers].ExpectQuery().WithArgs(tenant.DefaultTenant, domain.EventTypes[0]).WillReturnRows(rows)
+
+	got, err := r.Subscribers(tenantCtx, domain.EventTypes[0])
+	if err != nil {
+		t.Fatalf("Subscribers() error = %v", err)
+	}
+	if len(got) != 1 || got[0].UUID != webhookUUID || len(got[0].EventTypes) != 1 {
+		t.Errorf("Subscribers() got = %v", got)
+	}
+}
+
+func TestRepository_RetryDelivery(t *testing.T) {
+	deliveryUUID := uuid.MustParse("9e4b2c1d-7a3f-4d6e-8b5c-1f2a3e4d5c6b")
+	columns := []string{"id", "uuid", "tenant_id", "webhook_id", "status", "attempts"}
+
+	tests := []struct {
+		name     string
+		retried  *sqlmock.Rows
+		current  *sqlmock.Rows
+		wantCode errors.ErrorCode
+		wantErr  bool
+	}{
+		{
+			name:    "dead delivery",
+			retried: sqlmock.NewRows(columns).AddRow(1, deliveryUUID, tenant.DefaultTenant, webhookUUID, domain.DeliveryPending, 0),
+		},
+		{
+			name:     "succeeded delivery",
+			retried:  sqlmock.NewRows(columns),
+			current:  sqlmock.NewRows(columns).AddRow(1, deliveryUUID, tenant.DefaultTenant, webhookUUID, domain.DeliverySucceeded, 1),
+			wantCode: errors.ErrCodeConflict,
+			wantErr:  true,
+		},
+		{
+			name:     "missing delivery",
+			retried:  sqlmock.NewRows(columns),
+			current:  sqlmock.NewRows(columns),
+			wantCode: errors.ErrCodeNotFound,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, _, stmts := utils.NewTestDB(queriesDelivery())
+			r, err := NewDeliveryRepository(db)
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when creating the repository", err)
+			}
+
+			stmts[retryDelivery].ExpectQuery().WithArgs(deliveryUUID, webhookUUID, tenant.DefaultTenant).WillReturnRows(tt.retried)
+			if tt.current != nil {
+				stmts[getDelivery].ExpectQuery().WithArgs(deliveryUUID, webhookUUID, tenant.DefaultTenant).WillReturnRows(tt.current)
+			}
+
+			got, err := r.RetryDelivery(tenantCtx, webhookUUID, deliveryUUID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RetryDelivery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if code := errors.CodeOf(err); code != tt.wantCode {
+					t.Errorf("RetryDelivery() error code = %v, want %v", code, tt.wantCode)
+				}
+				return
+			}
+			if got.Status != domain.DeliveryPending || got.Attempts != 0 {
+				t.Errorf("RetryDelivery() got = %+v, want a pending delivery", got)
+			}
+		})
+	}
+}
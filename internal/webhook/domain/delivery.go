@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+// DeliveryStatus is the state of the delivery of an event to a webhook
+type DeliveryStatus string
+
+const (
+	// DeliveryPending waits for its first attempt
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliveryFailed failed and waits to be retried
+	DeliveryFailed DeliveryStatus = "failed"
+	// DeliverySucceeded was accepted by the webhook
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryDead failed every attempt, it is only sent again when retried by an admin
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// Delivery is an event sent to a webhook, along with the outcome of the last attempt
+type Delivery struct {
+	ID             uint            `json:"id"`
+	TenantID       string          `db:"tenant_id" json:"-"`
+	UUID           uuid.UUID       `json:"uuid"`
+	WebhookID      uuid.UUID       `db:"webhook_id" json:"webhook_id"`
+	EventID        uuid.UUID       `db:"event_id" json:"event_id"`
+	EventType      string          `db:"event_type" json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at" json:"next_attempt_at"`
+	ResponseStatus *int            `db:"response_status" json:"response_status"`
+	LastError      *string         `db:"last_error" json:"last_error"`
+	DeliveredAt    *time.Time      `db:"delivered_at" json:"delivered_at"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// DeliveryListFields are the delivery fields allowed to filter and sort the delivery log
+var DeliveryListFields = pagination.Fields{
+	Filters: []string{"status", "event_type"},
+	Sorts:   []string{"created_at", "updated_at"},
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type DeliveryRepository interface {
+	Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) ([]Delivery, pagination.Page, error)
+	CreateDelivery(ctx context.Context, d *Delivery) error
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	SaveAttempt(ctx context.Context, d *Delivery) error
+	RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (Delivery, error)
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+// Deliveries lists the delivery log of the webhook
+func (s *Service) Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) ([]Delivery, pagination.Page, error) {
+	if _, err := s.webhooks.Webhook(ctx, webhookID); err != nil {
+		return []Delivery{}, pagination.Page{}, fmt.Errorf("error checking if webhook %s exists: %w", webhookID, err)
+	}
+
+	list, page, err := s.deliveries.Deliveries(ctx, webhookID, q)
+	if err != nil {
+		return []Delivery{}, pagination.Page{}, fmt.Errorf("service didn't found any delivery: %w", err)
+	}
+	return list, page, nil
+}
+
+// RetryDelivery sends the failed or dead delivery again, with all its attempts
+func (s *Service) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (Delivery, error) {
+	d, err := s.deliveries.RetryDelivery(ctx, webhookID, id)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("service can't retry delivery: %w", err)
+	}
+	return d, nil
+}
+
+// Publish queues the delivery of the event to every webhook of its tenant receiving its type,
+// the service is the publisher of the outbox relay
+func (s *Service) Publish(ctx context.Context, e outbox.Event) error {
+	ctx = tenant.NewContext(ctx, e.TenantID)
+
+	webhooks, err := s.webhooks.Subscribers(ctx, e.Type)
+	if err != nil {
+		return fmt.Errorf("service can't find the webhooks of %s: %w", e.Type, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error encoding event %s", e.Type)
+	}
+	for _, w := range webhooks {
+		d := Delivery{
+			WebhookID: w.UUID,
+			EventID:   e.UUID,
+			EventType: e.Type,
+			Payload:   payload,
+		}
+		if err := s.deliveries.CreateDelivery(ctx, &d); err != nil {
+			return fmt.Errorf("service can't queue delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// Run sends the due deliveries every interval until the context is done
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Dispatch(ctx); err != nil && s.logger != nil {
+				s.logger.Log("msg", "unable to dispatch the webhook deliveries", "error", err) //nolint: errcheck
+			}
+		}
+	}
+}
+
+// Dispatch sends a batch of the due deliveries, it returns how many were sent
+func (s *Service) Dispatch(ctx context.Context) (int, error) {
+	claimed, err := s.deliveries.ClaimDeliveries(ctx, time.Now(), s.retry.Lease, s.retry.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("service can't claim deliveries: %w", err)
+	}
+
+	sent := 0
+	for i := range claimed {
+		d := &claimed[i]
+		if err := s.deliver(tenant.NewContext(ctx, d.TenantID), d); err != nil {
+			return sent, err
+		}
+		if d.Status == DeliverySucceeded {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver makes an attempt to send the delivery and records its outcome
+func (s *Service) deliver(ctx context.Context, d *Delivery) error {
+	w, err := s.webhooks.Webhook(ctx, d.WebhookID)
+	switch {
+	case errors.CodeOf(err) == errors.ErrCodeNotFound:
+		s.bury(d, "webhook was deleted")
+	case err != nil:
+		// The lease of the claim expires and the delivery is attempted again
+		return fmt.Errorf("service can't find webhook %s: %w", d.WebhookID, err)
+	case !w.Active:
+		s.bury(d, "webhook is inactive")
+	default:
+		s.attempt(ctx, w, d)
+	}
+
+	if err := s.deliveries.SaveAttempt(ctx, d); err != nil {
+		return fmt.Errorf("service can't save delivery attempt: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) attempt(ctx context.Context, w Webhook, d *Delivery) {
+	status, err := s.sender.Send(ctx, w, *d)
+	d.Attempts++
+	d.ResponseStatus = nil
+	if status != 0 {
+		d.ResponseStatus = &status
+	}
+
+	if err == nil {
+		now := time.Now()
+		d.Status, d.LastError, d.DeliveredAt = DeliverySucceeded, nil, &now
+		return
+	}
+
+	message := err.Error()
+	d.LastError = &message
+	if d.Attempts >= s.retry.MaxAttempts {
+		d.Status = DeliveryDead
+		return
+	}
+	d.Status = DeliveryFailed
+	d.NextAttemptAt = time.Now().Add(s.retry.Delay(d.Attempts))
+}
+
+// bury moves the delivery to the dead letters without attempting it
+func (s *Service) bury(d *Delivery, reason string) {
+	d.Status, d.LastError = DeliveryDead, &reason
+}
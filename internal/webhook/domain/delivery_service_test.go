@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/outbox"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+type stubWebhooks struct {
+	WebhookRepository
+	webhooks map[uuid.UUID]Webhook
+}
+
+func (r *stubWebhooks) Webhook(_ context.Context, id uuid.UUID) (Webhook, error) {
+	w, ok := r.webhooks[id]
+	if !ok {
+		return Webhook{}, errors.NewErrorf(errors.ErrCodeNotFound, "webhook %s not found", id)
+	}
+	return w, nil
+}
+
+func (r *stubWebhooks) Subscribers(ctx context.Context, eventType string) ([]Webhook, error) {
+	tenantID, _ := tenant.FromContext(ctx)
+	var ws []Webhook
+	for _, w := range r.webhooks {
+		for _, t := range w.EventTypes {
+			if t == eventType && w.TenantID == tenantID && w.Active {
+				ws = append(ws, w)
+			}
+		}
+	}
+	return ws, nil
+}
+
+type stubDeliveries struct {
+	DeliveryRepository
+	created []Delivery
+	claimed []Delivery
+	saved   []Delivery
+}
+
+func (r *stubDeliveries) CreateDelivery(ctx context.Context, d *Delivery) error {
+	d.TenantID, _ = tenant.FromContext(ctx)
+	r.created = append(r.created, *d)
+	return nil
+}
+
+func (r *stubDeliveries) ClaimDeliveries(context.Context, time.Time, time.Duration, int) ([]Delivery, error) {
+	return r.claimed, nil
+}
+
+func (r *stubDeliveries) SaveAttempt(_ context.Context, d *Delivery) error {
+	r.saved = append(r.saved, *d)
+	return nil
+}
+
+type stubSender struct {
+	status int
+	err    error
+}
+
+func (s stubSender) Send(context.Context, Webhook, Delivery) (int, error) {
+	return s.status, s.err
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 5, want: 16 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempts); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestService_Publish(t *testing.T) {
+	course := Webhook{UUID: uuid.New(), TenantID: "default", Active: true, EventTypes: []string{"course.created"}}
+	other := Webhook{UUID: uuid.New(), TenantID: "other", Active: true, EventTypes: []string{"course.created"}}
+	inactive := Webhook{UUID: uuid.New(), TenantID: "default", EventTypes: []string{"course.created"}}
+	matrix := Webhook{UUID: uuid.New(), TenantID: "default", Active: true, EventTypes: []string{"matrix.created"}}
+
+	deliveries := &stubDeliveries{}
+	s, _ := NewService(
+		WithWebhookRepository(&stubWebhooks{webhooks: map[uuid.UUID]Webhook{
+			course.UUID: course, other.UUID: other, inactive.UUID: inactive, matrix.UUID: matrix,
+		}}),
+		WithDeliveryRepository(deliveries))
+
+	e := outbox.NewEvent("course.created", "default", uuid.New(), map[string]string{"name": "course"})
+	e.Payload = []byte(`{"name":"course"}`)
+	if err := s.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(deliveries.created) != 1 {
+		t.Fatalf("Publish() queued %d deliveries, want 1", len(deliveries.created))
+	}
+	d := deliveries.created[0]
+	if d.WebhookID != course.UUID || d.EventID != e.UUID || d.TenantID != "default" {
+		t.Errorf("Publish() queued %+v, want the event for webhook %s", d, course.UUID)
+	}
+}
+
+func TestService_Dispatch(t *testing.T) {
+	active := Webhook{UUID: uuid.New(), TenantID: "default", Active: true}
+	inactive := Webhook{UUID: uuid.New(), TenantID: "default"}
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Minute}
+
+	tests := []struct {
+		name       string
+		webhook    uuid.UUID
+		attempts   int
+		sender     stubSender
+		wantStatus DeliveryStatus
+		wantSent   int
+	}{
+		{name: "delivered", webhook: active.UUID, sender: stubSender{status: 204}, wantStatus: DeliverySucceeded, wantSent: 1},
+		{name: "failed", webhook: active.UUID, sender: stubSender{status: 503, err: fmt.Errorf("unavailable")}, wantStatus: DeliveryFailed},
+		{name: "last attempt failed", webhook: active.UUID, attempts: 2, sender: stubSender{err: fmt.Errorf("timeout")}, wantStatus: DeliveryDead},
+		{name: "webhook deleted", webhook: uuid.New(), sender: stubSender{status: 204}, wantStatus: DeliveryDead},
+		{name: "webhook inactive", webhook: inactive.UUID, sender: stubSender{status: 204}, wantStatus: DeliveryDead},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			deliveries := &stubDeliveries{claimed: []Delivery{
+				{ID: 1, TenantID: "default", WebhookID: tt.webhook, Status: DeliveryPending, Attempts: tt.attempts},
+			}}
+			s, _ := NewService(
+				WithWebhookRepository(&stubWebhooks{webhooks: map[uuid.UUID]Webhook{active.UUID: active, inactive.UUID: inactive}}),
+				WithDeliveryRepository(deliveries),
+				WithSender(tt.sender),
+				WithRetryPolicy(retry))
+
+			sent, err := s.Dispatch(context.Background())
+			if err != nil {
+				t.Fatalf("Dispatch() error = %v", err)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("Dispatch() sent = %d, want %d", sent, tt.wantSent)
+			}
+			if len(deliveries.saved) != 1 {
+				t.Fatalf("Dispatch() saved %d attempts, want 1", len(deliveries.saved))
+			}
+
+			d := deliveries.saved[0]
+			if d.Status != tt.wantStatus {
+				t.Errorf("Dispatch() status = %s, want %s", d.Status, tt.wantStatus)
+			}
+			if d.Status == DeliveryFailed && time.Until(d.NextAttemptAt) < retry.Backoff-time.Second {
+				t.Errorf("Dispatch() next attempt at %v, want after the backoff", d.NextAttemptAt)
+			}
+			if d.Status != DeliverySucceeded && d.LastError == nil {
+				t.Errorf("Dispatch() didn't record the error")
+			}
+		})
+	}
+}
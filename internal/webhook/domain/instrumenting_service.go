@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type instrumentingService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	next           ServiceInterface
+}
+
+// NewInstrumentingService counts the calls to every method of the service and records their latency
+func NewInstrumentingService(next ServiceInterface, counter metrics.Counter, latency metrics.Histogram) ServiceInterface {
+	return &instrumentingService{requestCount: counter, requestLatency: latency, next: next}
+}
+
+func (s *instrumentingService) observe(method string, begin time.Time, err error) {
+	labels := []string{"method", method, "error", strconv.FormatBool(err != nil)}
+	s.requestCount.With(labels...).Add(1)
+	s.requestLatency.With(labels...).Observe(time.Since(begin).Seconds())
+}
+
+func (s *instrumentingService) Webhook(ctx context.Context, id uuid.UUID) (res Webhook, err error) {
+	defer func(begin time.Time) { s.observe("Webhook", begin, err) }(time.Now())
+	return s.next.Webhook(ctx, id)
+}
+
+func (s *instrumentingService) Webhooks(ctx context.Context, q pagination.Query) (list []Webhook, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Webhooks", begin, err) }(time.Now())
+	return s.next.Webhooks(ctx, q)
+}
+
+func (s *instrumentingService) CreateWebhook(ctx context.Context, w *Webhook) (err error) {
+	defer func(begin time.Time) { s.observe("CreateWebhook", begin, err) }(time.Now())
+	return s.next.CreateWebhook(ctx, w)
+}
+
+func (s *instrumentingService) UpdateWebhook(ctx context.Context, w *Webhook) (err error) {
+	defer func(begin time.Time) { s.observe("UpdateWebhook", begin, err) }(time.Now())
+	return s.next.UpdateWebhook(ctx, w)
+}
+
+func (s *instrumentingService) DeleteWebhook(ctx context.Context, id uuid.UUID) (err error) {
+	defer func(begin time.Time) { s.observe("DeleteWebhook", begin, err) }(time.Now())
+	return s.next.DeleteWebhook(ctx, id)
+}
+
+func (s *instrumentingService) Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) (list []Delivery, page pagination.Page, err error) {
+	defer func(begin time.Time) { s.observe("Deliveries", begin, err) }(time.Now())
+	return s.next.Deliveries(ctx, webhookID, q)
+}
+
+func (s *instrumentingService) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (res Delivery, err error) {
+	defer func(begin time.Time) { s.observe("RetryDelivery", begin, err) }(time.Now())
+	return s.next.RetryDelivery(ctx, webhookID, id)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+const ScopeWebhookAdmin = "webhook:admin"
+
+// adminPolicy grants the webhooks to the admins, their secrets reach every event of the tenant
+var adminPolicy = auth.Policy{Scopes: []string{ScopeWebhookAdmin}}
+
+type policyService struct {
+	next ServiceInterface
+}
+
+// NewPolicyService puts the authorization policies in front of the service, only admins
+// manage the webhooks and read their deliveries
+func NewPolicyService(next ServiceInterface) ServiceInterface {
+	return &policyService{next: next}
+}
+
+func (s *policyService) Webhook(ctx context.Context, id uuid.UUID) (Webhook, error) {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return Webhook{}, err
+	}
+	return s.next.Webhook(ctx, id)
+}
+
+func (s *policyService) Webhooks(ctx context.Context, q pagination.Query) ([]Webhook, pagination.Page, error) {
+	if err := adminPolicy.AuthorizeListing(ctx, q); err != nil {
+		return []Webhook{}, pagination.Page{}, err
+	}
+	return s.next.Webhooks(ctx, q)
+}
+
+func (s *policyService) CreateWebhook(ctx context.Context, w *Webhook) error {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.CreateWebhook(ctx, w)
+}
+
+func (s *policyService) UpdateWebhook(ctx context.Context, w *Webhook) error {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.UpdateWebhook(ctx, w)
+}
+
+func (s *policyService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return err
+	}
+	return s.next.DeleteWebhook(ctx, id)
+}
+
+func (s *policyService) Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) ([]Delivery, pagination.Page, error) {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return []Delivery{}, pagination.Page{}, err
+	}
+	return s.next.Deliveries(ctx, webhookID, q)
+}
+
+func (s *policyService) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (Delivery, error) {
+	if err := adminPolicy.Authorize(ctx); err != nil {
+		return Delivery{}, err
+	}
+	return s.next.RetryDelivery(ctx, webhookID, id)
+}
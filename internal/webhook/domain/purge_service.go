@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PurgeDeleted permanently deletes the webhooks soft deleted and the deliveries finished longer
+// than the retention period
+func (s *Service) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+
+	webhooks, err := s.webhooks.PurgeWebhooks(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge webhooks: %w", err)
+	}
+	deliveries, err := s.deliveries.PurgeDeliveries(ctx, before)
+	if err != nil {
+		return fmt.Errorf("service can't purge deliveries: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Log("msg", "purged deleted records", "webhooks", webhooks, "deliveries", deliveries) //nolint: errcheck
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// Sender posts the deliveries to the webhooks, it returns the status code of the response
+// when one was received
+type Sender interface {
+	Send(ctx context.Context, w Webhook, d Delivery) (int, error)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+// ServiceInterface defines the domains Service interface
+type ServiceInterface interface {
+	Webhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	Webhooks(ctx context.Context, q pagination.Query) ([]Webhook, pagination.Page, error)
+	CreateWebhook(ctx context.Context, w *Webhook) error
+	UpdateWebhook(ctx context.Context, w *Webhook) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) ([]Delivery, pagination.Page, error)
+	RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (Delivery, error)
+}
+
+// RetryPolicy tells how the failed deliveries are retried. The wait before an attempt doubles
+// after every failure, the delivery is dead once the attempts are exhausted.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	// Lease is how long a claimed delivery is held by the dispatcher sending it
+	Lease     time.Duration
+	BatchSize int
+}
+
+// Delay returns the wait before the attempt following the given number of failed attempts
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+type serviceConfiguration func(svc *Service) error
+
+type Service struct {
+	webhooks   WebhookRepository
+	deliveries DeliveryRepository
+	sender     Sender
+	retry      RetryPolicy
+	logger     log.Logger
+}
+
+// NewService creates a new domain Service instance
+func NewService(cfgs ...serviceConfiguration) (*Service, error) {
+	svc := &Service{}
+	for _, cfg := range cfgs {
+		err := cfg(svc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return svc, nil
+}
+
+// WithWebhookRepository injects the webhook repository to the domain Service
+func WithWebhookRepository(wr WebhookRepository) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.webhooks = wr
+		return nil
+	}
+}
+
+// WithDeliveryRepository injects the delivery repository to the domain Service
+func WithDeliveryRepository(dr DeliveryRepository) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.deliveries = dr
+		return nil
+	}
+}
+
+// WithSender injects the sender of the deliveries to the domain Service
+func WithSender(s Sender) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.sender = s
+		return nil
+	}
+}
+
+// WithRetryPolicy injects the retry policy of the deliveries to the domain Service
+func WithRetryPolicy(p RetryPolicy) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.retry = p
+		return nil
+	}
+}
+
+// WithLogger injects the logger to the domain Service
+func WithLogger(l log.Logger) serviceConfiguration {
+	return func(svc *Service) error {
+		svc.logger = l
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+)
+
+type tracingService struct {
+	next ServiceInterface
+}
+
+// NewTracingService starts a span for every call to the service, the parent of the spans of the
+// course client and of the statements it runs
+func NewTracingService(next ServiceInterface) ServiceInterface {
+	return &tracingService{next: next}
+}
+
+func (s *tracingService) Webhook(ctx context.Context, id uuid.UUID) (res Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.Webhook")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Webhook(ctx, id)
+}
+
+func (s *tracingService) Webhooks(ctx context.Context, q pagination.Query) (list []Webhook, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.Webhooks")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Webhooks(ctx, q)
+}
+
+func (s *tracingService) CreateWebhook(ctx context.Context, w *Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.CreateWebhook")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateWebhook(ctx, w)
+}
+
+func (s *tracingService) UpdateWebhook(ctx context.Context, w *Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.UpdateWebhook")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateWebhook(ctx, w)
+}
+
+func (s *tracingService) DeleteWebhook(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "webhook.DeleteWebhook")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteWebhook(ctx, id)
+}
+
+func (s *tracingService) Deliveries(ctx context.Context, webhookID uuid.UUID, q pagination.Query) (list []Delivery, page pagination.Page, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.Deliveries")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Deliveries(ctx, webhookID, q)
+}
+
+func (s *tracingService) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (res Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhook.RetryDelivery")
+	defer func() { tracing.End(span, err) }()
+	return s.next.RetryDelivery(ctx, webhookID, id)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	coursedomain "github.com/sumelms/microservice-course/internal/course/domain"
+	matrixdomain "github.com/sumelms/microservice-course/internal/matrix/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+// Webhook is the URL of a partner system receiving the events of the given types
+type Webhook struct {
+	ID         uint           `json:"id"`
+	TenantID   string         `db:"tenant_id" json:"-"`
+	UUID       uuid.UUID      `json:"uuid"`
+	URL        string         `json:"url"`
+	Secret     string         `json:"secret"`
+	EventTypes pq.StringArray `db:"event_types" json:"event_types"`
+	Active     bool           `json:"active"`
+	Version    int            `json:"version"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt  *time.Time     `db:"deleted_at" json:"deleted_at"`
+}
+
+// WebhookListFields are the webhook fields allowed to filter and sort the listing
+var WebhookListFields = pagination.Fields{
+	Filters: []string{"active"},
+	Sorts:   []string{"created_at", "updated_at"},
+}
+
+// EventTypes are the types of the events of the course and matrix services the webhooks receive
+var EventTypes = []string{
+	coursedomain.EventCourseCreated,
+	coursedomain.EventCourseUpdated,
+	coursedomain.EventCourseDeleted,
+	coursedomain.EventCourseRestored,
+	coursedomain.EventCourseStatusChanged,
+	coursedomain.EventCoursePublished,
+	coursedomain.EventSubscriptionCreated,
+	coursedomain.EventSubscriptionUpdated,
+	coursedomain.EventSubscriptionDeleted,
+	coursedomain.EventSubscriptionRestored,
+	coursedomain.EventSubscriptionExpired,
+	matrixdomain.EventMatrixCreated,
+	matrixdomain.EventMatrixUpdated,
+	matrixdomain.EventMatrixDeleted,
+	matrixdomain.EventMatrixRestored,
+	matrixdomain.EventMatrixPublished,
+	matrixdomain.EventMatrixSubjectAdded,
+	matrixdomain.EventMatrixSubjectRemoved,
+	matrixdomain.EventSubjectCreated,
+	matrixdomain.EventSubjectUpdated,
+	matrixdomain.EventSubjectDeleted,
+	matrixdomain.EventSubjectRestored,
+}
+
+// KnownEventType tells whether the webhooks can receive the events of the type
+func KnownEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
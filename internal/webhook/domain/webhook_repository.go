@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type WebhookRepository interface {
+	Webhook(ctx context.Context, id uuid.UUID) (Webhook, error)
+	Webhooks(ctx context.Context, q pagination.Query) ([]Webhook, pagination.Page, error)
+	CreateWebhook(ctx context.Context, w *Webhook) error
+	UpdateWebhook(ctx context.Context, w *Webhook) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	Subscribers(ctx context.Context, eventType string) ([]Webhook, error)
+	PurgeWebhooks(ctx context.Context, before time.Time) (int64, error)
+}
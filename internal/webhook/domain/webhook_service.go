@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+func (s *Service) Webhook(ctx context.Context, id uuid.UUID) (Webhook, error) {
+	w, err := s.webhooks.Webhook(ctx, id)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("service can't find webhook: %w", err)
+	}
+	return w, nil
+}
+
+func (s *Service) Webhooks(ctx context.Context, q pagination.Query) ([]Webhook, pagination.Page, error) {
+	list, page, err := s.webhooks.Webhooks(ctx, q)
+	if err != nil {
+		return []Webhook{}, pagination.Page{}, fmt.Errorf("service didn't found any webhook: %w", err)
+	}
+	return list, page, nil
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, w *Webhook) error {
+	if err := validateEventTypes(w.EventTypes); err != nil {
+		return err
+	}
+	if err := s.webhooks.CreateWebhook(ctx, w); err != nil {
+		return fmt.Errorf("service can't create webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, w *Webhook) error {
+	if err := s.webhookNoneMatch(ctx, w.UUID); err != nil {
+		return err
+	}
+	if err := validateEventTypes(w.EventTypes); err != nil {
+		return err
+	}
+	if err := s.webhooks.UpdateWebhook(ctx, w); err != nil {
+		return fmt.Errorf("service can't update webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if err := s.webhookNoneMatch(ctx, id); err != nil {
+		return err
+	}
+	if err := s.webhooks.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("service can't delete webhook: %w", err)
+	}
+	return nil
+}
+
+func validateEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "webhook must receive at least one event type")
+	}
+	for _, t := range eventTypes {
+		if !KnownEventType(t) {
+			return errors.NewErrorf(errors.ErrCodeInvalidArgument, "unknown event type %s", t)
+		}
+	}
+	return nil
+}
+
+// webhookNoneMatch fails when the If-None-Match of the change matches the current webhook
+func (s *Service) webhookNoneMatch(ctx context.Context, id uuid.UUID) error {
+	return etag.CheckNoneMatch(ctx, func() (int, error) {
+		current, err := s.webhooks.Webhook(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("service can't find webhook: %w", err)
+		}
+		return current.Version, nil
+	})
+}
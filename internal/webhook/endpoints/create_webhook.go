@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type createWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+	Active     *bool    `json:"active"`
+}
+
+// NewCreateWebhookHandler registers a new webhook
+// @Summary      Create webhook
+// @Description  Register a webhook receiving the events of the given types, signed with the secret
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body		createWebhookRequest	true	"Add Webhook"
+// @Success      200      {object}  findWebhookResponse
+// @Failure      400      {object}  error
+// @Failure      500      {object}  error
+// @Router       /webhooks [post]
+func NewCreateWebhookHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeCreateWebhookEndpoint(s),
+		decodeCreateWebhookRequest,
+		encodeCreateWebhookResponse,
+		opts...,
+	)
+}
+
+func makeCreateWebhookEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		w := domain.Webhook{
+			URL:        req.URL,
+			Secret:     req.Secret,
+			EventTypes: req.EventTypes,
+			Active:     req.Active == nil || *req.Active,
+		}
+		if err := s.CreateWebhook(ctx, &w); err != nil {
+			return nil, err
+		}
+
+		return newFindWebhookResponse(w), nil
+	}
+}
+
+func decodeCreateWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req createWebhookRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func encodeCreateWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
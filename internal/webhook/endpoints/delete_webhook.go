@@ -0,0 +1,53 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+)
+
+type deleteWebhookRequest struct {
+	UUID uuid.UUID `json:"uuid" validate:"required"`
+}
+
+func NewDeleteWebhookHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeDeleteWebhookEndpoint(s),
+		decodeDeleteWebhookRequest,
+		encodeDeleteWebhookResponse,
+		opts...,
+	)
+}
+
+func makeDeleteWebhookEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(deleteWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		if err := s.DeleteWebhook(ctx, req.UUID); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func decodeDeleteWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	return deleteWebhookRequest{UUID: id}, nil
+}
+
+func encodeDeleteWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,94 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
+)
+
+type findWebhookRequest struct {
+	UUID uuid.UUID `json:"uuid"`
+}
+
+// findWebhookResponse never carries the secret, it is only known to the one registering the webhook
+type findWebhookResponse struct {
+	UUID       uuid.UUID `json:"uuid"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	Version    int       `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewFindWebhookHandler finds the webhook
+// @Summary      Find webhook
+// @Description  Find a webhook, without its secret
+// @Tags         webhook
+// @Produce      json
+// @Param        uuid	  path		string		true	"Webhook UUID"
+// @Success      200      {object}  findWebhookResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /webhooks/{uuid} [get]
+func NewFindWebhookHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeFindWebhookEndpoint(s),
+		decodeFindWebhookRequest,
+		encodeFindWebhookResponse,
+		opts...,
+	)
+}
+
+func makeFindWebhookEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(findWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		w, err := s.Webhook(ctx, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newFindWebhookResponse(w), nil
+	}
+}
+
+func newFindWebhookResponse(w domain.Webhook) *findWebhookResponse {
+	return &findWebhookResponse{
+		UUID:       w.UUID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		Version:    w.Version,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+func decodeFindWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	return findWebhookRequest{UUID: id}, nil
+}
+
+func encodeFindWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	r, ok := response.(*findWebhookResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
+}
@@ -0,0 +1,121 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type listDeliveryRequest struct {
+	WebhookID uuid.UUID
+	Query     pagination.Query
+}
+
+type deliveryResponse struct {
+	UUID           uuid.UUID       `json:"uuid"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type listDeliveryResponse struct {
+	Deliveries []deliveryResponse `json:"deliveries"`
+	pagination.Page
+}
+
+// NewListDeliveryHandler lists the delivery log of the webhook
+// @Summary      List deliveries
+// @Description  List the deliveries of a webhook, the dead letters are filtered with status=dead
+// @Tags         webhook
+// @Produce      json
+// @Param        uuid	  path		string		true	"Webhook UUID"
+// @Param        status	  query		string		false	"pending, failed, succeeded or dead"
+// @Success      200      {object}  listDeliveryResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      500      {object}  error
+// @Router       /webhooks/{uuid}/deliveries [get]
+func NewListDeliveryHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListDeliveryEndpoint(s),
+		decodeListDeliveryRequest,
+		encodeListDeliveryResponse,
+		opts...,
+	)
+}
+
+func makeListDeliveryEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listDeliveryRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		ds, page, err := s.Deliveries(ctx, req.WebhookID, req.Query)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []deliveryResponse
+		for i := range ds {
+			list = append(list, newDeliveryResponse(ds[i]))
+		}
+
+		return &listDeliveryResponse{Deliveries: list, Page: page}, nil
+	}
+}
+
+func newDeliveryResponse(d domain.Delivery) deliveryResponse {
+	res := deliveryResponse{
+		UUID:           d.UUID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	// Only the deliveries waiting to be sent have a next attempt
+	if d.Status == domain.DeliveryPending || d.Status == domain.DeliveryFailed {
+		res.NextAttemptAt = &d.NextAttemptAt
+	}
+	return res
+}
+
+func decodeListDeliveryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := pagination.ParseQuery(r, domain.DeliveryListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listDeliveryRequest{WebhookID: id, Query: q}, nil
+}
+
+func encodeListDeliveryResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
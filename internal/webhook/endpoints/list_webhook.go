@@ -0,0 +1,64 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+type listWebhookRequest struct {
+	Query pagination.Query
+}
+
+type listWebhookResponse struct {
+	Webhooks []findWebhookResponse `json:"webhooks"`
+	pagination.Page
+}
+
+func NewListWebhookHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeListWebhookEndpoint(s),
+		decodeListWebhookRequest,
+		encodeListWebhookResponse,
+		opts...,
+	)
+}
+
+func makeListWebhookEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		ws, page, err := s.Webhooks(ctx, req.Query)
+		if err != nil {
+			return nil, err
+		}
+
+		var list []findWebhookResponse
+		for i := range ws {
+			list = append(list, *newFindWebhookResponse(ws[i]))
+		}
+
+		return &listWebhookResponse{Webhooks: list, Page: page}, nil
+	}
+}
+
+func decodeListWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q, err := pagination.ParseQuery(r, domain.WebhookListFields)
+	if err != nil {
+		return nil, err
+	}
+	return listWebhookRequest{Query: q}, nil
+}
+
+func encodeListWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
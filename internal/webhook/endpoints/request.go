@@ -0,0 +1,19 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// pathUUID parses the UUID in the given path variable of the request
+func pathUUID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(mux.Vars(r)[name])
+	if err != nil {
+		return uuid.Nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "%s must be a valid UUID", name)
+	}
+	return id, nil
+}
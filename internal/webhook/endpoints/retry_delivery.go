@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+)
+
+type retryDeliveryRequest struct {
+	WebhookID uuid.UUID
+	UUID      uuid.UUID
+}
+
+// NewRetryDeliveryHandler queues a failed or dead delivery again
+// @Summary      Retry delivery
+// @Description  Send a failed or dead delivery again, with all its attempts
+// @Tags         webhook
+// @Produce      json
+// @Param        uuid	  path		string		true	"Webhook UUID"
+// @Param        delivery path		string		true	"Delivery UUID"
+// @Success      200      {object}  deliveryResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      409      {object}  error
+// @Failure      500      {object}  error
+// @Router       /webhooks/{uuid}/deliveries/{delivery}/retry [post]
+func NewRetryDeliveryHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeRetryDeliveryEndpoint(s),
+		decodeRetryDeliveryRequest,
+		encodeRetryDeliveryResponse,
+		opts...,
+	)
+}
+
+func makeRetryDeliveryEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(retryDeliveryRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		d, err := s.RetryDelivery(ctx, req.WebhookID, req.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newDeliveryResponse(d), nil
+	}
+}
+
+func decodeRetryDeliveryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	webhookID, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	id, err := pathUUID(r, "delivery")
+	if err != nil {
+		return nil, err
+	}
+	return retryDeliveryRequest{WebhookID: webhookID, UUID: id}, nil
+}
+
+func encodeRetryDeliveryResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
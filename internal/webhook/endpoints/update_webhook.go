@@ -0,0 +1,98 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/validator"
+)
+
+type updateWebhookRequest struct {
+	UUID       uuid.UUID `json:"uuid" validate:"required"`
+	URL        string    `json:"url" validate:"required,url,max=2048"`
+	Secret     string    `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string  `json:"event_types" validate:"required,min=1,dive,required"`
+	Active     *bool     `json:"active" validate:"required"`
+}
+
+// NewUpdateWebhookHandler updates the webhook, the secret is kept when none is given
+// @Summary      Update webhook
+// @Description  Update a webhook, the secret is rotated only when given
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        uuid	  path		string					true	"Webhook UUID"
+// @Param        webhook  body		updateWebhookRequest	true	"Update Webhook"
+// @Success      200      {object}  findWebhookResponse
+// @Failure      400      {object}  error
+// @Failure      404      {object}  error
+// @Failure      412      {object}  error
+// @Failure      500      {object}  error
+// @Router       /webhooks/{uuid} [put]
+func NewUpdateWebhookHandler(s domain.ServiceInterface, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		makeUpdateWebhookEndpoint(s),
+		decodeUpdateWebhookRequest,
+		encodeUpdateWebhookResponse,
+		opts...,
+	)
+}
+
+func makeUpdateWebhookEndpoint(s domain.ServiceInterface) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(updateWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument")
+		}
+
+		v := validator.NewValidator()
+		if err := v.Validate(req); err != nil {
+			return nil, err
+		}
+
+		w := domain.Webhook{
+			UUID:       req.UUID,
+			URL:        req.URL,
+			Secret:     req.Secret,
+			EventTypes: req.EventTypes,
+			Active:     *req.Active,
+		}
+
+		if err := s.UpdateWebhook(ctx, &w); err != nil {
+			return nil, err
+		}
+
+		return newFindWebhookResponse(w), nil
+	}
+}
+
+func decodeUpdateWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	id, err := pathUUID(r, "uuid")
+	if err != nil {
+		return nil, err
+	}
+
+	var req updateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+	req.UUID = id
+
+	return req, nil
+}
+
+func encodeUpdateWebhookResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	r, ok := response.(*findWebhookResponse)
+	if !ok {
+		return fmt.Errorf("invalid response")
+	}
+	return etag.EncodeJSONResponse(ctx, w, r.Version, response)
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/internal/webhook/clients"
+	"github.com/sumelms/microservice-course/internal/webhook/database"
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+	"github.com/sumelms/microservice-course/internal/webhook/transport"
+	"github.com/sumelms/microservice-course/pkg/config"
+)
+
+func NewService(db *sqlx.DB, logger log.Logger, cfg *config.Webhooks) (*domain.Service, error) {
+	webhooks, err := database.NewWebhookRepository(db)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := database.NewDeliveryRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := domain.NewService(
+		domain.WithLogger(logger),
+		domain.WithWebhookRepository(webhooks),
+		domain.WithDeliveryRepository(deliveries),
+		domain.WithSender(clients.NewHTTPSender(cfg.Timeout)),
+		domain.WithRetryPolicy(domain.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			Backoff:     cfg.Backoff,
+			Lease:       cfg.Lease,
+			BatchSize:   cfg.BatchSize,
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func NewHTTPService(router *mux.Router, service domain.ServiceInterface, logger log.Logger) error {
+	transport.NewHTTPHandler(router, service, logger)
+	return nil
+}
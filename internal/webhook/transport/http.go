@@ -0,0 +1,47 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/sumelms/microservice-course/internal/webhook/endpoints"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/etag"
+	"github.com/sumelms/microservice-course/pkg/metrics"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+	"github.com/sumelms/microservice-course/pkg/validator"
+
+	kittransport "github.com/go-kit/kit/transport"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+
+	"github.com/sumelms/microservice-course/internal/webhook/domain"
+)
+
+func NewHTTPHandler(r *mux.Router, s domain.ServiceInterface, logger log.Logger) {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerBefore(tracing.HTTPToContext, kithttp.PopulateRequestContext, validator.PopulateLanguage,
+			etag.PopulatePreconditions),
+		kithttp.ServerFinalizer(tracing.HTTPFinalizer),
+		kithttp.ServerErrorHandler(kittransport.NewLogErrorHandler(logger)),
+		kithttp.ServerErrorEncoder(errors.EncodeError),
+	}
+
+	listWebhookHandler := metrics.NewHTTPHandler(endpoints.NewListWebhookHandler(s, opts...))
+	createWebhookHandler := metrics.NewHTTPHandler(endpoints.NewCreateWebhookHandler(s, opts...))
+	findWebhookHandler := metrics.NewHTTPHandler(endpoints.NewFindWebhookHandler(s, opts...))
+	updateWebhookHandler := metrics.NewHTTPHandler(endpoints.NewUpdateWebhookHandler(s, opts...))
+	deleteWebhookHandler := metrics.NewHTTPHandler(endpoints.NewDeleteWebhookHandler(s, opts...))
+
+	r.Handle("/webhooks", listWebhookHandler).Methods(http.MethodGet)
+	r.Handle("/webhooks", createWebhookHandler).Methods(http.MethodPost)
+	r.Handle("/webhooks/{uuid}", findWebhookHandler).Methods(http.MethodGet)
+	r.Handle("/webhooks/{uuid}", updateWebhookHandler).Methods(http.MethodPut)
+	r.Handle("/webhooks/{uuid}", deleteWebhookHandler).Methods(http.MethodDelete)
+
+	listDeliveryHandler := metrics.NewHTTPHandler(endpoints.NewListDeliveryHandler(s, opts...))
+	retryDeliveryHandler := metrics.NewHTTPHandler(endpoints.NewRetryDeliveryHandler(s, opts...))
+
+	r.Handle("/webhooks/{uuid}/deliveries", listDeliveryHandler).Methods(http.MethodGet)
+	r.Handle("/webhooks/{uuid}/deliveries/{delivery}/retry", retryDeliveryHandler).Methods(http.MethodPost)
+}
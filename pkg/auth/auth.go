@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// minSecretLength is the shortest HMAC secret accepted, the size of a SHA-256 digest, so the
+// sample and placeholder secrets are turned down
+const minSecretLength = 32
+
+var signingMethods = []string{
+	"HS256", "HS384", "HS512",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// Claims are the claims of the access tokens issued to the API clients
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope  string   `json:"scope,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+	Tenant string   `json:"tenant,omitempty"`
+}
+
+// Verifier checks the signature and the registered claims of access tokens
+type Verifier struct {
+	keys     KeySet
+	issuer   string
+	audience string
+	parser   *jwt.Parser
+}
+
+// NewVerifier creates a verifier using the HMAC secret and the JWKS files of the configuration
+func NewVerifier(cfg *config.Auth) (*Verifier, error) {
+	var keys KeySet
+	if cfg.Secret != "" {
+		if len(cfg.Secret) < minSecretLength {
+			return nil, errors.NewErrorf(errors.ErrCodeUnknown, "the secret must have at least %d characters", minSecretLength)
+		}
+		keys = append(keys, Key{Value: []byte(cfg.Secret)})
+	}
+	for _, path := range cfg.JWKSFiles {
+		set, err := LoadJWKS(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, set...)
+	}
+	if len(keys) == 0 {
+		return nil, errors.NewErrorf(errors.ErrCodeUnknown, "no key configured to verify tokens")
+	}
+
+	return &Verifier{
+		keys:     keys,
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		parser:   jwt.NewParser(jwt.WithValidMethods(signingMethods)),
+	}, nil
+}
+
+// Verify parses the token and returns its claims when it is signed by a known key and still valid
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keys.lookup); err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeUnauthenticated, "invalid token")
+	}
+
+	switch {
+	case !claims.VerifyExpiresAt(time.Now(), true):
+		return nil, errors.NewErrorf(errors.ErrCodeUnauthenticated, "token has no valid expiration")
+	case v.issuer != "" && !claims.VerifyIssuer(v.issuer, true):
+		return nil, errors.NewErrorf(errors.ErrCodeUnauthenticated, "token issuer is not accepted")
+	case v.audience != "" && !claims.VerifyAudience(v.audience, true):
+		return nil, errors.NewErrorf(errors.ErrCodeUnauthenticated, "token audience is not accepted")
+	case claims.Subject == "":
+		return nil, errors.NewErrorf(errors.ErrCodeUnauthenticated, "token has no subject")
+	}
+	return claims, nil
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	secret = "8c1f0b6e3d2a4f7e9b5c6d1a0e3f2b7c"
+	issuer = "https://sso.sumelms.com"
+)
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	set := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig",
+				"n": encodeInt(rsaKey.N), "e": encodeInt(big.NewInt(int64(rsaKey.E))),
+			},
+			{
+				"kty": "EC", "kid": "ec", "crv": "P-256",
+				"x": encodeInt(ecKey.X), "y": encodeInt(ecKey.Y),
+			},
+		},
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("unable to encode JWKS: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("unable to write JWKS: %v", err)
+	}
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unable to sign token: %v", err)
+	}
+	return s
+}
+
+func TestNewVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Auth
+		wantErr bool
+	}{
+		{name: "secret", cfg: config.Auth{Secret: secret}},
+		{name: "placeholder secret", cfg: config.Auth{Secret: "changeme"}, wantErr: true},
+		{name: "no key", cfg: config.Auth{Issuer: issuer}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewVerifier(&tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("NewVerifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate EC key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate RSA key: %v", err)
+	}
+
+	v, err := NewVerifier(&config.Auth{
+		Issuer:    issuer,
+		Secret:    secret,
+		JWKSFiles: []string{writeJWKS(t, rsaKey, ecKey)},
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier() unexpected error = %v", err)
+	}
+
+	valid := Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d",
+		Issuer:    issuer,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	foreign := valid
+	foreign.Issuer = "https://example.com"
+	anonymous := valid
+	anonymous.Subject = ""
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "HMAC token", token: sign(t, jwt.SigningMethodHS256, "", []byte(secret), valid)},
+		{name: "RSA token", token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, valid)},
+		{name: "ECDSA token", token: sign(t, jwt.SigningMethodES256, "ec", ecKey, valid)},
+		{name: "ECDSA token without kid", token: sign(t, jwt.SigningMethodES256, "", ecKey, valid)},
+		{name: "wrong HMAC secret", token: sign(t, jwt.SigningMethodHS256, "", []byte("other"), valid), wantErr: true},
+		{name: "unknown RSA key", token: sign(t, jwt.SigningMethodRS256, "rsa", otherKey, valid), wantErr: true},
+		{name: "unknown kid", token: sign(t, jwt.SigningMethodRS256, "other", rsaKey, valid), wantErr: true},
+		{name: "unsigned token", token: sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, valid), wantErr: true},
+		{name: "expired token", token: sign(t, jwt.SigningMethodHS256, "", []byte(secret), expired), wantErr: true},
+		{name: "foreign issuer", token: sign(t, jwt.SigningMethodHS256, "", []byte(secret), foreign), wantErr: true},
+		{name: "missing subject", token: sign(t, jwt.SigningMethodHS256, "", []byte(secret), anonymous), wantErr: true},
+		{name: "malformed token", token: "not a token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := v.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && claims.Subject != valid.Subject {
+				t.Errorf("Verify() subject = %v, want %v", claims.Subject, valid.Subject)
+			}
+		})
+	}
+}
+
+func TestNewHTTPHandler(t *testing.T) {
+	v, err := NewVerifier(&config.Auth{Secret: secret})
+	if err != nil {
+		t.Fatalf("NewVerifier() unexpected error = %v", err)
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := Subject(r.Context()); !ok {
+			t.Errorf("NewHTTPHandler() subject missing from the context")
+		}
+	})
+	h := NewHTTPHandler(v, next, errors.EncodeError)
+
+	token := sign(t, jwt.SigningMethodHS256, "", []byte(secret), Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "learner",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{name: "valid token", authorization: "Bearer " + token, want: http.StatusOK},
+		{name: "missing token", want: http.StatusUnauthorized},
+		{name: "invalid token", authorization: "Bearer invalid", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/courses", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("ServeHTTP() status = %v, want %v", w.Code, tt.want)
+			}
+		})
+	}
+}
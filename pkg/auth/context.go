@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+)
+
+type contextKey int
+
+const claimsContextKey contextKey = iota
+
+// NewContext returns a context carrying the claims, also under the go-kit JWT claims key
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	ctx = context.WithValue(ctx, kitjwt.JWTClaimsContextKey, claims)
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// FromContext returns the claims of the authenticated request
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+// Subject returns the subject of the authenticated request
+func Subject(ctx context.Context) (string, bool) {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return claims.Subject, true
+}
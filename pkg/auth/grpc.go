@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewUnaryServerInterceptor authenticates every call with the bearer token of its
+// authorization metadata before handing it to the handler.
+func NewUnaryServerInterceptor(v *Verifier) grpc.UnaryServerInterceptor {
+	toContext := kitjwt.GRPCToContext()
+
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx, err := authenticate(toContext(ctx, md), v)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// NewParser creates a go-kit endpoint middleware verifying the token put in the
+// context by kitjwt.HTTPToContext and passing its claims on to the endpoint.
+func NewParser(v *Verifier) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			ctx, err := authenticate(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// NewHTTPHandler authenticates every request before handing it to the next handler,
+// failures are written by the error encoder.
+func NewHTTPHandler(v *Verifier, next http.Handler, encode kithttp.ErrorEncoder) http.Handler {
+	toContext := kitjwt.HTTPToContext()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authenticate(toContext(r.Context(), r), v)
+		if err != nil {
+			encode(r.Context(), err, w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func authenticate(ctx context.Context, v *Verifier) (context.Context, error) {
+	token, ok := ctx.Value(kitjwt.JWTContextKey).(string)
+	if !ok || token == "" {
+		return ctx, errors.NewErrorf(errors.ErrCodeUnauthenticated, "missing bearer token")
+	}
+
+	claims, err := v.Verify(token)
+	if err != nil {
+		return ctx, err
+	}
+	return NewContext(ctx, claims), nil
+}
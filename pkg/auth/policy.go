@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+const (
+	// RoleAdmin is granted every operation, also accepted as a scope
+	RoleAdmin = "admin"
+)
+
+// Policy grants an operation to the callers holding any of its scopes or roles. A policy
+// without scopes and roles grants the operation to every authenticated caller.
+type Policy struct {
+	Scopes []string
+	Roles  []string
+}
+
+// Authorize checks the policy against the caller of the context
+func (p Policy) Authorize(ctx context.Context) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnauthenticated, "missing credentials")
+	}
+	if claims.IsAdmin() || (len(p.Scopes) == 0 && len(p.Roles) == 0) {
+		return nil
+	}
+	for _, scope := range p.Scopes {
+		if claims.HasScope(scope) {
+			return nil
+		}
+	}
+	for _, role := range p.Roles {
+		if claims.HasRole(role) {
+			return nil
+		}
+	}
+	return errors.NewErrorf(errors.ErrCodeForbidden, "operation requires one of the scopes %v or roles %v", p.Scopes, p.Roles)
+}
+
+// AuthorizeListing checks the policy of a listing, only admins list soft deleted records
+func (p Policy) AuthorizeListing(ctx context.Context, q pagination.Query) error {
+	if err := p.Authorize(ctx); err != nil {
+		return err
+	}
+	if q.IncludeDeleted && !IsAdmin(ctx) {
+		return errors.NewErrorf(errors.ErrCodeForbidden, "only admins list deleted records")
+	}
+	return nil
+}
+
+// Allows tells whether the policy grants the operation to the caller of the context
+func (p Policy) Allows(ctx context.Context) bool {
+	return p.Authorize(ctx) == nil
+}
+
+// HasScope tells whether the scope was granted to the token
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range strings.Fields(c.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole tells whether the role was granted to the token
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin tells whether the token was granted administrative access
+func (c *Claims) IsAdmin() bool {
+	return c.HasRole(RoleAdmin) || c.HasScope(RoleAdmin)
+}
+
+// SubjectID returns the subject of the authenticated request as a user id
+func SubjectID(ctx context.Context) (uuid.UUID, error) {
+	subject, ok := Subject(ctx)
+	if !ok {
+		return uuid.Nil, errors.NewErrorf(errors.ErrCodeUnauthenticated, "missing credentials")
+	}
+	id, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, errors.WrapErrorf(err, errors.ErrCodeForbidden, "subject %s is not a user", subject)
+	}
+	return id, nil
+}
+
+// IsAdmin tells whether the caller of the context was granted administrative access
+func IsAdmin(ctx context.Context) bool {
+	claims, ok := FromContext(ctx)
+	return ok && claims.IsAdmin()
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/pagination"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	withClaims := func(scope string, roles ...string) context.Context {
+		return NewContext(context.Background(), &Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d"},
+			Scope:            scope,
+			Roles:            roles,
+		})
+	}
+	write := Policy{Scopes: []string{"course:write"}, Roles: []string{"instructor"}}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		ctx      context.Context
+		wantCode errors.ErrorCode
+		wantErr  bool
+	}{
+		{name: "anonymous caller", policy: Policy{}, ctx: context.Background(), wantCode: errors.ErrCodeUnauthenticated, wantErr: true},
+		{name: "authenticated caller", policy: Policy{}, ctx: withClaims("")},
+		{name: "granted scope", policy: write, ctx: withClaims("course:read course:write")},
+		{name: "granted role", policy: write, ctx: withClaims("", "instructor")},
+		{name: "admin role", policy: write, ctx: withClaims("", RoleAdmin)},
+		{name: "admin scope", policy: write, ctx: withClaims(RoleAdmin)},
+		{name: "missing scope", policy: write, ctx: withClaims("course:read", "student"), wantCode: errors.ErrCodeForbidden, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.Authorize(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authorize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if ierr, ok := err.(*errors.Error); tt.wantErr && (!ok || ierr.Code() != tt.wantCode) {
+				t.Errorf("Authorize() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+
+	q := pagination.NewQuery()
+	q.IncludeDeleted = true
+	if err := (Policy{}).AuthorizeListing(withClaims(""), q); err == nil {
+		t.Errorf("AuthorizeListing() expected an error listing deleted records without admin access")
+	}
+	if err := (Policy{}).AuthorizeListing(withClaims("", RoleAdmin), q); err != nil {
+		t.Errorf("AuthorizeListing() unexpected error = %v", err)
+	}
+}
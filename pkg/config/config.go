@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sherifabdlnaby/configuro"
@@ -11,8 +12,19 @@ import (
 type Config struct {
 	Server struct {
 		HTTP *Server `validate:"required"`
+		GRPC *Server
 	} `validate:"required"`
-	Database *Database `validate:"required"`
+	Database    *Database `validate:"required"`
+	Retention   *Retention
+	Rules       *Rules
+	Auth        *Auth
+	Tenancy     *Tenancy
+	Idempotency *Idempotency
+	Clients     *Clients
+	Outbox      *Outbox
+	Webhooks    *Webhooks
+	Tracing     *Tracing
+	Health      *Health
 }
 
 // Database config struct
@@ -23,6 +35,119 @@ type Database struct {
 	Username string `validate:"required"`
 	Password string `validate:"required"`
 	Database string `validate:"required"`
+	Timeouts *Timeouts
+}
+
+// Timeouts config struct, the statements of an operation are cancelled after its own timeout or
+// else the default one. The operations are named after their statements, e.g. "list courses".
+type Timeouts struct {
+	Default    time.Duration `validate:"gte=0"`
+	Operations map[string]time.Duration
+}
+
+// Auth config struct, tokens are verified against the HMAC secret and the keys of the JWKS files
+type Auth struct {
+	Issuer    string
+	Audience  string
+	Secret    string
+	JWKSFiles []string `config:"jwks_files"`
+}
+
+// Tenancy config struct, the tenant of a request is read from the header unless the token names it
+type Tenancy struct {
+	Header  string
+	Default string
+}
+
+// Idempotency config struct, the responses to keyed requests are replayed during the window
+type Idempotency struct {
+	Window time.Duration `validate:"required"`
+}
+
+// Clients config struct
+type Clients struct {
+	Course *CourseClient
+}
+
+// CourseClient config struct, the matrix service calls the course service at the address
+// instead of the one running in the same process. Zero retries and cache TTL disable them.
+type CourseClient struct {
+	Address     string        `validate:"required"`
+	Timeout     time.Duration `validate:"required"`
+	Retries     int           `validate:"gte=0"`
+	Backoff     time.Duration
+	MaxFailures int           `config:"max_failures" validate:"gt=0"`
+	OpenTimeout time.Duration `config:"open_timeout" validate:"required"`
+	CacheTTL    time.Duration `config:"cache_ttl"`
+}
+
+// Outbox config struct, the relay publishes the events of the outbox every interval. An event
+// is parked once it failed the max attempts, so it doesn't hold back the next ones anymore.
+type Outbox struct {
+	Interval    time.Duration `validate:"required"`
+	BatchSize   int           `config:"batch_size" validate:"gt=0"`
+	MaxAttempts int           `config:"max_attempts" validate:"gt=0"`
+	Publisher   string        `validate:"oneof=memory nats"`
+	NATS        *NATS
+}
+
+// NATS config struct, the events are published under the subject prefix followed by their type
+type NATS struct {
+	URL     string `validate:"required"`
+	Subject string
+}
+
+// Webhooks config struct, the due deliveries are sent every interval. The wait before retrying
+// a failed delivery starts at the backoff and doubles after every failure, the delivery is dead
+// after the max attempts. The lease holding the claimed deliveries should outlast sending a batch
+// of them to unresponsive webhooks.
+type Webhooks struct {
+	Interval    time.Duration `validate:"required"`
+	Timeout     time.Duration `validate:"required"`
+	MaxAttempts int           `config:"max_attempts" validate:"gt=0"`
+	Backoff     time.Duration `validate:"required"`
+	Lease       time.Duration `validate:"required"`
+	BatchSize   int           `config:"batch_size" validate:"gt=0"`
+}
+
+// Tracing config struct, the spans are exported to an OTLP collector over gRPC or written to the
+// stdout for local runs. The endpoint defaults to localhost:4317. The sample ratio applies to the
+// traces started by the service.
+type Tracing struct {
+	Exporter    string `validate:"oneof=otlp stdout"`
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64 `config:"sample_ratio" validate:"gt=0,lte=1"`
+	ServiceName string  `config:"service_name" validate:"required"`
+}
+
+// Health config struct, the readiness checks run for at most the timeout and the migrations folder
+// holds the newest migration the database must be at. On shutdown, the service keeps serving for
+// the drain delay once it isn't ready anymore.
+type Health struct {
+	Timeout    time.Duration `validate:"required"`
+	Migrations string        `validate:"required"`
+	DrainDelay time.Duration `config:"drain_delay" validate:"gte=0"`
+}
+
+// Retention config struct
+type Retention struct {
+	Period   time.Duration `validate:"required"`
+	Interval time.Duration `validate:"required"`
+}
+
+// Rules config struct
+type Rules struct {
+	Matrix *MatrixRules
+}
+
+// MatrixRules config struct, a zero value disables the rule
+type MatrixRules struct {
+	MinCredits         float32 `config:"min_credits" validate:"gte=0"`
+	MaxCredits         float32 `config:"max_credits" validate:"gte=0"`
+	MinRequiredCredits float32 `config:"min_required_credits" validate:"gte=0"`
+	MaxTermCredits     float32 `config:"max_term_credits" validate:"gte=0"`
+	MaxTermWorkload    float32 `config:"max_term_workload" validate:"gte=0"`
 }
 
 // Server config struct
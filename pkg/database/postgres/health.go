@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// statements records whether each statement of the repositories was prepared
+var statements = struct {
+	sync.Mutex
+	prepared map[string]bool
+}{prepared: make(map[string]bool)}
+
+// Prepare prepares the statement of a repository and records whether it was created, for the
+// readiness check
+func Prepare(db *sqlx.DB, name, query string) (*sqlx.Stmt, error) {
+	stmt, err := db.Preparex(query)
+
+	statements.Lock()
+	defer statements.Unlock()
+	statements.prepared[name] = err == nil
+	return stmt, err
+}
+
+// CheckStatements fails unless every statement of the repositories was prepared
+func CheckStatements(_ context.Context) error {
+	statements.Lock()
+	defer statements.Unlock()
+
+	if len(statements.prepared) == 0 {
+		return fmt.Errorf("no statement was prepared")
+	}
+	var missing []string
+	for name, ok := range statements.prepared {
+		if !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("statements not prepared: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// NewestMigration returns the version of the newest migration in the folder, the version
+// prefixing the name of its files
+func NewestMigration(folder string) (uint64, error) {
+	files, err := filepath.Glob(filepath.Join(folder, "*.up.sql"))
+	if err != nil {
+		return 0, err
+	}
+
+	var newest uint64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file %s: %w", file, err)
+		}
+		if version > newest {
+			newest = version
+		}
+	}
+	if newest == 0 {
+		return 0, fmt.Errorf("no migration found in %s: %w", folder, os.ErrNotExist)
+	}
+	return newest, nil
+}
+
+// CheckMigrations fails unless the database was migrated up to the given version, without
+// leaving the last migration dirty
+func CheckMigrations(db *sqlx.DB, want uint64) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var migration struct {
+			Version uint64 `db:"version"`
+			Dirty   bool   `db:"dirty"`
+		}
+		if err := db.GetContext(ctx, &migration, "SELECT version, dirty FROM schema_migrations LIMIT 1"); err != nil {
+			return fmt.Errorf("error getting the migration version: %w", err)
+		}
+		if migration.Dirty {
+			return fmt.Errorf("migration %d is dirty", migration.Version)
+		}
+		if migration.Version != want {
+			return fmt.Errorf("migration version is %d, want %d", migration.Version, want)
+		}
+		return nil
+	}
+}
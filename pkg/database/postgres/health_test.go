@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/sumelms/microservice-course/tests/database"
+)
+
+func TestNewestMigration(t *testing.T) {
+	folder := t.TempDir()
+	for _, name := range []string{
+		"1663000000_create_courses_table.up.sql",
+		"1663000000_create_courses_table.down.sql",
+		"1792301245_create_webhook_deliveries_table.up.sql",
+		"1792301245_create_webhook_deliveries_table.down.sql",
+		"1700000000_create_matrices_table.up.sql",
+	} {
+		if err := os.WriteFile(filepath.Join(folder, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := NewestMigration(folder)
+	if err != nil {
+		t.Fatalf("NewestMigration() error = %v", err)
+	}
+	if got != 1792301245 {
+		t.Errorf("NewestMigration() got = %d, want %d", got, 1792301245)
+	}
+
+	if _, err := NewestMigration(t.TempDir()); err == nil {
+		t.Errorf("NewestMigration() expected an error for a folder without migrations")
+	}
+}
+
+func TestCheckMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version uint64
+		dirty   bool
+		wantErr bool
+	}{
+		{name: "up to date", version: 1792301245},
+		{name: "behind", version: 1792300325, wantErr: true},
+		{name: "dirty", version: 1792301245, dirty: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := database.NewDBMock()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT version, dirty FROM schema_migrations")).
+				WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(tt.version, tt.dirty))
+
+			if err := CheckMigrations(db, 1792301245)(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("CheckMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/metrics"
+	"github.com/sumelms/microservice-course/pkg/tracing"
+)
+
+// timeouts bounds the statements of the repositories, they aren't bounded until it is set
+var timeouts atomic.Pointer[config.Timeouts]
+
+// SetTimeouts bounds the statements started from now on with the configured timeouts
+func SetTimeouts(cfg *config.Timeouts) {
+	timeouts.Store(cfg)
+}
+
+// Timeout returns the timeout of the statements of the operation, zero when they aren't bounded
+func Timeout(name string) time.Duration {
+	cfg := timeouts.Load()
+	if cfg == nil {
+		return 0
+	}
+	if d, ok := cfg.Operations[name]; ok {
+		return d
+	}
+	return cfg.Default
+}
+
+// StartStatement starts the span of the prepared statement, a child of the one of the request,
+// and bounds the context by the timeout of the operation. The returned func ends the span,
+// observes the latency of the statement and releases the context.
+func StartStatement(ctx context.Context, name string) (context.Context, func()) {
+	begin := time.Now()
+	cancel := func() {}
+	if d := Timeout(name); d > 0 {
+		ctx, cancel = context.WithTimeout(ctx, d)
+	}
+
+	ctx, span := tracing.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", name),
+		))
+	return ctx, func() {
+		metrics.ObserveStatement(name, begin)
+		span.End()
+		cancel()
+	}
+}
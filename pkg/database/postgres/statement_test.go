@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sumelms/microservice-course/pkg/config"
+)
+
+func TestStartStatement(t *testing.T) {
+	SetTimeouts(&config.Timeouts{
+		Default:    5 * time.Second,
+		Operations: map[string]time.Duration{"list courses": 10 * time.Second, "purge deleted courses": 0},
+	})
+	defer SetTimeouts(nil)
+
+	tests := []struct {
+		name string
+		want time.Duration
+	}{
+		{name: "get course by uuid", want: 5 * time.Second},
+		{name: "list courses", want: 10 * time.Second},
+		{name: "purge deleted courses", want: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, end := StartStatement(context.Background(), tt.name)
+
+			deadline, ok := ctx.Deadline()
+			if ok != (tt.want > 0) {
+				t.Fatalf("StartStatement() bounded = %v, want %v", ok, tt.want > 0)
+			}
+			if ok && time.Until(deadline) > tt.want {
+				t.Errorf("StartStatement() deadline in %v, want at most %v", time.Until(deadline), tt.want)
+			}
+
+			end()
+			if ok && ctx.Err() != context.Canceled {
+				t.Errorf("the context isn't released once the statement ended, got %v", ctx.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes translated into domain codes
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqCheckViolation      = "23514"
+	pqQueryCanceled       = "57014"
+)
+
+// WrapDatabaseErrorf wraps an error returned by the database, the code follows what went
+// wrong: a missing row is not found, a unique violation conflicts with an existing record
+// and foreign key or check violations are invalid arguments. A statement cancelled when its
+// context or its statement timeout expired timed out. Anything else is unknown.
+func WrapDatabaseErrorf(original error, format string, a ...interface{}) error {
+	return WrapErrorf(original, DatabaseCode(original), format, a...)
+}
+
+// DatabaseCode returns the error code matching the database error
+func DatabaseCode(err error) ErrorCode {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCodeNotFound
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrCodeTimeout
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ErrCodeUnknown
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return ErrCodeConflict
+	case pqForeignKeyViolation, pqCheckViolation:
+		return ErrCodeInvalidArgument
+	case pqQueryCanceled:
+		return ErrCodeTimeout
+	}
+	return ErrCodeUnknown
+}
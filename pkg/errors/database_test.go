@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestDatabaseCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{name: "no rows", err: sql.ErrNoRows, want: ErrCodeNotFound},
+		{name: "wrapped no rows", err: fmt.Errorf("scan: %w", sql.ErrNoRows), want: ErrCodeNotFound},
+		{name: "unique violation", err: &pq.Error{Code: "23505"}, want: ErrCodeConflict},
+		{name: "foreign key violation", err: &pq.Error{Code: "23503"}, want: ErrCodeInvalidArgument},
+		{name: "check violation", err: &pq.Error{Code: "23514"}, want: ErrCodeInvalidArgument},
+		{name: "query canceled", err: &pq.Error{Code: "57014"}, want: ErrCodeTimeout},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: ErrCodeTimeout},
+		{name: "other postgres error", err: &pq.Error{Code: "22001"}, want: ErrCodeUnknown},
+		{name: "driver error", err: sql.ErrConnDone, want: ErrCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DatabaseCode(tt.err); got != tt.want {
+				t.Errorf("DatabaseCode() got = %v, want %v", got, tt.want)
+			}
+			if got := WrapDatabaseErrorf(tt.err, "error").(*Error).Code(); got != tt.want {
+				t.Errorf("WrapDatabaseErrorf() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,75 +6,138 @@ import (
 	"errors"
 	"net/http"
 
+	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-playground/validator/v10"
+
+	appvalidator "github.com/sumelms/microservice-course/pkg/validator"
 )
 
-type ErrorResponse struct {
-	Message any `json:"message"`
-	Error   any `json:"error"`
+// ProblemContentType is the media type of the error responses, as defined by RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of an error response. The internal error chain is never written,
+// it only reaches the logs.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          string         `json:"code"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
 }
 
-type ValidatorError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
+// InvalidParam tells which field of the request failed the validation and why
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
 }
 
 // EncodeError encodes errors from business-logic
-func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
-	resp := &ErrorResponse{
-		Message: "Invalid error",
-		Error:   err.Error(),
+func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	p := NewProblem(ctx, err)
+	if path, ok := ctx.Value(kithttp.ContextKeyRequestPath).(string); ok {
+		p.Instance = path
+	}
+
+	content, err := json.Marshal(p)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
-	code := http.StatusInternalServerError
+	if p.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
 
+	if _, err := w.Write(content); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// NewProblem describes the error without leaking its internal details, the validation
+// messages are written in the language of the request
+func NewProblem(ctx context.Context, err error) Problem {
 	var ve validator.ValidationErrors
 	if errors.As(err, &ve) {
-		msg := make([]ValidatorError, len(ve))
+		trans := appvalidator.TranslatorFromContext(ctx)
+		params := make([]InvalidParam, len(ve))
 		for i, fe := range ve {
-			msg[i] = ValidatorError{
-				Field:   fe.Field(),
-				Message: msgForTag(fe.Tag()),
+			params[i] = InvalidParam{
+				Name:   fe.Field(),
+				Reason: fe.Translate(trans),
 			}
 		}
-		resp.Message = "invalid input"
-		resp.Message = msg
-		code = http.StatusBadRequest
+		return newProblem(ErrCodeInvalidArgument, "the request has invalid fields", params)
 	}
 
 	var ierr *Error
-	if errors.As(err, &ierr) {
-		switch ierr.Code() {
-		case ErrCodeNotFound:
-			code = http.StatusNotFound
-		case ErrCodeInvalidArgument:
-			code = http.StatusBadRequest
-		case ErrCodeUnknown:
-			code = http.StatusInternalServerError
-		}
+	if errors.As(err, &ierr) && ierr.code != ErrCodeUnknown {
+		return newProblem(ierr.code, ierr.msg, nil)
 	}
+	return newProblem(ErrCodeUnknown, "", nil)
+}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-
-	content, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+func newProblem(code ErrorCode, detail string, params []InvalidParam) Problem {
+	status := code.HTTPStatus()
+	return Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        detail,
+		Code:          code.String(),
+		InvalidParams: params,
 	}
+}
 
-	w.WriteHeader(code)
-
-	if _, err := w.Write(content); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+// String returns the machine-readable name of the code
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrCodeNotFound:
+		return "not_found"
+	case ErrCodeInvalidArgument:
+		return "invalid_argument"
+	case ErrCodeUnauthenticated:
+		return "unauthenticated"
+	case ErrCodeForbidden:
+		return "forbidden"
+	case ErrCodeConflict:
+		return "conflict"
+	case ErrCodePreconditionFailed:
+		return "precondition_failed"
+	case ErrCodeUnprocessable:
+		return "unprocessable"
+	case ErrCodeUnavailable:
+		return "unavailable"
+	case ErrCodeTimeout:
+		return "timeout"
 	}
+	return "internal"
 }
 
-func msgForTag(tag string) string {
-	switch tag {
-	case "required":
-		return "This field is required"
-	case "email":
-		return "Invalid email"
+// HTTPStatus returns the HTTP status matching the code
+func (c ErrorCode) HTTPStatus() int {
+	switch c {
+	case ErrCodeNotFound:
+		return http.StatusNotFound
+	case ErrCodeInvalidArgument:
+		return http.StatusBadRequest
+	case ErrCodeUnauthenticated:
+		return http.StatusUnauthorized
+	case ErrCodeForbidden:
+		return http.StatusForbidden
+	case ErrCodeConflict:
+		return http.StatusConflict
+	case ErrCodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case ErrCodeUnprocessable:
+		return http.StatusUnprocessableEntity
+	case ErrCodeUnavailable:
+		return http.StatusServiceUnavailable
+	case ErrCodeTimeout:
+		return http.StatusGatewayTimeout
 	}
-	return ""
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	appvalidator "github.com/sumelms/microservice-course/pkg/validator"
+)
+
+func TestEncodeError(t *testing.T) {
+	type request struct {
+		Code string `json:"code" validate:"required"`
+		Name string `json:"name" validate:"max=3"`
+	}
+	invalid := appvalidator.NewValidator().Validate(request{Name: "too long"})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		language   string
+		wantParams []InvalidParam
+	}{
+		{
+			name:       "not found",
+			err:        fmt.Errorf("service can't find course: %w", WrapDatabaseErrorf(sql.ErrNoRows, "error getting course")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "not_found",
+			wantDetail: "error getting course",
+		},
+		{
+			name:       "internal error",
+			err:        fmt.Errorf("service can't find course: %w", WrapErrorf(sql.ErrConnDone, ErrCodeUnknown, "error getting course")),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal",
+		},
+		{
+			name:       "statement timeout",
+			err:        fmt.Errorf("service can't list courses: %w", WrapDatabaseErrorf(context.DeadlineExceeded, "error getting courses")),
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   "timeout",
+			wantDetail: "error getting courses",
+		},
+		{
+			name:       "validation error",
+			err:        invalid,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_argument",
+			wantDetail: "the request has invalid fields",
+			wantParams: []InvalidParam{
+				{Name: "code", Reason: "This field is required"},
+				{Name: "name", Reason: "name must be a maximum of 3 characters in length"},
+			},
+		},
+		{
+			name:       "localized validation error",
+			err:        invalid,
+			language:   "pt-BR,pt;q=0.9,en;q=0.8",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_argument",
+			wantDetail: "the request has invalid fields",
+			wantParams: []InvalidParam{
+				{Name: "code", Reason: "Este campo é obrigatório"},
+				{Name: "name", Reason: "name deve ter no máximo 3 caracteres"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.WithValue(context.Background(), kithttp.ContextKeyRequestPath, "/courses/1")
+			ctx = appvalidator.NewContext(ctx, appvalidator.Translator(tt.language))
+			w := httptest.NewRecorder()
+			EncodeError(ctx, tt.err, w)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("EncodeError() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("EncodeError() content type = %v, want %v", ct, ProblemContentType)
+			}
+			if strings.Contains(w.Body.String(), "service can't") {
+				t.Errorf("EncodeError() leaked the error chain: %s", w.Body.String())
+			}
+
+			var got Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("EncodeError() invalid body: %v", err)
+			}
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Detail != tt.wantDetail || got.Instance != "/courses/1" {
+				t.Errorf("EncodeError() got = %+v", got)
+			}
+			if fmt.Sprint(got.InvalidParams) != fmt.Sprint(tt.wantParams) {
+				t.Errorf("EncodeError() invalid params = %v, want %v", got.InvalidParams, tt.wantParams)
+			}
+		})
+	}
+}
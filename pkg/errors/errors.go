@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type Error struct {
 	original error
@@ -14,6 +17,13 @@ const (
 	ErrCodeUnknown ErrorCode = iota
 	ErrCodeNotFound
 	ErrCodeInvalidArgument
+	ErrCodeUnauthenticated
+	ErrCodeForbidden
+	ErrCodeConflict
+	ErrCodePreconditionFailed
+	ErrCodeUnprocessable
+	ErrCodeUnavailable
+	ErrCodeTimeout
 )
 
 func WrapErrorf(original error, code ErrorCode, format string, a ...interface{}) error {
@@ -43,3 +53,12 @@ func (e *Error) Unwrap() error {
 func (e *Error) Code() ErrorCode {
 	return e.code
 }
+
+// CodeOf returns the code of the error wrapped in err, unknown when there is none
+func CodeOf(err error) ErrorCode {
+	var ierr *Error
+	if errors.As(err, &ierr) {
+		return ierr.code
+	}
+	return ErrCodeUnknown
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCCode returns the gRPC status code matching the code
+func (c ErrorCode) GRPCCode() codes.Code {
+	switch c {
+	case ErrCodeNotFound:
+		return codes.NotFound
+	case ErrCodeInvalidArgument, ErrCodeUnprocessable:
+		return codes.InvalidArgument
+	case ErrCodeUnauthenticated:
+		return codes.Unauthenticated
+	case ErrCodeForbidden:
+		return codes.PermissionDenied
+	case ErrCodeConflict:
+		return codes.AlreadyExists
+	case ErrCodePreconditionFailed:
+		return codes.FailedPrecondition
+	case ErrCodeUnavailable:
+		return codes.Unavailable
+	case ErrCodeTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
+
+// DecodeGRPCError turns the status returned by a gRPC call back into an error with the
+// matching code, the message of the status is kept
+func DecodeGRPCError(err error) error {
+	s, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+
+	code := ErrCodeUnknown
+	switch s.Code() {
+	case codes.NotFound:
+		code = ErrCodeNotFound
+	case codes.InvalidArgument:
+		code = ErrCodeInvalidArgument
+	case codes.Unauthenticated:
+		code = ErrCodeUnauthenticated
+	case codes.PermissionDenied:
+		code = ErrCodeForbidden
+	case codes.AlreadyExists:
+		code = ErrCodeConflict
+	case codes.FailedPrecondition:
+		code = ErrCodePreconditionFailed
+	case codes.Unavailable:
+		code = ErrCodeUnavailable
+	case codes.DeadlineExceeded:
+		code = ErrCodeTimeout
+	}
+	return WrapErrorf(err, code, "%s", s.Message())
+}
+
+// EncodeGRPCError turns the error into a gRPC status carrying the same details as the
+// problem written over HTTP, errors that already are a status are kept
+func EncodeGRPCError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := ErrCodeUnknown
+	var ierr *Error
+	if errors.As(err, &ierr) {
+		code = ierr.code
+	}
+
+	p := NewProblem(ctx, err)
+	if len(p.InvalidParams) > 0 {
+		code = ErrCodeInvalidArgument
+	}
+
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	if len(p.InvalidParams) > 0 {
+		reasons := make([]string, len(p.InvalidParams))
+		for i, param := range p.InvalidParams {
+			reasons[i] = param.Name + ": " + param.Reason
+		}
+		msg += ": " + strings.Join(reasons, ", ")
+	}
+	return status.Error(code.GRPCCode(), msg)
+}
+
+// UnaryServerInterceptor encodes the errors of every call, it must be the first interceptor
+// so the failures of the authentication and the tenant resolution are encoded too
+func UnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, EncodeGRPCError(ctx, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	appvalidator "github.com/sumelms/microservice-course/pkg/validator"
+)
+
+func TestEncodeGRPCError(t *testing.T) {
+	type request struct {
+		Code string `json:"code" validate:"required"`
+	}
+	invalid := appvalidator.NewValidator().Validate(request{})
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:        "not found",
+			err:         fmt.Errorf("service can't find course: %w", WrapDatabaseErrorf(sql.ErrNoRows, "error getting course")),
+			wantCode:    codes.NotFound,
+			wantMessage: "error getting course",
+		},
+		{name: "conflict", err: NewErrorf(ErrCodeConflict, "subject already added"), wantCode: codes.AlreadyExists},
+		{name: "precondition", err: NewErrorf(ErrCodePreconditionFailed, "version 2 doesn't match"), wantCode: codes.FailedPrecondition},
+		{name: "forbidden", err: NewErrorf(ErrCodeForbidden, "missing scope"), wantCode: codes.PermissionDenied},
+		{name: "validation", err: invalid, wantCode: codes.InvalidArgument, wantMessage: "code: This field is required"},
+		{name: "unknown", err: fmt.Errorf("connection refused"), wantCode: codes.Internal, wantMessage: "Internal Server Error"},
+		{name: "status kept", err: status.Error(codes.Unavailable, "unavailable"), wantCode: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := status.FromError(EncodeGRPCError(context.Background(), tt.err))
+			if s.Code() != tt.wantCode {
+				t.Errorf("EncodeGRPCError() code = %v, want %v", s.Code(), tt.wantCode)
+			}
+			if !strings.Contains(s.Message(), tt.wantMessage) {
+				t.Errorf("EncodeGRPCError() message = %v, want %v", s.Message(), tt.wantMessage)
+			}
+			if strings.Contains(s.Message(), "connection refused") {
+				t.Errorf("EncodeGRPCError() leaked the internal error: %v", s.Message())
+			}
+		})
+	}
+}
+
+func TestDecodeGRPCError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode ErrorCode
+	}{
+		{name: "not found", err: status.Error(codes.NotFound, "course not found"), wantCode: ErrCodeNotFound},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "missing scope"), wantCode: ErrCodeForbidden},
+		{name: "deadline exceeded", err: status.Error(codes.DeadlineExceeded, "deadline exceeded"), wantCode: ErrCodeTimeout},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "connection refused"), wantCode: ErrCodeUnavailable},
+		{name: "internal", err: status.Error(codes.Internal, "Internal Server Error"), wantCode: ErrCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := DecodeGRPCError(tt.err)
+			ierr, ok := err.(*Error)
+			if !ok || ierr.Code() != tt.wantCode {
+				t.Errorf("DecodeGRPCError() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package etag
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+type contextKey int
+
+const (
+	versionContextKey contextKey = iota
+	noneMatchContextKey
+	changeNoneMatchContextKey
+)
+
+// mismatch is the expected version of a malformed If-Match, no record ever has it
+const mismatch = -1
+
+// Format returns the entity tag of the version
+func Format(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// Parse returns the version of the entity tag, weak tags are accepted
+func Parse(tag string) (int, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	s, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, false
+	}
+	version, err := strconv.Atoi(s)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// Match tells whether the header, a list of entity tags or *, matches the version
+func Match(header string, version int) bool {
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == "*" {
+			return true
+		}
+		if v, ok := Parse(tag); ok && v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// NewContext returns a context carrying the version the record must have to be changed
+func NewContext(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, versionContextKey, version)
+}
+
+// Expected returns the version the record must have to be changed, zero when any will do
+func Expected(ctx context.Context) int {
+	version, _ := ctx.Value(versionContextKey).(int)
+	return version
+}
+
+// Check fails when the context expects another version than the current one
+func Check(ctx context.Context, version int) error {
+	if expected := Expected(ctx); expected != 0 && expected != version {
+		return errors.NewErrorf(errors.ErrCodePreconditionFailed, "version %d doesn't match the current one", expected)
+	}
+	return nil
+}
+
+// CheckNoneMatch fails when the If-None-Match of a change matches the current version, which is
+// only loaded when the change has one. Every service write calls it before changing the record.
+func CheckNoneMatch(ctx context.Context, current func() (int, error)) error {
+	header, ok := ctx.Value(changeNoneMatchContextKey).(string)
+	if !ok {
+		return nil
+	}
+	version, err := current()
+	if err != nil {
+		return err
+	}
+	if Match(header, version) {
+		return errors.NewErrorf(errors.ErrCodePreconditionFailed, "version %d matches If-None-Match", version)
+	}
+	return nil
+}
+
+// PopulatePreconditions puts the conditional headers of the request in the context. Only the
+// first entity tag of If-Match is enforced, and * is left to the existence of the record. The
+// If-None-Match of a GET or HEAD answers 304 Not Modified, the one of any other method is a
+// precondition of the change, which fails with 412 Precondition Failed before anything changes.
+func PopulatePreconditions(ctx context.Context, r *http.Request) context.Context {
+	if header := strings.TrimSpace(r.Header.Get("If-Match")); header != "" && header != "*" {
+		version, ok := Parse(strings.Split(header, ",")[0])
+		if !ok {
+			version = mismatch
+		}
+		ctx = NewContext(ctx, version)
+	}
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			ctx = context.WithValue(ctx, noneMatchContextKey, header)
+		} else {
+			ctx = context.WithValue(ctx, changeNoneMatchContextKey, header)
+		}
+	}
+	return ctx
+}
+
+// EncodeJSONResponse writes the response along with the entity tag of its version. When
+// the If-None-Match of a GET or HEAD matches, only 304 Not Modified is written.
+func EncodeJSONResponse(ctx context.Context, w http.ResponseWriter, version int, response interface{}) error {
+	w.Header().Set("ETag", Format(version))
+
+	if header, ok := ctx.Value(noneMatchContextKey).(string); ok && Match(header, version) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return kithttp.EncodeJSONResponse(ctx, w, response)
+}
@@ -0,0 +1,186 @@
+package etag
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version int
+		want    bool
+	}{
+		{name: "same version", header: `"3"`, version: 3, want: true},
+		{name: "weak tag", header: `W/"3"`, version: 3, want: true},
+		{name: "list of tags", header: `"1", "3"`, version: 3, want: true},
+		{name: "wildcard", header: "*", version: 3, want: true},
+		{name: "other version", header: `"2"`, version: 3, want: false},
+		{name: "malformed tag", header: "3", version: 3, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Match(tt.header, tt.version); got != tt.want {
+				t.Errorf("Match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPopulatePreconditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+	}{
+		{name: "no header", want: 0},
+		{name: "wildcard", ifMatch: "*", want: 0},
+		{name: "entity tag", ifMatch: `"4"`, want: 4},
+		{name: "malformed tag", ifMatch: "four", want: mismatch},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/courses/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			if got := Expected(PopulatePreconditions(context.Background(), r)); got != tt.want {
+				t.Errorf("Expected() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  string
+		wantCode errors.ErrorCode
+	}{
+		{name: "no header", wantCode: errors.ErrCodeUnknown},
+		{name: "current if-match", ifMatch: `"2"`, wantCode: errors.ErrCodeUnknown},
+		{name: "stale if-match", ifMatch: `"1"`, wantCode: errors.ErrCodePreconditionFailed},
+		{name: "malformed if-match", ifMatch: "2", wantCode: errors.ErrCodePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPut, "/courses/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			err := Check(PopulatePreconditions(context.Background(), r), 2)
+			if (err != nil) != (tt.wantCode != errors.ErrCodeUnknown) || (err != nil && errors.CodeOf(err) != tt.wantCode) {
+				t.Errorf("Check() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCheckNoneMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantLoaded  bool
+		wantCode    errors.ErrorCode
+	}{
+		{name: "no header", method: http.MethodPut, wantCode: errors.ErrCodeUnknown},
+		{name: "stale tag", method: http.MethodPut, ifNoneMatch: `"1"`, wantLoaded: true, wantCode: errors.ErrCodeUnknown},
+		{
+			name: "current tag on update", method: http.MethodPut, ifNoneMatch: `"2"`, wantLoaded: true,
+			wantCode: errors.ErrCodePreconditionFailed,
+		},
+		{
+			name: "current tag on patch", method: http.MethodPatch, ifNoneMatch: `"1", "2"`, wantLoaded: true,
+			wantCode: errors.ErrCodePreconditionFailed,
+		},
+		{
+			name: "wildcard on delete", method: http.MethodDelete, ifNoneMatch: "*", wantLoaded: true,
+			wantCode: errors.ErrCodePreconditionFailed,
+		},
+		{name: "current tag of a read", method: http.MethodGet, ifNoneMatch: `"2"`, wantCode: errors.ErrCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(tt.method, "/courses/1", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+
+			loaded := false
+			err := CheckNoneMatch(PopulatePreconditions(context.Background(), r), func() (int, error) {
+				loaded = true
+				return 2, nil
+			})
+			if loaded != tt.wantLoaded {
+				t.Errorf("CheckNoneMatch() loaded the current version = %v, want %v", loaded, tt.wantLoaded)
+			}
+			if (err != nil) != (tt.wantCode != errors.ErrCodeUnknown) || (err != nil && errors.CodeOf(err) != tt.wantCode) {
+				t.Errorf("CheckNoneMatch() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestEncodeJSONResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "no header", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "stale tag", method: http.MethodGet, ifNoneMatch: `"1"`, wantStatus: http.StatusOK},
+		{name: "current tag", method: http.MethodGet, ifNoneMatch: `"2"`, wantStatus: http.StatusNotModified},
+		{name: "current tag on update", method: http.MethodPut, ifNoneMatch: `"2"`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(tt.method, "/courses/1", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			if err := EncodeJSONResponse(PopulatePreconditions(context.Background(), r), w, 2, map[string]string{"name": "course"}); err != nil {
+				t.Fatalf("EncodeJSONResponse() unexpected error = %v", err)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("EncodeJSONResponse() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != `"2"` {
+				t.Errorf("EncodeJSONResponse() ETag = %v, want %v", got, `"2"`)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("EncodeJSONResponse() wrote a body with 304: %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	statusOK      = "ok"
+	statusFailing = "failing"
+)
+
+// Check tells whether a dependency of the service is ready, it must return once the context is done
+type Check func(ctx context.Context) error
+
+// Result is the status of a check, along with its error when it fails
+type Result struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the body of the liveness and readiness responses
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the checks of the readiness probe. Once drained, the service isn't ready anymore
+// so the orchestrator stops routing it new requests before it shuts down.
+type Checker struct {
+	timeout  time.Duration
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+// NewChecker creates a checker whose checks run for at most the timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add adds the check to the readiness probe
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes the readiness probe fail from now on
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Check runs all the checks concurrently, the report fails when any of them does
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := Report{Status: statusOK, Checks: make(map[string]Result, len(c.checks)+1)}
+	if c.draining.Load() {
+		report.Status = statusFailing
+		report.Checks["shutdown"] = Result{Status: statusFailing, Error: "the service is shutting down"}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			result := Result{Status: statusOK}
+			if err := nc.check(ctx); err != nil {
+				result = Result{Status: statusFailing, Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = result
+			if result.Status == statusFailing {
+				report.Status = statusFailing
+			}
+		}(nc)
+	}
+	wg.Wait()
+	return report
+}
+
+// LiveHandler answers the liveness probe, the service is alive as long as it serves requests
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Report{Status: statusOK})
+	})
+}
+
+// ReadyHandler answers the readiness probe with the result of every check, the status is
+// 503 Service Unavailable when any of them fails
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Check(r.Context()))
+	})
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	status := http.StatusOK
+	if report.Status != statusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report) //nolint: errcheck
+}
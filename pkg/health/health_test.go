@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChecker_ReadyHandler(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		drain      bool
+		wantStatus int
+		want       Report
+	}{
+		{
+			name:       "ready",
+			checks:     map[string]Check{"postgres": ok, "statements": ok},
+			wantStatus: http.StatusOK,
+			want: Report{Status: "ok", Checks: map[string]Result{
+				"postgres":   {Status: "ok"},
+				"statements": {Status: "ok"},
+			}},
+		},
+		{
+			name:       "failing check",
+			checks:     map[string]Check{"postgres": failing, "statements": ok},
+			wantStatus: http.StatusServiceUnavailable,
+			want: Report{Status: "failing", Checks: map[string]Result{
+				"postgres":   {Status: "failing", Error: "connection refused"},
+				"statements": {Status: "ok"},
+			}},
+		},
+		{
+			name:       "check timed out",
+			checks:     map[string]Check{"migrations": slow},
+			wantStatus: http.StatusServiceUnavailable,
+			want: Report{Status: "failing", Checks: map[string]Result{
+				"migrations": {Status: "failing", Error: context.DeadlineExceeded.Error()},
+			}},
+		},
+		{
+			name:       "draining",
+			checks:     map[string]Check{"postgres": ok},
+			drain:      true,
+			wantStatus: http.StatusServiceUnavailable,
+			want: Report{Status: "failing", Checks: map[string]Result{
+				"postgres": {Status: "ok"},
+				"shutdown": {Status: "failing", Error: "the service is shutting down"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewChecker(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				c.Add(name, check)
+			}
+			if tt.drain {
+				c.Drain()
+			}
+
+			w := httptest.NewRecorder()
+			c.ReadyHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("ReadyHandler() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var got Report
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("invalid report: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadyHandler() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_LiveHandler(t *testing.T) {
+	c := NewChecker(time.Second)
+	c.Add("postgres", func(context.Context) error { return errors.New("connection refused") })
+	c.Drain()
+
+	w := httptest.NewRecorder()
+	c.LiveHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("LiveHandler() status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
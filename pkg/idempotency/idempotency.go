@@ -0,0 +1,185 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+const (
+	// Header carries the key the client picked for the request
+	Header = "Idempotency-Key"
+	// ReplayedHeader marks the responses replayed from a previous request
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// Record is a request made with an idempotency key. It has no status while the request is
+// still being processed.
+type Record struct {
+	ID          uint      `json:"id"`
+	TenantID    string    `db:"tenant_id" json:"tenant_id"`
+	Owner       string    `json:"owner"`
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	Status      *int      `json:"status"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	Body        []byte    `json:"body"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// Store keeps the records of the keyed requests
+type Store interface {
+	// Reserve saves the record unless a live one holds the key, which is returned instead
+	Reserve(ctx context.Context, r *Record) (existing *Record, err error)
+	// Complete stores the response of the reserved record
+	Complete(ctx context.Context, r *Record) error
+	// Release frees the key of a request that failed, so it can be retried
+	Release(ctx context.Context, r *Record) error
+	// Purge deletes the records expired before the given time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Guard replays the response of a request repeated with the same idempotency key
+type Guard struct {
+	store  Store
+	window time.Duration
+	encode kithttp.ErrorEncoder
+}
+
+// NewGuard creates a guard keeping the responses for the window
+func NewGuard(store Store, window time.Duration, encode kithttp.ErrorEncoder) *Guard {
+	return &Guard{store: store, window: window, encode: encode}
+}
+
+// Handler wraps the handler of a create endpoint. Requests without a key go straight
+// through, and a nil guard leaves the handler as it is.
+func (g *Guard) Handler(next http.Handler) http.Handler {
+	if g == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		rec, err := g.newRecord(r, key)
+		if err != nil {
+			g.encode(ctx, err, w)
+			return
+		}
+
+		existing, err := g.store.Reserve(ctx, rec)
+		if err != nil {
+			g.encode(ctx, err, w)
+			return
+		}
+		if existing != nil {
+			g.replay(ctx, w, rec, existing)
+			return
+		}
+
+		rw := &recorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		if rw.status >= http.StatusInternalServerError {
+			_ = g.store.Release(ctx, rec)
+			return
+		}
+		rec.Status = &rw.status
+		rec.ContentType = rw.Header().Get("Content-Type")
+		rec.Body = rw.body.Bytes()
+		if err := g.store.Complete(ctx, rec); err != nil {
+			// A key reserved without its response would turn down the retries until the window is over
+			_ = g.store.Release(ctx, rec)
+		}
+	})
+}
+
+// PurgeDeleted permanently deletes the keys whose window is over
+func (g *Guard) PurgeDeleted(ctx context.Context, _ time.Duration) error {
+	if _, err := g.store.Purge(ctx, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newRecord scopes the key to the tenant and the caller, and fingerprints the request
+func (g *Guard) newRecord(r *http.Request, key string) (*Record, error) {
+	if len(key) > maxKeyLength {
+		return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "%s must have at most %d characters", Header, maxKeyLength)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "error reading the request")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	tenantID, _ := tenant.FromContext(r.Context())
+	owner, _ := auth.Subject(r.Context())
+
+	return &Record{
+		TenantID:    tenantID,
+		Owner:       owner,
+		Key:         key,
+		Fingerprint: fingerprint(r.Method, r.URL.Path, body),
+		ExpiresAt:   time.Now().Add(g.window),
+	}, nil
+}
+
+func (g *Guard) replay(ctx context.Context, w http.ResponseWriter, rec, existing *Record) {
+	switch {
+	case existing.Fingerprint != rec.Fingerprint:
+		g.encode(ctx, errors.NewErrorf(errors.ErrCodeUnprocessable, "%s was used with another request", Header), w)
+	case existing.Status == nil:
+		g.encode(ctx, errors.NewErrorf(errors.ErrCodeConflict, "the request with this %s is still being processed", Header), w)
+	default:
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set(ReplayedHeader, "true")
+		w.WriteHeader(*existing.Status)
+		_, _ = w.Write(existing.Body)
+	}
+}
+
+func fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder keeps a copy of the response written by the handler
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,201 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+	"github.com/sumelms/microservice-course/pkg/tenant"
+)
+
+type memoryStore struct {
+	mu           sync.Mutex
+	records      map[string]*Record
+	failComplete bool
+}
+
+func (s *memoryStore) Reserve(_ context.Context, r *Record) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.TenantID + "/" + r.Owner + "/" + r.Key
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(time.Now()) {
+		c := *existing
+		return &c, nil
+	}
+	c := *r
+	s.records[id] = &c
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failComplete {
+		return errors.NewErrorf(errors.ErrCodeUnavailable, "database is down")
+	}
+	c := *r
+	s.records[r.TenantID+"/"+r.Owner+"/"+r.Key] = &c
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, r.TenantID+"/"+r.Owner+"/"+r.Key)
+	return nil
+}
+
+func (s *memoryStore) Purge(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestGuard_Handler(t *testing.T) {
+	type request struct {
+		key        string
+		body       string
+		wantStatus int
+		wantReplay bool
+	}
+
+	tests := []struct {
+		name      string
+		status    int
+		requests  []request
+		wantCalls int
+	}{
+		{
+			name:   "without key",
+			status: http.StatusCreated,
+			requests: []request{
+				{body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+				{body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "replayed",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusCreated, wantReplay: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "other payload",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+				{key: "k1", body: `{"name":"other"}`, wantStatus: http.StatusUnprocessableEntity},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "other key",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+				{key: "k2", body: `{"name":"course"}`, wantStatus: http.StatusCreated},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "failure released",
+			status: http.StatusInternalServerError,
+			requests: []request{
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusInternalServerError},
+				{key: "k1", body: `{"name":"course"}`, wantStatus: http.StatusInternalServerError},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "key too long",
+			status: http.StatusCreated,
+			requests: []request{
+				{key: strings.Repeat("k", maxKeyLength+1), body: `{}`, wantStatus: http.StatusBadRequest},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"uuid":"1"}`))
+			})
+			guard := NewGuard(&memoryStore{records: map[string]*Record{}}, time.Hour, errors.EncodeError)
+			h := guard.Handler(next)
+
+			for i, req := range tt.requests {
+				r := httptest.NewRequest(http.MethodPost, "/courses", strings.NewReader(req.body))
+				r = r.WithContext(tenant.NewContext(r.Context(), tenant.DefaultTenant))
+				if req.key != "" {
+					r.Header.Set(Header, req.key)
+				}
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+
+				if w.Code != req.wantStatus {
+					t.Errorf("request %d status = %v, want %v", i, w.Code, req.wantStatus)
+				}
+				if got := w.Header().Get(ReplayedHeader) == "true"; got != req.wantReplay {
+					t.Errorf("request %d replayed = %v, want %v", i, got, req.wantReplay)
+				}
+				if req.wantReplay && w.Body.String() != `{"uuid":"1"}` {
+					t.Errorf("request %d replayed body = %s", i, w.Body.String())
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGuard_HandlerCompleteFailure(t *testing.T) {
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	})
+	store := &memoryStore{records: map[string]*Record{}, failComplete: true}
+	h := NewGuard(store, time.Hour, errors.EncodeError).Handler(next)
+
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodPost, "/courses", strings.NewReader(`{}`))
+		r = r.WithContext(tenant.NewContext(r.Context(), tenant.DefaultTenant))
+		r.Header.Set(Header, "k1")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("request %d status = %v, want %v", i, w.Code, http.StatusCreated)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %v, the key must be released when its response isn't stored", calls)
+	}
+}
+
+func TestGuard_HandlerNil(t *testing.T) {
+	var guard *Guard
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	if got := guard.Handler(next); got == nil {
+		t.Errorf("Handler() of a nil guard should return the handler")
+	}
+}
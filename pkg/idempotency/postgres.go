@@ -0,0 +1,138 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	reserveKey  = "reserve idempotency key"
+	getKey      = "get idempotency key"
+	completeKey = "complete idempotency key"
+	releaseKey  = "release idempotency key"
+	purgeKeys   = "purge expired idempotency keys"
+)
+
+func queries() map[string]string {
+	return map[string]string{
+		// An expired record gives its key to the new request
+		reserveKey: `INSERT INTO
+			idempotency_keys (tenant_id, owner, key, fingerprint, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (tenant_id, owner, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, status = NULL, content_type = '', body = NULL,
+			created_at = NOW(), expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at < NOW() RETURNING *`,
+		getKey: "SELECT * FROM idempotency_keys WHERE tenant_id = $1 AND owner = $2 AND key = $3",
+		completeKey: `UPDATE idempotency_keys SET status = $1, content_type = $2, body = $3
+			WHERE tenant_id = $4 AND owner = $5 AND key = $6`,
+		releaseKey: `DELETE FROM idempotency_keys
+			WHERE tenant_id = $1 AND owner = $2 AND key = $3 AND status IS NULL`,
+		purgeKeys: "DELETE FROM idempotency_keys WHERE expires_at < $1",
+	}
+}
+
+// NewPostgresStore creates the store keeping the records in the idempotency_keys table
+func NewPostgresStore(db *sqlx.DB) (Store, error) {
+	sqlStatements := make(map[string]*sqlx.Stmt)
+
+	for queryName, query := range queries() {
+		stmt, err := postgres.Prepare(db, queryName, query)
+		if err != nil {
+			return nil, errors.WrapErrorf(err, errors.ErrCodeUnknown,
+				"error preparing statement %s", queryName)
+		}
+		sqlStatements[queryName] = stmt
+	}
+
+	return postgresStore{statements: sqlStatements}, nil
+}
+
+type postgresStore struct {
+	statements map[string]*sqlx.Stmt
+}
+
+// Reserve saves the record unless a live one holds the key
+func (s postgresStore) Reserve(ctx context.Context, r *Record) (*Record, error) {
+	stmt, ok := s.statements[reserveKey]
+	if !ok {
+		return nil, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", reserveKey)
+	}
+
+	ctx, end := postgres.StartStatement(ctx, reserveKey)
+	defer end()
+
+	err := stmt.GetContext(ctx, r, r.TenantID, r.Owner, r.Key, r.Fingerprint, r.ExpiresAt)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, errors.WrapDatabaseErrorf(err, "error reserving idempotency key")
+	}
+
+	stmt, ok = s.statements[getKey]
+	if !ok {
+		return nil, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", getKey)
+	}
+
+	var existing Record
+	if err := stmt.GetContext(ctx, &existing, r.TenantID, r.Owner, r.Key); err != nil {
+		return nil, errors.WrapDatabaseErrorf(err, "error getting idempotency key")
+	}
+	return &existing, nil
+}
+
+// Complete stores the response of the reserved record
+func (s postgresStore) Complete(ctx context.Context, r *Record) error {
+	stmt, ok := s.statements[completeKey]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", completeKey)
+	}
+
+	ctx, end := postgres.StartStatement(ctx, completeKey)
+	defer end()
+
+	if _, err := stmt.ExecContext(ctx, r.Status, r.ContentType, r.Body, r.TenantID, r.Owner, r.Key); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error completing idempotency key")
+	}
+	return nil
+}
+
+// Release frees the key of a request that failed
+func (s postgresStore) Release(ctx context.Context, r *Record) error {
+	stmt, ok := s.statements[releaseKey]
+	if !ok {
+		return errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", releaseKey)
+	}
+
+	ctx, end := postgres.StartStatement(ctx, releaseKey)
+	defer end()
+
+	if _, err := stmt.ExecContext(ctx, r.TenantID, r.Owner, r.Key); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error releasing idempotency key")
+	}
+	return nil
+}
+
+// Purge deletes the records expired before the given time
+func (s postgresStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	stmt, ok := s.statements[purgeKeys]
+	if !ok {
+		return 0, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", purgeKeys)
+	}
+
+	ctx, end := postgres.StartStatement(ctx, purgeKeys)
+	defer end()
+
+	res, err := stmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, errors.WrapDatabaseErrorf(err, "error purging idempotency keys")
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	kitmetrics "github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sumelms_course"
+
+// registry holds the metrics of the service along with the ones of the Go runtime and the process
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = counter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by route and status.",
+	}, "method", "route", "status")
+	httpLatency = histogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent handling the HTTP requests, by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, "method", "route", "status")
+
+	serviceRequests = counter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "requests_total",
+		Help:      "Number of calls to the domain services, by method and outcome.",
+	}, "service", "method", "error")
+	serviceLatency = histogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "service",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent in the domain services, by method and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, "service", "method", "error")
+
+	statementLatency = histogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "database",
+		Name:      "statement_duration_seconds",
+		Help:      "Time spent running the prepared statements of the repositories.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, "statement")
+)
+
+func init() { //nolint: gochecknoinits
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+func counter(opts prometheus.CounterOpts, labels ...string) *kitprometheus.Counter {
+	cv := prometheus.NewCounterVec(opts, labels)
+	registry.MustRegister(cv)
+	return kitprometheus.NewCounter(cv)
+}
+
+func histogram(opts prometheus.HistogramOpts, labels ...string) *kitprometheus.Histogram {
+	hv := prometheus.NewHistogramVec(opts, labels)
+	registry.MustRegister(hv)
+	return kitprometheus.NewHistogram(hv)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exposes the connection pool stats of the database
+func RegisterDB(db *sql.DB, name string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Service returns the request counter and latency histogram of the domain service, meant for
+// its instrumenting middleware. They are labeled by the method and whether it failed.
+func Service(name string) (kitmetrics.Counter, kitmetrics.Histogram) {
+	return serviceRequests.With("service", name), serviceLatency.With("service", name)
+}
+
+// ObserveStatement records the latency of the prepared statement run since begin, the
+// repositories defer it with the time the statement started
+func ObserveStatement(name string, begin time.Time) {
+	statementLatency.With("statement", name).Observe(time.Since(begin).Seconds())
+}
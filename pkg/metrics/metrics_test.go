@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestNewHTTPHandler(t *testing.T) {
+	r := mux.NewRouter()
+	r.Handle("/courses/{uuid}", NewHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["uuid"] == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("{}")) //nolint: errcheck
+	}))).Methods(http.MethodGet)
+
+	for _, path := range []string{"/courses/first", "/courses/second", "/courses/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t)
+	for _, want := range []string{
+		`sumelms_course_http_requests_total{method="GET",route="/courses/{uuid}",status="200"} 2`,
+		`sumelms_course_http_requests_total{method="GET",route="/courses/{uuid}",status="404"} 1`,
+		`sumelms_course_http_request_duration_seconds_count{method="GET",route="/courses/{uuid}",status="200"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("the metrics don't have %s", want)
+		}
+	}
+}
+
+func TestObserveStatement(t *testing.T) {
+	ObserveStatement("get course by uuid", time.Now().Add(-10*time.Millisecond))
+
+	want := `sumelms_course_database_statement_duration_seconds_count{statement="get course by uuid"} 1`
+	if body := scrape(t); !strings.Contains(body, want) {
+		t.Errorf("the metrics don't have %s", want)
+	}
+}
@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	natsDefaultPort = "4222"
+	natsDialTimeout = 5 * time.Second
+)
+
+// NATSPublisher publishes the events to a NATS server, under the subject prefix followed by
+// the event type, e.g. sumelms.course.created. It speaks the text protocol of the server, each
+// message is followed by a PING so the publish only succeeds once the server processed it.
+type NATSPublisher struct {
+	mu      sync.Mutex
+	address string
+	prefix  string
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// NewNATSPublisher creates the publisher of the server at the nats://host:port URL, the
+// connection is established on the first publish and again after a failure
+func NewNATSPublisher(rawURL, prefix string) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid NATS URL %s", rawURL)
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	return &NATSPublisher{address: address, prefix: strings.TrimSuffix(prefix, ".")}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, e Event) error {
+	message, err := json.Marshal(e)
+	if err != nil {
+		return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error encoding event %s", e.Type)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = p.conn.SetDeadline(deadline)
+	} else {
+		_ = p.conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	}
+
+	subject := e.Type
+	if p.prefix != "" {
+		subject = p.prefix + "." + e.Type
+	}
+	if _, err := fmt.Fprintf(p.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(message), message); err != nil {
+		p.close()
+		return errors.WrapErrorf(err, errors.ErrCodeUnavailable, "error publishing event %s", e.Type)
+	}
+	if err := p.waitPong(); err != nil {
+		p.close()
+		return errors.WrapErrorf(err, errors.ErrCodeUnavailable, "error publishing event %s", e.Type)
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (p *NATSPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.close()
+	return nil
+}
+
+// connect reads the INFO of the server and introduces the client
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	if p.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return errors.WrapErrorf(err, errors.ErrCodeUnavailable, "error connecting to NATS")
+	}
+	_ = conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	p.conn, p.reader = conn, bufio.NewReader(conn)
+
+	line, err := p.readLine()
+	if err == nil && !strings.HasPrefix(line, "INFO") {
+		err = fmt.Errorf("unexpected greeting %q", line)
+	}
+	if err == nil {
+		_, err = fmt.Fprint(conn, `CONNECT {"verbose":false,"pedantic":false,"lang":"go","name":"microservice-course","protocol":1}`+"\r\nPING\r\n")
+	}
+	if err == nil {
+		err = p.waitPong()
+	}
+	if err != nil {
+		p.close()
+		return errors.WrapErrorf(err, errors.ErrCodeUnavailable, "error connecting to NATS")
+	}
+	return nil
+}
+
+// waitPong reads the replies of the server until the PONG of our PING, answering its PINGs
+func (p *NATSPublisher) waitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := fmt.Fprint(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NATSPublisher) close() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn, p.reader = nil, nil
+}
@@ -0,0 +1,161 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// natsServer stands in for a NATS server, it records the published messages and rejects
+// the subjects given
+type natsServer struct {
+	listener net.Listener
+	reject   string
+
+	mu       sync.Mutex
+	messages map[string][]byte
+}
+
+func newNATSServer(t *testing.T, reject string) *natsServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when listening", err)
+	}
+	s := &natsServer{listener: listener, reject: reject, messages: map[string][]byte{}}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close() //nolint: errcheck
+
+	fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n") //nolint: errcheck
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "PING":
+			fmt.Fprint(conn, "PONG\r\n") //nolint: errcheck
+		case strings.HasPrefix(line, "PUB "):
+			var subject string
+			var size int
+			if _, err := fmt.Sscanf(line, "PUB %s %d", &subject, &size); err != nil {
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			if subject == s.reject {
+				fmt.Fprintf(conn, "-ERR 'Permissions Violation for Publish to %s'\r\n", subject) //nolint: errcheck
+				continue
+			}
+			s.mu.Lock()
+			s.messages[subject] = payload[:size]
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *natsServer) message(subject string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages[subject]
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	server := newNATSServer(t, "sumelms.course.deleted")
+	p, err := NewNATSPublisher("nats://"+server.listener.Addr().String(), "sumelms")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the publisher", err)
+	}
+	defer p.Close() //nolint: errcheck
+
+	courseID := uuid.New()
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{
+			name:  "course created",
+			event: Event{UUID: uuid.New(), TenantID: "default", Type: "course.created", AggregateID: courseID, Payload: []byte(`{"name":"course"}`)},
+		},
+		{
+			name:    "rejected subject",
+			event:   Event{UUID: uuid.New(), TenantID: "default", Type: "course.deleted", AggregateID: courseID, Payload: []byte(`{}`)},
+			wantErr: true,
+		},
+		{
+			name:  "published after a failure",
+			event: Event{UUID: uuid.New(), TenantID: "default", Type: "course.updated", AggregateID: courseID, Payload: []byte(`{"name":"renamed"}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		err := p.Publish(context.Background(), tt.event)
+		if tt.wantErr {
+			if ierr, ok := err.(*errors.Error); !ok || ierr.Code() != errors.ErrCodeUnavailable {
+				t.Errorf("%s: Publish() error = %v, want unavailable", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Publish() error = %v", tt.name, err)
+		}
+
+		var got Event
+		if err := json.Unmarshal(server.message("sumelms."+tt.event.Type), &got); err != nil {
+			t.Fatalf("%s: the message isn't an event: %v", tt.name, err)
+		}
+		if got.UUID != tt.event.UUID || got.AggregateID != courseID || string(got.Payload) != string(tt.event.Payload) {
+			t.Errorf("%s: published %+v, want %+v", tt.name, got, tt.event)
+		}
+	}
+}
+
+func TestNATSPublisher_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when listening", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	p, err := NewNATSPublisher("nats://"+address, "sumelms")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the publisher", err)
+	}
+
+	err = p.Publish(context.Background(), Event{Type: "course.created", Payload: []byte(`{}`)})
+	if ierr, ok := err.(*errors.Error); !ok || ierr.Code() != errors.ErrCodeUnavailable {
+		t.Errorf("Publish() error = %v, want unavailable", err)
+	}
+}
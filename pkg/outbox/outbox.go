@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const insertEvent = `INSERT INTO
+	outbox (tenant_id, type, aggregate_id, payload)
+	VALUES ($1, $2, $3, $4)`
+
+// Event is a change of an aggregate, written to the outbox in the transaction of the change
+// and published later by the relay
+type Event struct {
+	ID          int64           `db:"id" json:"-"`
+	UUID        uuid.UUID       `db:"uuid" json:"id"`
+	TenantID    string          `db:"tenant_id" json:"tenant_id"`
+	Type        string          `db:"type" json:"type"`
+	AggregateID uuid.UUID       `db:"aggregate_id" json:"aggregate_id"`
+	Payload     json.RawMessage `db:"payload" json:"payload"`
+	Attempts    int             `db:"attempts" json:"-"`
+	LastError   *string         `db:"last_error" json:"-"`
+	CreatedAt   time.Time       `db:"created_at" json:"occurred_at"`
+	PublishedAt *time.Time      `db:"published_at" json:"-"`
+	ParkedAt    *time.Time      `db:"parked_at" json:"-"`
+
+	// data is encoded into the payload when the event is written, after the change filled it
+	data interface{}
+}
+
+// NewEvent creates the event of the aggregate, the data is encoded as the payload
+func NewEvent(eventType, tenantID string, aggregateID uuid.UUID, data interface{}) Event {
+	return Event{
+		Type:        eventType,
+		TenantID:    tenantID,
+		AggregateID: aggregateID,
+		data:        data,
+	}
+}
+
+// Write adds the event to the outbox within the transaction
+func Write(ctx context.Context, tx *sqlx.Tx, e Event) error {
+	payload := e.Payload
+	if payload == nil {
+		var err error
+		if payload, err = json.Marshal(e.data); err != nil {
+			return errors.WrapErrorf(err, errors.ErrCodeUnknown, "error encoding event %s", e.Type)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, insertEvent, e.TenantID, e.Type, e.AggregateID, payload); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error writing event %s", e.Type)
+	}
+	return nil
+}
+
+// WithTx runs the change in a transaction and writes the events it returns to the outbox,
+// the events are only recorded if the change is committed
+func WithTx(ctx context.Context, db *sqlx.DB, change func(tx *sqlx.Tx) ([]Event, error)) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.WrapDatabaseErrorf(err, "error beginning transaction")
+	}
+	defer tx.Rollback() //nolint: errcheck
+
+	events, err := change(tx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := Write(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapDatabaseErrorf(err, "error committing transaction")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// Publisher delivers the events to the other services. The relay publishes the events one at
+// a time in the order they were written, and retries them until Publish succeeds.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// maxMemoryEvents is the number of the latest events kept by the memory publisher
+const maxMemoryEvents = 1000
+
+// MemoryPublisher keeps the latest published events, it is meant for tests and local development
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, e Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, e)
+	if len(p.events) > maxMemoryEvents {
+		p.events = append(p.events[:0], p.events[len(p.events)-maxMemoryEvents:]...)
+	}
+	return nil
+}
+
+// Events returns the latest published events
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+// MultiPublisher publishes the events to every publisher in turn. It stops at the first failure,
+// so the publishers before it receive the event again when the relay retries it.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, e Event) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
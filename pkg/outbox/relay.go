@@ -0,0 +1,211 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/sumelms/microservice-course/pkg/database/postgres"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	// relayLock is the advisory lock held by the relay publishing, the events keep their order
+	// when several instances of the service run
+	relayLock = 7237325
+
+	lockRelay      = "lock outbox relay"
+	pendingEvents  = "pending outbox events"
+	markPublished  = "mark outbox event published"
+	markFailed     = "mark outbox event failed"
+	parkEvent      = "park outbox event"
+	purgePublished = "purge published outbox events"
+)
+
+func queries() map[string]string {
+	return map[string]string{
+		lockRelay:      "SELECT pg_try_advisory_xact_lock($1)",
+		pendingEvents:  "SELECT * FROM outbox WHERE published_at IS NULL AND parked_at IS NULL ORDER BY id LIMIT $1",
+		markPublished:  "UPDATE outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1",
+		markFailed:     "UPDATE outbox SET attempts = attempts + 1, last_error = $1 WHERE id = $2",
+		parkEvent:      "UPDATE outbox SET attempts = attempts + 1, last_error = $1, parked_at = NOW() WHERE id = $2",
+		purgePublished: "DELETE FROM outbox WHERE published_at < $1",
+	}
+}
+
+// Relay publishes the events of the outbox, an event is parked after failing the max attempts
+type Relay struct {
+	db          *sqlx.DB
+	statements  map[string]*sqlx.Stmt
+	publisher   Publisher
+	batchSize   int
+	maxAttempts int
+	logger      log.Logger
+}
+
+func NewRelay(db *sqlx.DB, publisher Publisher, batchSize, maxAttempts int, logger log.Logger) (*Relay, error) {
+	sqlStatements := make(map[string]*sqlx.Stmt)
+
+	for queryName, query := range queries() {
+		stmt, err := postgres.Prepare(db, queryName, query)
+		if err != nil {
+			return nil, errors.WrapErrorf(err, errors.ErrCodeUnknown,
+				"error preparing statement %s", queryName)
+		}
+		sqlStatements[queryName] = stmt
+	}
+
+	return &Relay{
+		db:          db,
+		statements:  sqlStatements,
+		publisher:   publisher,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}, nil
+}
+
+// Run publishes the pending events every interval until the context is done
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil && r.logger != nil {
+				r.logger.Log("msg", "unable to publish the outbox events", "error", err) //nolint: errcheck
+			}
+		}
+	}
+}
+
+// Flush publishes the pending events in the order they were written. It stops at the first
+// failure, the failed event is retried before the next ones on the following flush. Once the
+// event failed the max attempts, it is parked and the next ones are published.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		handled, pending, err := r.publishBatch(ctx)
+		total += handled
+		if err != nil || handled < pending || pending < r.batchSize {
+			return total, err
+		}
+	}
+}
+
+// publishBatch publishes a batch of events, it returns how many were published or parked out
+// of the pending ones read
+func (r *Relay) publishBatch(ctx context.Context) (int, int, error) {
+	stmts, err := r.prepared(lockRelay, pendingEvents, markPublished, markFailed, parkEvent)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, 0, errors.WrapDatabaseErrorf(err, "error beginning transaction")
+	}
+	defer tx.Rollback() //nolint: errcheck
+
+	var locked bool
+	lockCtx, end := postgres.StartStatement(ctx, lockRelay)
+	err = tx.StmtxContext(lockCtx, stmts[lockRelay]).GetContext(lockCtx, &locked, relayLock)
+	end()
+	if err != nil {
+		return 0, 0, errors.WrapDatabaseErrorf(err, "error locking the outbox relay")
+	}
+	if !locked {
+		// Another instance is publishing
+		return 0, 0, nil
+	}
+
+	var events []Event
+	pendingCtx, end := postgres.StartStatement(ctx, pendingEvents)
+	err = tx.StmtxContext(pendingCtx, stmts[pendingEvents]).SelectContext(pendingCtx, &events, r.batchSize)
+	end()
+	if err != nil {
+		return 0, 0, errors.WrapDatabaseErrorf(err, "error getting the outbox events")
+	}
+
+	handled := 0
+	var publishErr error
+	for _, e := range events {
+		if publishErr = r.publisher.Publish(ctx, e); publishErr != nil {
+			if e.Attempts+1 < r.maxAttempts {
+				if err := execTx(ctx, tx, stmts, markFailed, publishErr.Error(), e.ID); err != nil {
+					return 0, len(events), errors.WrapDatabaseErrorf(err, "error marking event %s failed", e.UUID)
+				}
+				break
+			}
+
+			if err := execTx(ctx, tx, stmts, parkEvent, publishErr.Error(), e.ID); err != nil {
+				return 0, len(events), errors.WrapDatabaseErrorf(err, "error parking event %s", e.UUID)
+			}
+			if r.logger != nil {
+				r.logger.Log("msg", "parked the outbox event", "event", e.UUID, "type", e.Type, "error", publishErr) //nolint: errcheck
+			}
+			handled++
+			publishErr = nil
+			continue
+		}
+		if err := execTx(ctx, tx, stmts, markPublished, e.ID); err != nil {
+			return 0, len(events), errors.WrapDatabaseErrorf(err, "error marking event %s published", e.UUID)
+		}
+		handled++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, len(events), errors.WrapDatabaseErrorf(err, "error committing transaction")
+	}
+	return handled, len(events), publishErr
+}
+
+// PurgeDeleted permanently deletes the events published before the retention period, the parked
+// ones are kept
+func (r *Relay) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	stmts, err := r.prepared(purgePublished)
+	if err != nil {
+		return err
+	}
+
+	ctx, end := postgres.StartStatement(ctx, purgePublished)
+	defer end()
+
+	events, err := stmts[purgePublished].ExecContext(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return errors.WrapDatabaseErrorf(err, "error purging outbox events")
+	}
+
+	if r.logger != nil {
+		n, _ := events.RowsAffected()
+		r.logger.Log("msg", "purged published events", "events", n) //nolint: errcheck
+	}
+	return nil
+}
+
+// execTx runs the statement in the transaction, bounded by the timeout of the statement rather
+// than the one of the whole batch
+func execTx(ctx context.Context, tx *sqlx.Tx, stmts map[string]*sqlx.Stmt, name string, args ...interface{}) error {
+	ctx, end := postgres.StartStatement(ctx, name)
+	defer end()
+
+	_, err := tx.StmtxContext(ctx, stmts[name]).ExecContext(ctx, args...)
+	return err
+}
+
+func (r *Relay) prepared(names ...string) (map[string]*sqlx.Stmt, error) {
+	stmts := make(map[string]*sqlx.Stmt, len(names))
+	for _, name := range names {
+		stmt, ok := r.statements[name]
+		if !ok {
+			return nil, errors.NewErrorf(errors.ErrCodeUnknown, "prepared statement %s not found", name)
+		}
+		stmts[name] = stmt
+	}
+	return stmts, nil
+}
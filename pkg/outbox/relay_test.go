@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	utils "github.com/sumelms/microservice-course/tests"
+)
+
+var (
+	courseCreated = uuid.MustParse("6a1f3c1e-0b64-4c36-9b0f-1f4e0c2d9a11")
+	courseUpdated = uuid.MustParse("9c2e6a0d-8f3b-4b7e-a5d2-3e1f7c8b6d22")
+)
+
+type failingPublisher struct {
+	calls int
+}
+
+func (p *failingPublisher) Publish(context.Context, Event) error {
+	p.calls++
+	return fmt.Errorf("broker is down")
+}
+
+func pendingRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "uuid", "tenant_id", "type", "aggregate_id", "payload", "attempts"}).
+		AddRow(1, courseCreated, "default", "course.created", utils.CourseUUID, []byte(`{"name":"course"}`), 0).
+		AddRow(2, courseUpdated, "default", "course.updated", utils.CourseUUID, []byte(`{"name":"renamed"}`), 0)
+}
+
+func TestRelay_Flush(t *testing.T) {
+	db, mock, stmts := utils.NewTestDB(queries())
+	publisher := NewMemoryPublisher()
+	r, err := NewRelay(db, publisher, 10, 3, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the relay", err)
+	}
+
+	mock.ExpectBegin()
+	stmts[lockRelay].ExpectQuery().WithArgs(relayLock).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	stmts[pendingEvents].ExpectQuery().WithArgs(10).WillReturnRows(pendingRows())
+	stmts[markPublished].ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	stmts[markPublished].ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	n, err := r.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Flush() published = %d, want 2", n)
+	}
+
+	events := publisher.Events()
+	if len(events) != 2 || events[0].UUID != courseCreated || events[1].UUID != courseUpdated {
+		t.Fatalf("Flush() published %v, want the events in order", events)
+	}
+	if string(events[1].Payload) != `{"name":"renamed"}` {
+		t.Errorf("Flush() payload = %s", events[1].Payload)
+	}
+}
+
+func TestRelay_FlushFailure(t *testing.T) {
+	db, mock, stmts := utils.NewTestDB(queries())
+	publisher := &failingPublisher{}
+	r, err := NewRelay(db, publisher, 10, 3, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the relay", err)
+	}
+
+	mock.ExpectBegin()
+	stmts[lockRelay].ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	stmts[pendingEvents].ExpectQuery().WillReturnRows(pendingRows())
+	stmts[markFailed].ExpectExec().WithArgs("broker is down", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	n, err := r.Flush(context.Background())
+	if err == nil {
+		t.Fatalf("Flush() should fail when the publisher fails")
+	}
+	if n != 0 {
+		t.Errorf("Flush() published = %d, want 0", n)
+	}
+	if publisher.calls != 1 {
+		t.Errorf("publisher calls = %d, the events after a failure must wait", publisher.calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Flush() didn't record the failure: %v", err)
+	}
+}
+
+func TestRelay_FlushParksExhaustedEvent(t *testing.T) {
+	db, mock, stmts := utils.NewTestDB(queries())
+	publisher := &failingPublisher{}
+	r, err := NewRelay(db, publisher, 10, 3, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the relay", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "tenant_id", "type", "aggregate_id", "payload", "attempts"}).
+		AddRow(1, courseCreated, "default", "course.created", utils.CourseUUID, []byte(`{"name":"course"}`), 2).
+		AddRow(2, courseUpdated, "default", "course.updated", utils.CourseUUID, []byte(`{"name":"renamed"}`), 0)
+
+	mock.ExpectBegin()
+	stmts[lockRelay].ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	stmts[pendingEvents].ExpectQuery().WillReturnRows(rows)
+	stmts[parkEvent].ExpectExec().WithArgs("broker is down", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	stmts[markFailed].ExpectExec().WithArgs("broker is down", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if _, err := r.Flush(context.Background()); err == nil {
+		t.Fatalf("Flush() should fail when the next event fails")
+	}
+	if publisher.calls != 2 {
+		t.Errorf("publisher calls = %d, the parked event must not hold back the next ones", publisher.calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Flush() didn't park the event: %v", err)
+	}
+}
+
+func TestRelay_FlushLocked(t *testing.T) {
+	db, mock, stmts := utils.NewTestDB(queries())
+	publisher := NewMemoryPublisher()
+	r, err := NewRelay(db, publisher, 10, 3, nil)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the relay", err)
+	}
+
+	mock.ExpectBegin()
+	stmts[lockRelay].ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	n, err := r.Flush(context.Background())
+	if err != nil || n != 0 {
+		t.Errorf("Flush() = %d, %v, want nothing published while another relay runs", n, err)
+	}
+	if len(publisher.Events()) != 0 {
+		t.Errorf("Flush() published %v", publisher.Events())
+	}
+}
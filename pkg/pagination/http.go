@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// ParseQuery reads the limit, cursor, sort and filter parameters from the request
+func ParseQuery(r *http.Request, f Fields) (Query, error) {
+	q := NewQuery()
+	values := r.URL.Query()
+
+	if limit := values.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return Query{}, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid limit %s", limit)
+		}
+		q.Limit = l
+	}
+
+	if deleted := values.Get("include_deleted"); deleted != "" {
+		d, err := strconv.ParseBool(deleted)
+		if err != nil {
+			return Query{}, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid include_deleted %s", deleted)
+		}
+		q.IncludeDeleted = d
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		q.Sort = strings.TrimPrefix(sort, "-")
+		q.Desc = strings.HasPrefix(sort, "-")
+	}
+
+	if cursor := values.Get("cursor"); cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return Query{}, err
+		}
+		q.Cursor = c
+		q.Sort = c.Sort
+		q.Desc = c.Desc
+	}
+
+	for _, name := range f.Filters {
+		if value := values.Get(name); value != "" {
+			q.Filters[name] = value
+		}
+	}
+
+	q.Nullable = contains(f.Nullable, q.Sort)
+
+	if err := q.Validate(f); err != nil {
+		return Query{}, err
+	}
+	return q, nil
+}
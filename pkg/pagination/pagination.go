@@ -0,0 +1,301 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+
+	idColumn = "id"
+)
+
+// Fields describes the columns of a resource that can be used to filter and sort a listing.
+// Nullable lists the sorts whose column may be NULL, which come after every value.
+type Fields struct {
+	Filters  []string
+	Sorts    []string
+	Nullable []string
+}
+
+// Query is the shared list-query model used by every list endpoint
+type Query struct {
+	Limit          int
+	Cursor         *Cursor
+	Filters        map[string]interface{}
+	Sort           string
+	Desc           bool
+	Nullable       bool
+	IncludeDeleted bool
+}
+
+// Cursor points to the row where the next (or previous) page starts
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	Null  bool   `json:"n,omitempty"`
+	ID    uint   `json:"id"`
+	Prev  bool   `json:"p,omitempty"`
+}
+
+// Page holds the cursors returned along with a listing
+type Page struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Condition is an extra WHERE clause, using ? as placeholder, applied to a listing
+type Condition struct {
+	Clause string
+	Args   []interface{}
+}
+
+var mapper = reflectx.NewMapperFunc("db", strings.ToLower)
+
+// NewQuery creates a query with the default limit and sort
+func NewQuery() Query {
+	return Query{
+		Limit:   DefaultLimit,
+		Filters: make(map[string]interface{}),
+		Sort:    idColumn,
+	}
+}
+
+// Encode returns the opaque representation of the cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an opaque cursor
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid cursor")
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid cursor")
+	}
+	return &c, nil
+}
+
+// Validate checks the query against the fields allowed for the resource
+func (q Query) Validate(f Fields) error {
+	if q.Limit < 1 || q.Limit > MaxLimit {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "limit must be between 1 and %d", MaxLimit)
+	}
+	for name := range q.Filters {
+		if !contains(f.Filters, name) {
+			return errors.NewErrorf(errors.ErrCodeInvalidArgument, "filter %s is not allowed", name)
+		}
+	}
+	if q.Sort != idColumn && !contains(f.Sorts, q.Sort) {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "sort %s is not allowed", q.Sort)
+	}
+	if q.Cursor != nil && q.Cursor.Null && !contains(f.Nullable, q.Sort) {
+		return errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid cursor")
+	}
+	return nil
+}
+
+// Select builds the SELECT statement for a page of the given table. One extra row
+// is requested so Paginate can tell whether there is another page. Soft-deleted
+// rows are left out unless the query asks to include them. The NULLs of a nullable
+// sort come last in ascending order, and first in descending order.
+func Select(table string, q Query, conditions ...Condition) (string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+
+	if !q.IncludeDeleted {
+		where = append(where, "deleted_at IS NULL")
+	}
+
+	for _, c := range conditions {
+		where = append(where, c.Clause)
+		args = append(args, c.Args...)
+	}
+
+	for _, name := range sortedKeys(q.Filters) {
+		where = append(where, fmt.Sprintf("%s = ?", name))
+		args = append(args, q.Filters[name])
+	}
+
+	backward := q.Cursor != nil && q.Cursor.Prev
+	desc := q.Desc != backward
+
+	if q.Cursor != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		switch {
+		case q.Sort == idColumn:
+			where = append(where, fmt.Sprintf("id %s ?", op))
+			args = append(args, q.Cursor.ID)
+		case q.Nullable:
+			where = append(where, nullableAfter(q.Sort, op, q.Cursor.Null))
+			if !q.Cursor.Null {
+				args = append(args, q.Cursor.Value)
+			}
+			args = append(args, q.Cursor.ID)
+		default:
+			where = append(where, fmt.Sprintf("(%s, id) %s (?, ?)", q.Sort, op))
+			args = append(args, q.Cursor.Value, q.Cursor.ID)
+		}
+	}
+
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s", table)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	switch {
+	case q.Sort == idColumn:
+		query += fmt.Sprintf(" ORDER BY id %s", order)
+	case q.Nullable:
+		nulls := "LAST"
+		if desc {
+			nulls = "FIRST"
+		}
+		query += fmt.Sprintf(" ORDER BY %s %s NULLS %s, id %s", q.Sort, order, nulls, order)
+	default:
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", q.Sort, order, order)
+	}
+	query += fmt.Sprintf(" LIMIT %d", q.Limit+1)
+
+	return sqlx.Rebind(sqlx.DOLLAR, query), args
+}
+
+// nullableAfter is the condition of the rows after the cursor on a nullable column, whose NULLs
+// are greater than any value
+func nullableAfter(column, op string, null bool) string {
+	switch {
+	case op == ">" && null:
+		return fmt.Sprintf("(%s IS NULL AND id > ?)", column)
+	case op == ">":
+		return fmt.Sprintf("((%s, id) > (?, ?) OR %s IS NULL)", column, column)
+	case null:
+		return fmt.Sprintf("(%s IS NOT NULL OR id < ?)", column)
+	default:
+		return fmt.Sprintf("(%s, id) < (?, ?)", column)
+	}
+}
+
+// Paginate trims the rows fetched by a Select statement to the query limit and
+// builds the cursors pointing to the surrounding pages.
+func Paginate[T any](q Query, rows []T) ([]T, Page, error) {
+	hasMore := len(rows) > q.Limit
+	if hasMore {
+		rows = rows[:q.Limit]
+	}
+
+	backward := q.Cursor != nil && q.Cursor.Prev
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	var page Page
+	if len(rows) == 0 {
+		return rows, page, nil
+	}
+
+	if (!backward && hasMore) || (backward && q.Cursor != nil) {
+		c, err := cursorFor(q, rows[len(rows)-1], false)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		page.NextCursor = c.Encode()
+	}
+	if (!backward && q.Cursor != nil) || (backward && hasMore) {
+		c, err := cursorFor(q, rows[0], true)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		page.PrevCursor = c.Encode()
+	}
+	return rows, page, nil
+}
+
+func cursorFor(q Query, row interface{}, prev bool) (Cursor, error) {
+	v := reflect.ValueOf(row)
+
+	idField, ok := fieldValue(v, idColumn)
+	id, isUint := idField.Interface().(uint)
+	if !ok || !isUint {
+		return Cursor{}, errors.NewErrorf(errors.ErrCodeUnknown, "row %T has no id column", row)
+	}
+
+	c := Cursor{Sort: q.Sort, Desc: q.Desc, ID: id, Prev: prev}
+	if q.Sort == idColumn {
+		return c, nil
+	}
+
+	field, ok := fieldValue(v, q.Sort)
+	if !ok {
+		return Cursor{}, errors.NewErrorf(errors.ErrCodeUnknown, "row %T has no %s column", row, q.Sort)
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			c.Null = true
+			return c, nil
+		}
+		field = field.Elem()
+	}
+	switch value := field.Interface().(type) {
+	case time.Time:
+		c.Value = value.Format(time.RFC3339Nano)
+	default:
+		c.Value = fmt.Sprint(value)
+	}
+	return c, nil
+}
+
+func fieldValue(v reflect.Value, column string) (reflect.Value, bool) {
+	v = reflect.Indirect(v)
+	fi, ok := mapper.TypeMap(v.Type()).Names[column]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return reflectx.FieldByIndexesReadOnly(v, fi.Index), true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,215 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type row struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt *time.Time `db:"expires_at"`
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    Query
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:  "default query",
+			query: NewQuery(),
+			want:  "SELECT * FROM courses WHERE deleted_at IS NULL ORDER BY id ASC LIMIT 21",
+		},
+		{
+			name: "filtered and sorted query",
+			query: Query{
+				Limit:          10,
+				Filters:        map[string]interface{}{"name": "Go", "code": "SUME"},
+				Sort:           "name",
+				Desc:           true,
+				IncludeDeleted: true,
+			},
+			want:     "SELECT * FROM courses WHERE code = $1 AND name = $2 ORDER BY name DESC, id DESC LIMIT 11",
+			wantArgs: []interface{}{"SUME", "Go"},
+		},
+		{
+			name: "previous page query",
+			query: Query{
+				Limit:  5,
+				Cursor: &Cursor{Sort: "name", Value: "Go", ID: 7, Prev: true},
+				Sort:   "name",
+			},
+			want: "SELECT * FROM courses WHERE deleted_at IS NULL AND (name, id) < ($1, $2) " +
+				"ORDER BY name DESC, id DESC LIMIT 6",
+			wantArgs: []interface{}{"Go", uint(7)},
+		},
+		{
+			name: "nullable sort after a value",
+			query: Query{
+				Limit:    5,
+				Cursor:   &Cursor{Sort: "expires_at", Value: "2022-08-01T00:00:00Z", ID: 7},
+				Sort:     "expires_at",
+				Nullable: true,
+			},
+			want: "SELECT * FROM courses WHERE deleted_at IS NULL AND ((expires_at, id) > ($1, $2) OR expires_at IS NULL) " +
+				"ORDER BY expires_at ASC NULLS LAST, id ASC LIMIT 6",
+			wantArgs: []interface{}{"2022-08-01T00:00:00Z", uint(7)},
+		},
+		{
+			name: "nullable sort after a null",
+			query: Query{
+				Limit:    5,
+				Cursor:   &Cursor{Sort: "expires_at", Null: true, ID: 7},
+				Sort:     "expires_at",
+				Nullable: true,
+			},
+			want: "SELECT * FROM courses WHERE deleted_at IS NULL AND (expires_at IS NULL AND id > $1) " +
+				"ORDER BY expires_at ASC NULLS LAST, id ASC LIMIT 6",
+			wantArgs: []interface{}{uint(7)},
+		},
+		{
+			name: "nullable sort before a null",
+			query: Query{
+				Limit:    5,
+				Cursor:   &Cursor{Sort: "expires_at", Null: true, ID: 7, Prev: true},
+				Sort:     "expires_at",
+				Nullable: true,
+			},
+			want: "SELECT * FROM courses WHERE deleted_at IS NULL AND (expires_at IS NOT NULL OR id < $1) " +
+				"ORDER BY expires_at DESC NULLS FIRST, id DESC LIMIT 6",
+			wantArgs: []interface{}{uint(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, args := Select("courses", tt.query)
+			if got != tt.want {
+				t.Errorf("Select() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Select() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	rows := []row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+
+	tests := []struct {
+		name     string
+		query    Query
+		rows     []row
+		wantLen  int
+		wantNext bool
+		wantPrev bool
+	}{
+		{
+			name:     "first page with more rows",
+			query:    Query{Limit: 2, Sort: "name"},
+			rows:     rows,
+			wantLen:  2,
+			wantNext: true,
+		},
+		{
+			name:     "last page",
+			query:    Query{Limit: 2, Sort: "name", Cursor: &Cursor{Sort: "name", Value: "b", ID: 2}},
+			rows:     rows[2:],
+			wantLen:  1,
+			wantPrev: true,
+		},
+		{
+			name:    "empty page",
+			query:   Query{Limit: 2, Sort: "id"},
+			rows:    []row{},
+			wantLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, page, err := Paginate(tt.query, tt.rows)
+			if err != nil {
+				t.Fatalf("Paginate() unexpected error = %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Paginate() got = %v, want %v", len(got), tt.wantLen)
+			}
+			if (page.NextCursor != "") != tt.wantNext {
+				t.Errorf("Paginate() next cursor = %q, want %v", page.NextCursor, tt.wantNext)
+			}
+			if (page.PrevCursor != "") != tt.wantPrev {
+				t.Errorf("Paginate() prev cursor = %q, want %v", page.PrevCursor, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func TestPaginate_NullableColumn(t *testing.T) {
+	expiry := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	rows := []row{{ID: 1, ExpiresAt: &expiry}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name     string
+		rows     []row
+		wantNext Cursor
+	}{
+		{
+			name:     "page ending on a value",
+			rows:     rows,
+			wantNext: Cursor{Sort: "expires_at", Value: "2022-08-01T00:00:00Z", ID: 1},
+		},
+		{
+			name:     "page ending on a null",
+			rows:     rows[1:],
+			wantNext: Cursor{Sort: "expires_at", Null: true, ID: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, page, err := Paginate(Query{Limit: 1, Sort: "expires_at", Nullable: true}, tt.rows)
+			if err != nil {
+				t.Fatalf("Paginate() unexpected error = %v", err)
+			}
+			got, err := DecodeCursor(page.NextCursor)
+			if err != nil {
+				t.Fatalf("DecodeCursor() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.wantNext) {
+				t.Errorf("Paginate() next cursor = %+v, want %+v", *got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestCursor_Encode(t *testing.T) {
+	c := Cursor{Sort: "created_at", Desc: true, Value: "2022-08-01T00:00:00Z", ID: 42}
+
+	got, err := DecodeCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, c) {
+		t.Errorf("DecodeCursor() got = %v, want %v", *got, c)
+	}
+
+	if _, err := DecodeCursor("not a cursor"); err == nil {
+		t.Errorf("DecodeCursor() expected an error for an invalid cursor")
+	}
+}
@@ -0,0 +1,130 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// ContentType is the media type of the merge patches, as defined by RFC 7386
+const ContentType = "application/merge-patch+json"
+
+// Merge applies the merge patch to the JSON document following RFC 7386: members set to
+// null are removed, objects are merged recursively and anything else replaces the target
+func Merge(doc, patch []byte) ([]byte, error) {
+	var target, p interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid document")
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid merge patch")
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = merge(t[name], value)
+	}
+	return t
+}
+
+// Apply merges the patch into the target, a pointer to a struct, and returns the JSON names
+// of the fields whose value changed, in alphabetical order. Removed fields take their zero value.
+func Apply(target interface{}, patch []byte) ([]string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(patch), []byte("{")) {
+		return nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "merge patch must be a JSON object")
+	}
+
+	doc, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := Merge(doc, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := reflect.New(reflect.TypeOf(target).Elem())
+	if err := json.Unmarshal(merged, result.Interface()); err != nil {
+		return nil, errors.WrapErrorf(err, errors.ErrCodeInvalidArgument, "invalid merge patch")
+	}
+	after, err := json.Marshal(result.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := changed(doc, after)
+	if err != nil {
+		return nil, err
+	}
+	reflect.ValueOf(target).Elem().Set(result.Elem())
+	return fields, nil
+}
+
+func changed(before, after []byte) ([]string, error) {
+	var b, a map[string]interface{}
+	if err := json.Unmarshal(before, &b); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &a); err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for name, value := range a {
+		if !reflect.DeepEqual(b[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// Update builds the UPDATE statement writing only the given fields of the record with the
+// uuid in the tenant, bumping its version. Columns maps the fields that may be patched to
+// their new values, and the version guards the record when it isn't zero.
+func Update(table string, columns map[string]interface{}, fields []string, id uuid.UUID, tenantID string,
+	version int) (string, []interface{}, error) {
+	if len(fields) == 0 {
+		return "", nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "nothing to patch")
+	}
+
+	set := make([]string, 0, len(fields)+2)
+	args := make([]interface{}, 0, len(fields)+3)
+	for _, name := range fields {
+		value, ok := columns[name]
+		if !ok {
+			return "", nil, errors.NewErrorf(errors.ErrCodeInvalidArgument, "field %s can't be patched", name)
+		}
+		args = append(args, value)
+		set = append(set, fmt.Sprintf("%s = $%d", name, len(args)))
+	}
+	set = append(set, "version = version + 1", "updated_at = NOW()")
+	args = append(args, id, tenantID, version)
+
+	n := len(args)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE uuid = $%d AND tenant_id = $%d AND ($%d = 0 OR version = $%d) "+
+		"AND deleted_at IS NULL RETURNING *", table, strings.Join(set, ", "), n-2, n-1, n, n)
+	return query, args, nil
+}
@@ -0,0 +1,123 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMerge(t *testing.T) {
+	// Examples from the appendix of RFC 7386
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{name: "replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "remove member", doc: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{name: "remove one of many", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "replace array", doc: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "nested objects", doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{name: "existing null kept", doc: `{"e":null}`, patch: `{"a":1}`, want: `{"a":1,"e":null}`},
+		{name: "nested null kept out", doc: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, want: `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Merge([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Merge() unexpected error = %v", err)
+			}
+
+			var g, w interface{}
+			_ = json.Unmarshal(got, &g)
+			_ = json.Unmarshal([]byte(tt.want), &w)
+			if !reflect.DeepEqual(g, w) {
+				t.Errorf("Merge() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	type course struct {
+		Name        string  `json:"name"`
+		Image       string  `json:"image"`
+		Description string  `json:"description"`
+		Credit      float32 `json:"credit"`
+	}
+	current := course{Name: "Course", Image: "image.png", Description: "About the course", Credit: 4}
+
+	tests := []struct {
+		name       string
+		patch      string
+		want       course
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			name:       "change one field",
+			patch:      `{"name":"New course"}`,
+			want:       course{Name: "New course", Image: "image.png", Description: "About the course", Credit: 4},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "remove a field",
+			patch:      `{"image":null,"credit":4}`,
+			want:       course{Name: "Course", Description: "About the course", Credit: 4},
+			wantFields: []string{"image"},
+		},
+		{name: "unchanged", patch: `{"name":"Course"}`, want: current},
+		{name: "not an object", patch: `["name"]`, want: current, wantErr: true},
+		{name: "wrong type", patch: `{"credit":"four"}`, want: current, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := current
+			fields, err := Apply(&got, []byte(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() got = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("Apply() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	id := uuid.MustParse("e8276e31-9a87-4cf1-a16c-080f9c5790d1")
+	columns := map[string]interface{}{"name": "New course", "excerpt": "Excerpt"}
+
+	query, args, err := Update("courses", columns, []string{"excerpt", "name"}, id, "default", 3)
+	if err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	want := "UPDATE courses SET excerpt = $1, name = $2, version = version + 1, updated_at = NOW() " +
+		"WHERE uuid = $3 AND tenant_id = $4 AND ($5 = 0 OR version = $5) AND deleted_at IS NULL RETURNING *"
+	if query != want {
+		t.Errorf("Update() query = %v, want %v", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"Excerpt", "New course", id, "default", 3}) {
+		t.Errorf("Update() args = %v", args)
+	}
+
+	if _, _, err := Update("courses", columns, []string{"uuid"}, id, "default", 0); err == nil {
+		t.Errorf("Update() expected an error patching a field that isn't allowed")
+	}
+}
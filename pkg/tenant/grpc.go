@@ -0,0 +1,45 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewUnaryServerInterceptor puts the tenant of every call in its context, the header is read
+// from the call metadata. It runs after the authentication.
+func NewUnaryServerInterceptor(res *Resolver) grpc.UnaryServerInterceptor {
+	key := strings.ToLower(res.header)
+
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		var header string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(key); len(values) > 0 {
+				header = values[0]
+			}
+		}
+
+		id, err := res.resolve(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, id), req)
+	}
+}
+
+// ContextToGRPC puts the tenant of the context in the metadata of the outgoing calls, under
+// the header the resolver reads
+func (res *Resolver) ContextToGRPC() kitgrpc.ClientRequestFunc {
+	key := strings.ToLower(res.header)
+
+	return func(ctx context.Context, md *metadata.MD) context.Context {
+		if id, ok := FromContext(ctx); ok {
+			(*md)[key] = []string{id}
+		}
+		return ctx
+	}
+}
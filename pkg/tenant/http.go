@@ -0,0 +1,76 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+// Resolver finds out the tenant of a request
+type Resolver struct {
+	header        string
+	defaultTenant string
+}
+
+// NewResolver creates a resolver from the configuration, falling back to the default header and tenant
+func NewResolver(cfg *config.Tenancy) *Resolver {
+	r := &Resolver{header: DefaultHeader, defaultTenant: DefaultTenant}
+	if cfg != nil {
+		if cfg.Header != "" {
+			r.header = cfg.Header
+		}
+		if cfg.Default != "" {
+			r.defaultTenant = cfg.Default
+		}
+	}
+	return r
+}
+
+// Resolve returns the tenant of the request. The tenant claim of the token wins, and a
+// header naming another tenant is refused. Without a tenant claim only admins, or any
+// caller when authentication is disabled, pick the tenant through the header.
+func (res *Resolver) Resolve(r *http.Request) (string, error) {
+	return res.resolve(r.Context(), r.Header.Get(res.header))
+}
+
+func (res *Resolver) resolve(ctx context.Context, header string) (string, error) {
+	claims, authenticated := auth.FromContext(ctx)
+
+	var id string
+	switch {
+	case authenticated && claims.Tenant != "":
+		if header != "" && header != claims.Tenant {
+			return "", errors.NewErrorf(errors.ErrCodeForbidden, "token doesn't grant access to tenant %s", header)
+		}
+		id = claims.Tenant
+	case header != "" && (!authenticated || claims.IsAdmin()):
+		id = header
+	case header != "":
+		return "", errors.NewErrorf(errors.ErrCodeForbidden, "token doesn't grant access to tenant %s", header)
+	default:
+		id = res.defaultTenant
+	}
+
+	if !Valid(id) {
+		return "", errors.NewErrorf(errors.ErrCodeInvalidArgument, "invalid tenant %q", id)
+	}
+	return id, nil
+}
+
+// NewHTTPHandler puts the tenant of every request in its context before handing it to the
+// next handler, failures are written by the error encoder. It runs after the authentication.
+func NewHTTPHandler(res *Resolver, next http.Handler, encode kithttp.ErrorEncoder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := res.Resolve(r)
+		if err != nil {
+			encode(r.Context(), err, w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
@@ -0,0 +1,76 @@
+package tenant
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/sumelms/microservice-course/pkg/auth"
+	"github.com/sumelms/microservice-course/pkg/config"
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	withClaims := func(tenant string, roles ...string) context.Context {
+		return auth.NewContext(context.Background(), &auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "d7b3e5f1-4c1a-4f0b-9a3e-5f7c2a1b0c9d"},
+			Tenant:           tenant,
+			Roles:            roles,
+		})
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		header   string
+		want     string
+		wantCode errors.ErrorCode
+		wantErr  bool
+	}{
+		{name: "default tenant", ctx: context.Background(), want: "acme"},
+		{name: "anonymous header", ctx: context.Background(), header: "globex", want: "globex"},
+		{name: "token tenant", ctx: withClaims("initech"), want: "initech"},
+		{name: "token tenant matching header", ctx: withClaims("initech"), header: "initech", want: "initech"},
+		{name: "token tenant conflicting header", ctx: withClaims("initech"), header: "globex", wantCode: errors.ErrCodeForbidden, wantErr: true},
+		{name: "admin header", ctx: withClaims("", auth.RoleAdmin), header: "globex", want: "globex"},
+		{name: "learner header", ctx: withClaims("", "student"), header: "globex", wantCode: errors.ErrCodeForbidden, wantErr: true},
+		{name: "learner without tenant", ctx: withClaims("", "student"), want: "acme"},
+		{name: "invalid tenant", ctx: context.Background(), header: "Not A Tenant", wantCode: errors.ErrCodeInvalidArgument, wantErr: true},
+	}
+
+	res := NewResolver(&config.Tenancy{Default: "acme"})
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", "/courses", nil).WithContext(tt.ctx)
+			if tt.header != "" {
+				r.Header.Set(DefaultHeader, tt.header)
+			}
+
+			got, err := res.Resolve(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if ierr, ok := err.(*errors.Error); tt.wantErr && (!ok || ierr.Code() != tt.wantCode) {
+				t.Errorf("Resolve() error = %v, want code %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	if _, err := Require(context.Background()); err == nil {
+		t.Errorf("Require() expected an error without a tenant")
+	}
+	if got, err := Require(NewContext(context.Background(), "acme")); err != nil || got != "acme" {
+		t.Errorf("Require() got = %v, %v, want acme", got, err)
+	}
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/sumelms/microservice-course/pkg/errors"
+)
+
+const (
+	DefaultHeader = "X-Tenant-ID"
+	DefaultTenant = "default"
+)
+
+type contextKey int
+
+const tenantContextKey contextKey = iota
+
+var pattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Valid tells whether the tenant identifier is well formed
+func Valid(id string) bool {
+	return pattern.MatchString(id)
+}
+
+// NewContext returns a context carrying the tenant
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantContextKey, id)
+}
+
+// FromContext returns the tenant of the request
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantContextKey).(string)
+	return id, ok && id != ""
+}
+
+// Require returns the tenant of the request, failing when the request has none
+func Require(ctx context.Context) (string, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return "", errors.NewErrorf(errors.ErrCodeInvalidArgument, "missing tenant")
+	}
+	return id, nil
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier reads and writes the trace context in the gRPC metadata
+type metadataCarrier struct {
+	md *metadata.MD
+}
+
+func (c metadataCarrier) Get(key string) string {
+	if values := c.md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	c.md.Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.md))
+	for key := range *c.md {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// GRPCToContext starts the span of the call, the child of the one in its traceparent metadata
+func GRPCToContext(ctx context.Context, md metadata.MD) context.Context {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier{md: &md})
+
+	method, ok := grpc.Method(ctx)
+	if !ok {
+		method = "grpc"
+	}
+	ctx, _ = Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer))
+	return ctx
+}
+
+// GRPCFinalizer ends the span of the call started by GRPCToContext
+func GRPCFinalizer(ctx context.Context, err error) {
+	End(trace.SpanFromContext(ctx), err)
+}
+
+// ContextToGRPC forwards the trace context of the outgoing call in its metadata
+func ContextToGRPC(ctx context.Context, md *metadata.MD) context.Context {
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier{md: md})
+	return ctx
+}
+
+var (
+	_ kitgrpc.ServerRequestFunc   = GRPCToContext
+	_ kitgrpc.ServerFinalizerFunc = GRPCFinalizer
+	_ kitgrpc.ClientRequestFunc   = ContextToGRPC
+)
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanID = "00f067aa0ba902b7"
+)
+
+func TestHTTPToContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	// The endpoint calls the service, which calls the course service over gRPC
+	var md metadata.MD
+	handler := kithttp.NewServer(
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			ctx, span := Start(ctx, "course.Course")
+			defer End(span, nil)
+
+			md = metadata.MD{}
+			ContextToGRPC(ctx, &md)
+			GRPCFinalizer(GRPCToContext(ctx, md), nil)
+			return struct{}{}, nil
+		},
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		kithttp.EncodeJSONResponse,
+		kithttp.ServerBefore(HTTPToContext),
+		kithttp.ServerFinalizer(HTTPFinalizer),
+	)
+	r := mux.NewRouter()
+	r.Handle("/courses/{uuid}", handler).Methods(http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, "/courses/e6a7cbd2-6b0a-4b5b-9a11-e0e0d4e3c6a1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range spans {
+		if got := span.SpanContext.TraceID().String(); got != traceID {
+			t.Errorf("span %s has the trace %s, want %s", span.Name, got, traceID)
+		}
+		byName[span.Name] = span
+	}
+
+	server, ok := byName["GET /courses/{uuid}"]
+	if !ok {
+		t.Fatalf("the request span isn't named after the route, got %v", byName)
+	}
+	if server.SpanKind != trace.SpanKindServer || server.Parent.SpanID().String() != parentSpanID {
+		t.Errorf("the request span doesn't continue the traceparent, parent %s", server.Parent.SpanID())
+	}
+	service := byName["course.Course"]
+	if service.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Errorf("the service span isn't a child of the request span")
+	}
+	if md.Get("traceparent") == nil {
+		t.Errorf("the traceparent isn't forwarded in the gRPC metadata")
+	}
+	if service.SpanContext.SpanID() != byName["grpc"].Parent.SpanID() {
+		t.Errorf("the gRPC span doesn't continue the trace of the service span")
+	}
+}
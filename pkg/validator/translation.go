@@ -0,0 +1,147 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
+	pttranslations "github.com/go-playground/validator/v10/translations/pt"
+)
+
+// DefaultLanguage is used when the client accepts none of the supported languages
+const DefaultLanguage = "en"
+
+type contextKey int
+
+const translatorContextKey contextKey = iota
+
+type language struct {
+	locale   locales.Translator
+	defaults func(*validator.Validate, ut.Translator) error
+}
+
+var languages = []language{
+	{locale: en.New(), defaults: entranslations.RegisterDefaultTranslations},
+	{locale: pt.New(), defaults: pttranslations.RegisterDefaultTranslations},
+	{locale: es.New(), defaults: estranslations.RegisterDefaultTranslations},
+}
+
+// messages are our own wording for the tags, they replace the defaults of the library
+var messages = map[string]map[string]string{
+	"required": {
+		"en": "This field is required",
+		"pt": "Este campo é obrigatório",
+		"es": "Este campo es obligatorio",
+	},
+	"email": {
+		"en": "Invalid email",
+		"pt": "E-mail inválido",
+		"es": "Correo electrónico inválido",
+	},
+}
+
+var uni = ut.New(languages[0].locale, languages[0].locale, languages[1].locale, languages[2].locale)
+
+func registerTranslations(v *validator.Validate) error {
+	for _, l := range languages {
+		trans, _ := uni.GetTranslator(l.locale.Locale())
+		if err := l.defaults(v, trans); err != nil {
+			return err
+		}
+		for tag, msg := range messages {
+			if err := registerMessage(v, trans, tag, msg[l.locale.Locale()]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func registerMessage(v *validator.Validate, trans ut.Translator, tag, msg string) error {
+	register := func(trans ut.Translator) error {
+		return trans.Add(tag, msg, true)
+	}
+	translate := func(trans ut.Translator, fe validator.FieldError) string {
+		t, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+		if err != nil {
+			return fe.Error()
+		}
+		return t
+	}
+	return v.RegisterTranslation(tag, trans, register, translate)
+}
+
+// Translator returns the translator of the preferred language in the Accept-Language
+// header, falling back to English when none of them is supported
+func Translator(acceptLanguage string) ut.Translator {
+	trans, _ := uni.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+	return trans
+}
+
+// NewContext returns a context carrying the translator
+func NewContext(ctx context.Context, trans ut.Translator) context.Context {
+	return context.WithValue(ctx, translatorContextKey, trans)
+}
+
+// TranslatorFromContext returns the translator of the request, English when there is none
+func TranslatorFromContext(ctx context.Context) ut.Translator {
+	if trans, ok := ctx.Value(translatorContextKey).(ut.Translator); ok {
+		return trans
+	}
+	trans, _ := uni.GetTranslator(DefaultLanguage)
+	return trans
+}
+
+// PopulateLanguage puts the translator matching the Accept-Language header in the context
+func PopulateLanguage(ctx context.Context, r *http.Request) context.Context {
+	return NewContext(ctx, Translator(r.Header.Get("Accept-Language")))
+}
+
+// parseAcceptLanguage lists the locales of the header by preference, the regional ones
+// followed by their base language, e.g. pt-BR;q=0.9 gives pt_BR and pt
+func parseAcceptLanguage(header string) []string {
+	type tag struct {
+		name string
+		q    float64
+	}
+
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.TrimSpace(fields[0])
+		if name == "" || name == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			if f = strings.TrimSpace(f); strings.HasPrefix(f, "q=") {
+				if parsed, err := strconv.ParseFloat(strings.TrimPrefix(f, "q="), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		tags = append(tags, tag{name: name, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	var names []string
+	for _, t := range tags {
+		lang, region, found := strings.Cut(t.name, "-")
+		lang = strings.ToLower(lang)
+		if found {
+			names = append(names, lang+"_"+strings.ToUpper(region))
+		}
+		names = append(names, lang)
+	}
+	return names
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestTranslator(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "no header", acceptLanguage: "", want: "en"},
+		{name: "supported language", acceptLanguage: "es", want: "es"},
+		{name: "regional language", acceptLanguage: "pt-BR", want: "pt"},
+		{name: "quality order", acceptLanguage: "en;q=0.5, es;q=0.9, de", want: "es"},
+		{name: "unsupported language", acceptLanguage: "de, fr;q=0.8", want: "en"},
+		{name: "wildcard", acceptLanguage: "*", want: "en"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Translator(tt.acceptLanguage).Locale(); got != tt.want {
+				t.Errorf("Translator() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_Translate(t *testing.T) {
+	type request struct {
+		Code string `json:"code" validate:"required,max=15"`
+	}
+
+	tests := []struct {
+		name           string
+		code           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "custom message", code: "", acceptLanguage: "es", want: "Este campo es obligatorio"},
+		{name: "default message", code: "A-CODE-LONGER-THAN-15", acceptLanguage: "en", want: "code must be a maximum of 15 characters in length"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewValidator().Validate(request{Code: tt.code})
+			ve, ok := err.(validator.ValidationErrors)
+			if !ok || len(ve) != 1 {
+				t.Fatalf("Validate() error = %v, want one validation error", err)
+			}
+			if got := ve[0].Translate(Translator(tt.acceptLanguage)); got != tt.want {
+				t.Errorf("Translate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
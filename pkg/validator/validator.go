@@ -1,6 +1,14 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is shared by every validator so the translations are registered only once
+var validate = newValidate()
 
 type Validator struct {
 	validator *validator.Validate
@@ -12,6 +20,28 @@ func (v Validator) Validate(i interface{}) error {
 
 func NewValidator() *Validator {
 	return &Validator{
-		validator: validator.New(),
+		validator: validate,
+	}
+}
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(jsonName)
+
+	if err := registerTranslations(v); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// jsonName reports the fields by the name the clients send them
+func jsonName(f reflect.StructField) string {
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
 	}
+	return name
 }
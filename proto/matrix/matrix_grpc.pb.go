@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: proto/matrix/matrix.proto
+
+package matrix
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MatrixServiceClient is the client API for MatrixService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MatrixServiceClient interface {
+	CreateMatrix(ctx context.Context, in *CreateMatrixRequest, opts ...grpc.CallOption) (*Matrix, error)
+	FindMatrix(ctx context.Context, in *FindMatrixRequest, opts ...grpc.CallOption) (*Matrix, error)
+	UpdateMatrix(ctx context.Context, in *UpdateMatrixRequest, opts ...grpc.CallOption) (*Matrix, error)
+	DeleteMatrix(ctx context.Context, in *DeleteMatrixRequest, opts ...grpc.CallOption) (*DeleteMatrixResponse, error)
+	CreateSubject(ctx context.Context, in *CreateSubjectRequest, opts ...grpc.CallOption) (*Subject, error)
+	FindSubject(ctx context.Context, in *FindSubjectRequest, opts ...grpc.CallOption) (*Subject, error)
+	DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*DeleteSubjectResponse, error)
+}
+
+type matrixServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatrixServiceClient(cc grpc.ClientConnInterface) MatrixServiceClient {
+	return &matrixServiceClient{cc}
+}
+
+func (c *matrixServiceClient) CreateMatrix(ctx context.Context, in *CreateMatrixRequest, opts ...grpc.CallOption) (*Matrix, error) {
+	out := new(Matrix)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/CreateMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) FindMatrix(ctx context.Context, in *FindMatrixRequest, opts ...grpc.CallOption) (*Matrix, error) {
+	out := new(Matrix)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/FindMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) UpdateMatrix(ctx context.Context, in *UpdateMatrixRequest, opts ...grpc.CallOption) (*Matrix, error) {
+	out := new(Matrix)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/UpdateMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) DeleteMatrix(ctx context.Context, in *DeleteMatrixRequest, opts ...grpc.CallOption) (*DeleteMatrixResponse, error) {
+	out := new(DeleteMatrixResponse)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/DeleteMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) CreateSubject(ctx context.Context, in *CreateSubjectRequest, opts ...grpc.CallOption) (*Subject, error) {
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/CreateSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) FindSubject(ctx context.Context, in *FindSubjectRequest, opts ...grpc.CallOption) (*Subject, error) {
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/FindSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*DeleteSubjectResponse, error) {
+	out := new(DeleteSubjectResponse)
+	err := c.cc.Invoke(ctx, "/sumelms.matrix.v1.MatrixService/DeleteSubject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatrixServiceServer is the server API for MatrixService service.
+// All implementations must embed UnimplementedMatrixServiceServer
+// for forward compatibility
+type MatrixServiceServer interface {
+	CreateMatrix(context.Context, *CreateMatrixRequest) (*Matrix, error)
+	FindMatrix(context.Context, *FindMatrixRequest) (*Matrix, error)
+	UpdateMatrix(context.Context, *UpdateMatrixRequest) (*Matrix, error)
+	DeleteMatrix(context.Context, *DeleteMatrixRequest) (*DeleteMatrixResponse, error)
+	CreateSubject(context.Context, *CreateSubjectRequest) (*Subject, error)
+	FindSubject(context.Context, *FindSubjectRequest) (*Subject, error)
+	DeleteSubject(context.Context, *DeleteSubjectRequest) (*DeleteSubjectResponse, error)
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+// UnimplementedMatrixServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMatrixServiceServer struct {
+}
+
+func (UnimplementedMatrixServiceServer) CreateMatrix(context.Context, *CreateMatrixRequest) (*Matrix, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) FindMatrix(context.Context, *FindMatrixRequest) (*Matrix, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) UpdateMatrix(context.Context, *UpdateMatrixRequest) (*Matrix, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) DeleteMatrix(context.Context, *DeleteMatrixRequest) (*DeleteMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) CreateSubject(context.Context, *CreateSubjectRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubject not implemented")
+}
+func (UnimplementedMatrixServiceServer) FindSubject(context.Context, *FindSubjectRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSubject not implemented")
+}
+func (UnimplementedMatrixServiceServer) DeleteSubject(context.Context, *DeleteSubjectRequest) (*DeleteSubjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubject not implemented")
+}
+func (UnimplementedMatrixServiceServer) mustEmbedUnimplementedMatrixServiceServer() {}
+
+// UnsafeMatrixServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatrixServiceServer will
+// result in compilation errors.
+type UnsafeMatrixServiceServer interface {
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+func RegisterMatrixServiceServer(s grpc.ServiceRegistrar, srv MatrixServiceServer) {
+	s.RegisterService(&MatrixService_ServiceDesc, srv)
+}
+
+func _MatrixService_CreateMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).CreateMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/CreateMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).CreateMatrix(ctx, req.(*CreateMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_FindMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).FindMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/FindMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).FindMatrix(ctx, req.(*FindMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_UpdateMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).UpdateMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/UpdateMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).UpdateMatrix(ctx, req.(*UpdateMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_DeleteMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).DeleteMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/DeleteMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).DeleteMatrix(ctx, req.(*DeleteMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_CreateSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).CreateSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/CreateSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).CreateSubject(ctx, req.(*CreateSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_FindSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).FindSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/FindSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).FindSubject(ctx, req.(*FindSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_DeleteSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).DeleteSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sumelms.matrix.v1.MatrixService/DeleteSubject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).DeleteSubject(ctx, req.(*DeleteSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MatrixService_ServiceDesc is the grpc.ServiceDesc for MatrixService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MatrixService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sumelms.matrix.v1.MatrixService",
+	HandlerType: (*MatrixServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMatrix",
+			Handler:    _MatrixService_CreateMatrix_Handler,
+		},
+		{
+			MethodName: "FindMatrix",
+			Handler:    _MatrixService_FindMatrix_Handler,
+		},
+		{
+			MethodName: "UpdateMatrix",
+			Handler:    _MatrixService_UpdateMatrix_Handler,
+		},
+		{
+			MethodName: "DeleteMatrix",
+			Handler:    _MatrixService_DeleteMatrix_Handler,
+		},
+		{
+			MethodName: "CreateSubject",
+			Handler:    _MatrixService_CreateSubject_Handler,
+		},
+		{
+			MethodName: "FindSubject",
+			Handler:    _MatrixService_FindSubject_Handler,
+		},
+		{
+			MethodName: "DeleteSubject",
+			Handler:    _MatrixService_DeleteSubject_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/matrix/matrix.proto",
+}
@@ -33,3 +33,10 @@ func NewTestDB(queries map[string]string) (*sqlx.DB, sqlmock.Sqlmock, map[string
 	mock.MatchExpectationsInOrder(false)
 	return db, mock, sqlStatements
 }
+
+// ExpectOutboxTx expects the transaction of a change writing its events to the outbox
+func ExpectOutboxTx(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO\s+outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}